@@ -0,0 +1,176 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package beacon
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/uxuycom/indexer/utils"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// blobCommitmentVersionKZG version byte of the blob versioned hashes
+const blobCommitmentVersionKZG = 0x01
+
+// Client beacon node api client fetching the eip-4844 blob sidecars
+type Client struct {
+	endpoint string
+	client   *utils.HttpClient
+
+	mu             sync.Mutex
+	genesisTime    uint64
+	secondsPerSlot uint64
+}
+
+type GenesisResponse struct {
+	Data struct {
+		GenesisTime string `json:"genesis_time"`
+	} `json:"data"`
+}
+
+type SpecResponse struct {
+	Data struct {
+		SecondsPerSlot string `json:"SECONDS_PER_SLOT"`
+	} `json:"data"`
+}
+
+type BlobSidecar struct {
+	Index         string        `json:"index"`
+	Blob          hexutil.Bytes `json:"blob"`
+	KzgCommitment hexutil.Bytes `json:"kzg_commitment"`
+	KzgProof      hexutil.Bytes `json:"kzg_proof"`
+}
+
+type BlobSidecarsResponse struct {
+	Data []*BlobSidecar `json:"data"`
+}
+
+func NewClient(endpoint string) *Client {
+	return &Client{
+		endpoint: strings.TrimRight(strings.TrimSpace(endpoint), "/"),
+		client:   utils.NewHttpClient(),
+	}
+}
+
+func (c *Client) call(ctx context.Context, path string, out interface{}) error {
+	apiUrl := fmt.Sprintf("%s/%s", c.endpoint, strings.TrimLeft(path, "/"))
+	return c.client.CallContext(ctx, "GET", apiUrl, out)
+}
+
+// Slot the beacon slot of the execution block timestamp, genesis & slot time are loaded once
+func (c *Client) Slot(ctx context.Context, blockTime uint64) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.secondsPerSlot == 0 {
+		genesis := &GenesisResponse{}
+		if err := c.call(ctx, "eth/v1/beacon/genesis", genesis); err != nil {
+			return 0, fmt.Errorf("beacon genesis err:%v", err)
+		}
+		spec := &SpecResponse{}
+		if err := c.call(ctx, "eth/v1/config/spec", spec); err != nil {
+			return 0, fmt.Errorf("beacon spec err:%v", err)
+		}
+
+		genesisTime, err := strconv.ParseUint(genesis.Data.GenesisTime, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("beacon genesis time[%s] invalid", genesis.Data.GenesisTime)
+		}
+		secondsPerSlot, err := strconv.ParseUint(spec.Data.SecondsPerSlot, 10, 64)
+		if err != nil || secondsPerSlot == 0 {
+			return 0, fmt.Errorf("beacon seconds per slot[%s] invalid", spec.Data.SecondsPerSlot)
+		}
+		c.genesisTime, c.secondsPerSlot = genesisTime, secondsPerSlot
+	}
+
+	if blockTime < c.genesisTime {
+		return 0, fmt.Errorf("block time[%d] before beacon genesis[%d]", blockTime, c.genesisTime)
+	}
+	return (blockTime - c.genesisTime) / c.secondsPerSlot, nil
+}
+
+// Blobs fetches the blobs of the versioned hashes included in the execution block of the timestamp.
+// Each blob is verified against its kzg commitment, missing blobs (e.g. pruned by the node) fail
+func (c *Client) Blobs(ctx context.Context, blockTime uint64, hashes []string) (map[string][]byte, error) {
+	slot, err := c.Slot(ctx, blockTime)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BlobSidecarsResponse{}
+	if err = c.call(ctx, fmt.Sprintf("eth/v1/beacon/blob_sidecars/%d", slot), result); err != nil {
+		return nil, fmt.Errorf("beacon blob sidecars of slot[%d] err:%v", slot, err)
+	}
+
+	wanted := make(map[string]struct{}, len(hashes))
+	for _, h := range hashes {
+		wanted[strings.ToLower(h)] = struct{}{}
+	}
+
+	blobs := make(map[string][]byte, len(hashes))
+	for _, sidecar := range result.Data {
+		hash := strings.ToLower(VersionedHash(sidecar.KzgCommitment).String())
+		if _, ok := wanted[hash]; !ok {
+			continue
+		}
+		if err = verify(sidecar); err != nil {
+			return nil, fmt.Errorf("beacon blob sidecar[%s] of slot[%d] err:%v", hash, slot, err)
+		}
+		blobs[hash] = sidecar.Blob
+	}
+
+	for hash := range wanted {
+		if _, ok := blobs[hash]; !ok {
+			return nil, fmt.Errorf("beacon blob sidecar[%s] of slot[%d] not found", hash, slot)
+		}
+	}
+	return blobs, nil
+}
+
+// VersionedHash the eip-4844 versioned hash of the kzg commitment
+func VersionedHash(commitment []byte) common.Hash {
+	h := common.Hash(sha256.Sum256(commitment))
+	h[0] = blobCommitmentVersionKZG
+	return h
+}
+
+func verify(sidecar *BlobSidecar) error {
+	var (
+		blob       kzg4844.Blob
+		commitment kzg4844.Commitment
+		proof      kzg4844.Proof
+	)
+	if len(sidecar.Blob) != len(blob) || len(sidecar.KzgCommitment) != len(commitment) || len(sidecar.KzgProof) != len(proof) {
+		return fmt.Errorf("blob / commitment / proof size invalid")
+	}
+	copy(blob[:], sidecar.Blob)
+	copy(commitment[:], sidecar.KzgCommitment)
+	copy(proof[:], sidecar.KzgProof)
+	return kzg4844.VerifyBlobProof(blob, commitment, proof)
+}
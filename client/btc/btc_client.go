@@ -32,7 +32,6 @@ import (
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btclog"
 	"github.com/uxuycom/indexer/config"
-	"github.com/uxuycom/indexer/xylog"
 	"math/big"
 	"net/url"
 	"os"
@@ -106,19 +105,7 @@ func (c *BtcClient) BlockNumber(ctx context.Context) (uint64, error) {
 	if err != nil {
 		return 0, fmt.Errorf("get status data error[%v]", err)
 	}
-	block, err1 := c.OrdClient.BlockNumber(ctx)
-	if err1 != nil {
-		return uint64(count) - 1, err1
-	}
-	if block == 0 {
-		return 0, fmt.Errorf("get ord blockNumber error. block is zero")
-	}
-	xylog.Logger.Infof("BlockNumber block:%v", block)
-	blockNumber := uint64(count)
-	if count > block {
-		blockNumber = uint64(block)
-	}
-	return blockNumber, nil
+	return uint64(count), nil
 }
 
 func (c *BtcClient) HeaderByNumber(ctx context.Context, number *big.Int) (*btcjson.GetBlockVerboseResult, error) {
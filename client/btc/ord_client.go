@@ -112,6 +112,21 @@ func (c *OrdClient) BlockNumber(ctx context.Context) (number int64, err error) {
 	return number, nil
 }
 
+// OrdHeight latest block indexed by the ord server, its balance api reflects this height only
+func (c *OrdClient) OrdHeight(ctx context.Context) (int64, error) {
+	path := fmt.Sprintf("api/v1/node/info")
+	result := &NodeInfoResponse{}
+
+	apiUrl := fmt.Sprintf("%s/%s", c.endpoint, strings.TrimLeft(path, "/"))
+	if err := c.client.CallContext(ctx, "GET", apiUrl, &result); err != nil {
+		return 0, err
+	}
+	if result.Data == nil || result.Data.ChainInfo == nil {
+		return 0, fmt.Errorf("ord node info empty")
+	}
+	return result.Data.ChainInfo.OrdHeight, nil
+}
+
 func (c *OrdClient) checkBlockNumber(number int64) (int64, int64) {
 	bNumber, ok := c.blockTimeMap.Load("blockNumber")
 	bTime, tok := c.blockTimeMap.Load("blockTime")
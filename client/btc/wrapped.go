@@ -24,6 +24,7 @@ package btc
 
 import (
 	"context"
+	"github.com/btcsuite/btcd/btcjson"
 	"github.com/ethereum/go-ethereum"
 	"github.com/uxuycom/indexer/client/xycommon"
 	"github.com/uxuycom/indexer/config"
@@ -62,21 +63,12 @@ func (b BClient) BlockByNumber(ctx context.Context, number *big.Int) (*xycommon.
 		return nil, err
 	}
 
-	blockEvent, errOrd := b.btcClient.OrdClient.BlockByHash(ctx, block.Hash)
-	if errOrd != nil {
-		return nil, errOrd
-	}
-	xylog.Logger.Infof("btc BlockNumber blockEvent[%v]", blockEvent)
-	if len(blockEvent.Txs) > 0 {
-		xylog.Logger.Infof("btc BlockNumber blockEvent txs : [%v]", blockEvent.Txs)
-	}
 	rpcBlock := xycommon.RpcBlock{
 		Number:     number,
 		Hash:       block.Hash,
 		Time:       uint64(block.Time),
 		RpcTxs:     block.Tx,
 		ParentHash: block.PreviousHash,
-		Txs:        blockEvent.Txs, // get txs from ord
 	}
 	return &rpcBlock, nil
 }
@@ -106,6 +98,10 @@ func (b BClient) TransactionReceipt(ctx context.Context, txHash string) (*xycomm
 	return nil, nil
 }
 
+func (b BClient) BlockReceipts(ctx context.Context, number *big.Int) ([]*xycommon.RpcReceipt, error) {
+	return nil, nil
+}
+
 func (b BClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]xycommon.RpcLog, error) {
 	return nil, nil
 }
@@ -114,10 +110,26 @@ func (b BClient) GetAddressBalanceByTick(ctx context.Context, address, tick stri
 	return b.btcClient.OrdClient.GetAddressBalanceByTick(ctx, address, tick)
 }
 
+func (b BClient) OrdHeight(ctx context.Context) (int64, error) {
+	return b.btcClient.OrdClient.OrdHeight(ctx)
+}
+
 func (b BClient) GetInscription(ctx context.Context, inscriptionId string) (ins *xycommon.RpcOkxInscription, err error) {
 	return b.btcClient.OrdClient.GetInscription(ctx, inscriptionId)
 }
 
+func (b BClient) GetRawTransactionVerbose(ctx context.Context, txHash string) (*btcjson.TxRawResult, error) {
+	return b.btcClient.GetRawTransactionVerbose(ctx, txHash)
+}
+
+func (b BClient) GetMultiRawTransactionVerbose(ctx context.Context, txHashes []string) (map[string]*btcjson.TxRawResult, error) {
+	return b.btcClient.GetMultiRawTransactionVerbose(ctx, txHashes)
+}
+
+func (b BClient) GetBlockVerbose(ctx context.Context, blockHash string) (*btcjson.GetBlockVerboseResult, error) {
+	return b.btcClient.GetBlockVerbose(ctx, blockHash)
+}
+
 func (b BClient) GetRunes(ctx context.Context) ([]xycommon.RpcOrdRunes, error) {
 	return b.btcClient.OrdinalsClient.GetRunes(ctx)
 }
@@ -39,11 +39,14 @@ import (
 // RawClient defines typed wrappers for the Ethereum RPC API.
 type RawClient struct {
 	c *rpc.Client
+
+	// finality the node rejects queries of unfinalized blocks
+	finality bool
 }
 
 // NewClient creates a client that uses the given RPC client.
 func NewClient(c *rpc.Client) *RawClient {
-	return &RawClient{c}
+	return &RawClient{c: c}
 }
 
 // Close closes the underlying RPC connection.
@@ -95,7 +98,7 @@ func (ec *RawClient) CallContext(ctx context.Context, result interface{}, method
 			return rpc.ErrNoResult
 		}
 
-		if err.Error() == "cannot query unfinalized data" {
+		if ec.finality && err.Error() == "cannot query unfinalized data" {
 			return rpc.ErrNoResult
 		}
 
@@ -234,6 +237,15 @@ func (ec *RawClient) TransactionSender(ctx context.Context, txHash common.Hash,
 
 // TransactionReceipt returns the receipt of a transaction by transaction hash.
 // Note that the receipt is not available for pending transactions.
+func (ec *RawClient) BlockReceipts(ctx context.Context, number *big.Int) ([]*RpcReceipt, error) {
+	var rs []*RpcReceipt
+	err := ec.CallContext(ctx, &rs, "eth_getBlockReceipts", toBlockNumArg(number))
+	if err == nil && rs == nil {
+		return nil, ethereum.NotFound
+	}
+	return rs, err
+}
+
 func (ec *RawClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*RpcReceipt, error) {
 	var r *RpcReceipt
 	err := ec.CallContext(ctx, &r, "eth_getTransactionReceipt", txHash)
@@ -30,6 +30,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
 	"math/big"
 	"time"
 )
@@ -39,16 +40,17 @@ type EClient struct {
 	rawClient *RawClient
 }
 
-// Dial connects a client to the given URL.
-func Dial(rawurl string) (*EClient, error) {
+// Dial connects a client to the rpc of the chain.
+func Dial(chainCfg *config.ChainConfig) (*EClient, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
 
-	client, err := DialContext(ctx, rawurl)
+	client, err := DialContext(ctx, chainCfg.Rpc)
 
 	if err != nil {
 		return nil, err
 	}
+	client.finality = chainCfg.Finality
 	return &EClient{rawClient: client}, nil
 }
 
@@ -137,6 +139,11 @@ func (ec *EClient) convertTransaction(tx *RpcTransaction) *xycommon.RpcTransacti
 	if tx.To != nil {
 		toAddr = tx.To.String()
 	}
+
+	var blobHashes []string
+	for _, h := range tx.BlobVersionedHashes {
+		blobHashes = append(blobHashes, h.String())
+	}
 	return &xycommon.RpcTransaction{
 		BlockHash:   tx.BlockHash.String(),
 		BlockNumber: tx.BlockNumber.ToInt(),
@@ -150,6 +157,8 @@ func (ec *EClient) convertTransaction(tx *RpcTransaction) *xycommon.RpcTransacti
 		Value:       tx.Value.ToInt(),
 		Gas:         big.NewInt(0).SetUint64(uint64(tx.Gas)),
 		GasPrice:    tx.GasPrice.ToInt(),
+
+		BlobVersionedHashes: blobHashes,
 	}
 }
 
@@ -252,6 +261,22 @@ func (ec *EClient) convertLog(l *RpcLog) *xycommon.RpcLog {
 	}
 }
 
+// BlockReceipts returns the receipts of all transactions in the block, eth_getBlockReceipts.
+func (ec *EClient) BlockReceipts(ctx context.Context, number *big.Int) ([]*xycommon.RpcReceipt, error) {
+	rs, err := ec.rawClient.BlockReceipts(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	receipts := make([]*xycommon.RpcReceipt, 0, len(rs))
+	for _, r := range rs {
+		if r != nil {
+			receipts = append(receipts, ec.convertReceipt(r))
+		}
+	}
+	return receipts, nil
+}
+
 // TransactionReceipt returns the receipt of a transaction by transaction hash.
 // Note that the receipt is not available for pending transactions.
 func (ec *EClient) TransactionReceipt(ctx context.Context, txHashStr string) (*xycommon.RpcReceipt, error) {
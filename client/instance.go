@@ -23,20 +23,43 @@
 package client
 
 import (
+	"context"
 	"github.com/uxuycom/indexer/client/btc"
 	"github.com/uxuycom/indexer/client/evm"
 	"github.com/uxuycom/indexer/client/xycommon"
 	"github.com/uxuycom/indexer/config"
 	"github.com/uxuycom/indexer/model"
+	"time"
 )
 
+// IdentifyChain resolves the chain id of an evm rpc configured without the chain name & id,
+// the chain profile of the id is applied
+func IdentifyChain(cfg *config.Config) error {
+	c, err := evm.Dial(&cfg.Chain)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	chainId, err := c.ChainID(ctx)
+	if err != nil {
+		return err
+	}
+	cfg.Chain.ChainId = int(chainId.Int64())
+	config.ApplyChainProfile(cfg)
+	return nil
+}
+
 func NewRPCClient(chainCfg *config.ChainConfig) (xycommon.IRPCClient, error) {
 
 	switch chainCfg.ChainGroup {
 	case model.BtcChainGroup:
 		return btc.Dial(chainCfg)
 	default:
-		return evm.Dial(chainCfg.Rpc)
+		return evm.Dial(chainCfg)
 	}
 
 }
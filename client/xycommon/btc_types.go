@@ -43,6 +43,7 @@ type BlockEvent struct {
 	To                *OkxAddress `json:"to"`
 	Valid             bool        `json:"valid"`
 	Msg               string      `json:"msg"`
+	Content           string      `json:"content,omitempty"` // inscription content parsed from witness
 }
 
 type Tx struct {
@@ -76,6 +77,7 @@ type Inscription struct {
 	LimitPerMint decimal.Decimal `json:"limit_per_mint"`
 	TotalSupply  decimal.Decimal `json:"total_supply"`
 	Decimals     int8            `json:"decimals"`
+	SelfMint     bool            `json:"self_mint"`
 	Owner        string          `json:"owner"`
 	Number       int64           `json:"number"`
 	Content      string          `json:"content"`
@@ -87,6 +89,8 @@ type InscriptionContent struct {
 	Tick         string `json:"tick"`
 	Max          string `json:"max"`
 	LimitPerMint string `json:"lim"`
+	Decimals     string `json:"dec"`
+	SelfMint     string `json:"self_mint"`
 }
 
 type RpcOkxBalance struct {
@@ -46,6 +46,8 @@ type IRPCClient interface {
 
 	TransactionReceipt(ctx context.Context, txHash string) (*RpcReceipt, error)
 
+	BlockReceipts(ctx context.Context, number *big.Int) ([]*RpcReceipt, error)
+
 	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]RpcLog, error)
 }
 
@@ -95,6 +97,12 @@ type RpcTransaction struct {
 	Events      []RpcLog       `json:"events"`
 	Receipt     []RpcReceipt   `json:"receipt"`
 	Status      int64          `json:"status"`
+	// BlobVersionedHashes eip-4844 blob versioned hashes of type-3 txs
+	BlobVersionedHashes []string `json:"blobVersionedHashes,omitempty"`
+	// Blobs blob data fetched from the beacon node, in the versioned hashes order
+	Blobs [][]byte `json:"blobs,omitempty"`
+	// SubIndex position of a smart account inner call within the tx, each call is indexed as a tx of its own
+	SubIndex int `json:"subIndex,omitempty"`
 }
 
 type RpcLog struct {
@@ -77,6 +77,14 @@ func main() {
 		xylog.InitLog(lv, cfg.LogPath)
 	}
 
+	// only the rpc configured, the chain is identified by its chain id
+	if cfg.Chain.ChainName == "" {
+		if err := client.IdentifyChain(&cfg); err != nil {
+			xylog.Logger.Fatalf("identify chain err:%v", err)
+		}
+		xylog.Logger.Infof("chain identified, name[%s], id[%d]", cfg.Chain.ChainName, cfg.Chain.ChainId)
+	}
+
 	dbClient, err := storage.NewDbClient(&cfg.Database)
 	if err != nil {
 		xylog.Logger.Fatalf("db init err:%v", err)
@@ -90,14 +98,16 @@ func main() {
 	dCache := dcache.NewManager(dbClient, cfg.Chain.ChainName)
 
 	// init protocols
-	protocol.InitProtocols(dCache)
+	if err = protocol.InitProtocols(&cfg, dCache); err != nil {
+		xylog.Logger.Fatalf("init protocols err:%v", err)
+	}
 
 	// init task
 	task.InitTask(dbClient, &cfg)
 
 	// Listen for SIGINT and SIGTERM signals
 	quit := make(chan os.Signal, 1)
-	dEvent := devents.NewDEvents(context.TODO(), dbClient)
+	dEvent := devents.NewDEvents(context.TODO(), dbClient, cfg.Chain.ChainGroup)
 	exp := explorer.NewExplorer(rpcClient, dbClient, &cfg, dCache, dEvent, quit)
 	go exp.Scan()
 	go exp.Index()
@@ -34,18 +34,117 @@ type ScanConfig struct {
 	BlockBatchWorkers uint64 `json:"block_batch_workers" mapstructure:"block_batch_workers"`
 	TxBatchWorkers    uint64 `json:"tx_batch_workers" mapstructure:"tx_batch_workers"`
 	DelayedBlockNum   uint64 `json:"delayed_block_num" mapstructure:"delayed_block_num"`
+	// TxParseWorkers concurrent tick shards parsed within a block, 0 means the number of cpus
+	TxParseWorkers uint64 `json:"tx_parse_workers" mapstructure:"tx_parse_workers"`
 }
 
 type ChainConfig struct {
-	ChainId     int              `json:"chain_id" mapstructure:"chain_id"`
-	ChainName   string           `json:"chain_name" mapstructure:"chain_name"`
-	Rpc         string           `json:"rpc"`
-	OrdRpc      string           `json:"ord_rpc" mapstructure:"ord_rpc"`
-	OrdinalsRpc string           `json:"ordinals_rpc" mapstructure:"ordinals_rpc"`
-	Testnet     bool             `json:"testnet"`
-	UserName    string           `json:"username"`
-	PassWord    string           `json:"password"`
-	ChainGroup  model.ChainGroup `json:"chain_group" mapstructure:"chain_group"`
+	ChainId     int    `json:"chain_id" mapstructure:"chain_id"`
+	ChainName   string `json:"chain_name" mapstructure:"chain_name"`
+	Rpc         string `json:"rpc"`
+	OrdRpc      string `json:"ord_rpc" mapstructure:"ord_rpc"`
+	OrdinalsRpc string `json:"ordinals_rpc" mapstructure:"ordinals_rpc"`
+	// BeaconRpc beacon node api fetching the eip-4844 blobs of type-3 txs, required by the blobs rule,
+	// an archive node keeping all blob sidecars is required to sync blocks older than the blob retention window
+	BeaconRpc string `json:"beacon_rpc" mapstructure:"beacon_rpc"`
+	// BalanceCrossCheck compares the local brc-20 balances with the ord api and logs divergences,
+	// only the blocks at the ord server height are checked, one at a time
+	BalanceCrossCheck bool             `json:"balance_cross_check" mapstructure:"balance_cross_check"`
+	Testnet           bool             `json:"testnet"`
+	UserName          string           `json:"username"`
+	PassWord          string           `json:"password"`
+	ChainGroup        model.ChainGroup `json:"chain_group" mapstructure:"chain_group"`
+	// Rules protocol rule schedule, each rule value takes effect from its activation height
+	Rules []*RuleActivation `json:"rules"`
+	// Marketplaces marketplace contracts whose events move tokens between addresses
+	Marketplaces []*MarketplaceConfig `json:"marketplaces"`
+	// Bridges cross-chain bridge contracts locking, releasing & minting tokens
+	Bridges []*BridgeConfig `json:"bridges"`
+	// Wrappers wrapper contracts issuing erc-20 tokens against locked inscription tokens
+	Wrappers []*WrapperConfig `json:"wrappers"`
+	// Finality the rpc rejects queries of unfinalized blocks, defaults to the chain profile
+	Finality bool `json:"finality"`
+	// MaxLogsRange maximum block range of one eth_getLogs query, 0 unbounded, defaults to the chain profile
+	MaxLogsRange uint64 `json:"max_logs_range" mapstructure:"max_logs_range"`
+	// BlockReceipts fetch the receipts of a block by eth_getBlockReceipts, defaults to the chain profile
+	BlockReceipts bool `json:"block_receipts" mapstructure:"block_receipts"`
+}
+
+// RuleActivation protocol rule value active from the block height
+type RuleActivation struct {
+	Protocol string `json:"protocol"`
+	Rule     string `json:"rule"`
+	Height   uint64 `json:"height"`
+	Value    string `json:"value"`
+}
+
+// MarketplaceConfig marketplace contract event mapped to token transfers
+type MarketplaceConfig struct {
+	Name     string            `json:"name"`
+	Protocol string            `json:"protocol"` // token protocol, asc-20 by default
+	Contract string            `json:"contract"`
+	AbiFile  string            `json:"abi_file" mapstructure:"abi_file"`
+	Event    string            `json:"event"`
+	Fields   MarketplaceFields `json:"fields"`
+	Decimals int32             `json:"decimals"` // amount decimals of the event, 0 for raw token amounts
+}
+
+// MarketplaceFields event field names of the transfer values
+type MarketplaceFields struct {
+	Tick   string `json:"tick"` // string tick, or bytes32 / indexed string tick hash
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Amount string `json:"amount"`
+}
+
+// BridgeConfig bridge contract events, lock moves tokens into the contract, release pays them out
+// and mint credits tokens locked on another chain. The name identifies the bridge on all chains
+type BridgeConfig struct {
+	Name     string             `json:"name"`
+	Protocol string             `json:"protocol"` // token protocol, asc-20 by default
+	Contract string             `json:"contract"`
+	AbiFile  string             `json:"abi_file" mapstructure:"abi_file"`
+	Decimals int32              `json:"decimals"` // amount decimals of the events, 0 for raw token amounts
+	Lock     *BridgeEventConfig `json:"lock"`
+	Release  *BridgeEventConfig `json:"release"`
+	Mint     *BridgeEventConfig `json:"mint"`
+}
+
+// BridgeEventConfig bridge event & its field names
+type BridgeEventConfig struct {
+	Event  string       `json:"event"`
+	Fields BridgeFields `json:"fields"`
+}
+
+// BridgeFields event field names of the bridge transfer values
+type BridgeFields struct {
+	Id     string `json:"id"`   // transfer id shared by the lock & its release / mint
+	Tick   string `json:"tick"` // string tick, or bytes32 / indexed string tick hash
+	From   string `json:"from"` // sender on the source chain, optional on release & mint
+	To     string `json:"to"`   // recipient on the destination chain, optional on lock
+	Amount string `json:"amount"`
+	Chain  string `json:"chain"` // optional, destination chain of the lock, source chain of the release & mint
+}
+
+// WrapperConfig inscription tokens transferred to the wrapper address are locked & wrapped into the erc-20 contract,
+// the erc-20 mint & burn events change the wrapped supply which must always equal the locked balance
+type WrapperConfig struct {
+	Name     string              `json:"name"`
+	Protocol string              `json:"protocol"` // token protocol, asc-20 by default
+	Tick     string              `json:"tick"`
+	Address  string              `json:"address"`  // holder of the locked inscription tokens
+	Contract string              `json:"contract"` // erc-20 contract, the wrapper address if empty
+	AbiFile  string              `json:"abi_file" mapstructure:"abi_file"`
+	Decimals int32               `json:"decimals"` // erc-20 decimals, 0 for raw token amounts
+	Mint     *WrapperEventConfig `json:"mint"`
+	Burn     *WrapperEventConfig `json:"burn"`
+}
+
+// WrapperEventConfig erc-20 mint / burn event & its field names
+type WrapperEventConfig struct {
+	Event   string `json:"event"`
+	Account string `json:"account"` // optional, recipient of the mint, holder of the burn
+	Amount  string `json:"amount"`
 }
 
 type StatConfig struct {
@@ -54,11 +153,13 @@ type StatConfig struct {
 }
 
 type IndexFilter struct {
-	Whitelist *struct {
-		Ticks     []string `json:"ticks"`
-		Protocols []string `json:"protocols"`
-	} `json:"whitelist"`
-	EventTopics []string `json:"event_topics" mapstructure:"event_topics"`
+	Whitelist   *Whitelist `json:"whitelist"`
+	EventTopics []string   `json:"event_topics" mapstructure:"event_topics"`
+}
+
+type Whitelist struct {
+	Ticks     []string `json:"ticks"`
+	Protocols []string `json:"protocols"`
 }
 
 // DatabaseConfig database config
@@ -115,6 +216,7 @@ type CacheConfig struct {
 
 func LoadConfig(cfg *Config, configFile string) {
 	UnmarshalConfig(configFile, cfg)
+	ApplyChainProfile(cfg)
 }
 
 func LoadJsonRpcConfig(cfg *RpcConfig, configFile string) {
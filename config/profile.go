@@ -0,0 +1,221 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package config
+
+import (
+	"fmt"
+	"github.com/spf13/viper"
+	"github.com/uxuycom/indexer/model"
+	"strings"
+)
+
+// ChainProfile chain defaults & rpc quirks, unspecified config fields are filled from the profile of the chain
+type ChainProfile struct {
+	Name            string
+	ChainId         int
+	ChainGroup      model.ChainGroup
+	DelayedBlockNum uint64
+	// Finality the rpc rejects queries of unfinalized blocks ("cannot query unfinalized data"), retried as not found
+	Finality bool
+	// MaxLogsRange maximum block range of one eth_getLogs query, 0 unbounded
+	MaxLogsRange uint64
+	// BlockReceipts eth_getBlockReceipts supported, otherwise receipts are fetched per tx
+	BlockReceipts bool
+	// ExchangeEvents txs with scanned events are asc-20 exchange events, not parsed from their calldata
+	ExchangeEvents bool
+	// Protocols protocols indexed on the chain, the default protocol whitelist, all protocols when empty
+	Protocols []string
+	// EventTopics default event topics scanned besides the configured contracts
+	EventTopics []string
+}
+
+// DefaultChainProfile defaults of evm chains without a registered profile
+var DefaultChainProfile = &ChainProfile{
+	ChainGroup:      model.EvmChainGroup,
+	DelayedBlockNum: 12,
+	MaxLogsRange:    1000,
+}
+
+var chainProfiles = []*ChainProfile{
+	{
+		Name:       model.ChainBTC,
+		ChainGroup: model.BtcChainGroup,
+		Protocols:  []string{"brc-20", "runes"},
+	},
+	{
+		Name:            model.ChainAVAX,
+		ChainId:         43114,
+		ChainGroup:      model.EvmChainGroup,
+		DelayedBlockNum: 10,
+		Finality:        true,
+		MaxLogsRange:    2048,
+		ExchangeEvents:  true,
+		Protocols:       []string{"asc-20", "ethscriptions"},
+		EventTopics: []string{
+			"0xe2750d6418e3719830794d3db788aa72febcd657bcd18ed8f1facdbf61a69a9a",
+			"0x3efe873bf4d1c1061b9980e7aed9b564e024844522ec8c80aec160809948ef77",
+			"0x8cdf9e10a7b20e7a9c4e778fc3eb28f2766e438a9856a62eac39fbd2be98cbc2",
+		},
+	},
+	{
+		Name:            model.ChainETH,
+		ChainId:         1,
+		ChainGroup:      model.EvmChainGroup,
+		DelayedBlockNum: 10,
+		MaxLogsRange:    10000,
+		BlockReceipts:   true,
+		Protocols:       []string{"erc-20", "ethscriptions"},
+		EventTopics: []string{
+			"0xf30861289185032f511ff94a8127e470f3d0e6230be4925cb6fad33f3436dffb",
+			"0xf1d95ed4d1680e6f665104f19c296ae52c1f64cd8114e84d55dc6349dbdafea3",
+		},
+	},
+	{
+		Name:            model.ChainBSC,
+		ChainId:         56,
+		ChainGroup:      model.EvmChainGroup,
+		DelayedBlockNum: 15,
+		MaxLogsRange:    5000,
+		BlockReceipts:   true,
+		Protocols:       []string{"bsc-20", "ethscriptions"},
+	},
+	{
+		Name:            model.ChainPolygon,
+		ChainId:         137,
+		ChainGroup:      model.EvmChainGroup,
+		DelayedBlockNum: 64,
+		MaxLogsRange:    3500,
+		BlockReceipts:   true,
+		Protocols:       []string{"prc-20", "ethscriptions"},
+	},
+}
+
+// LookupChainProfile the registered profile of the chain name, or of the chain id if the name is empty, nil if unknown
+func LookupChainProfile(name string, chainId int) *ChainProfile {
+	for _, p := range chainProfiles {
+		if name != "" && strings.EqualFold(p.Name, name) {
+			return p
+		}
+		if name == "" && chainId != 0 && p.ChainId == chainId {
+			return p
+		}
+	}
+	return nil
+}
+
+// ChainProfileOf the registered profile of the chain name, the default evm profile if unknown
+func ChainProfileOf(name string) *ChainProfile {
+	if p := LookupChainProfile(name, 0); p != nil {
+		return p
+	}
+	return DefaultChainProfile
+}
+
+// ApplyChainProfile fills the chain identity & the unspecified config fields from the chain profile,
+// keys set explicitly in the config file are kept even if zero
+func ApplyChainProfile(cfg *Config) {
+	p := LookupChainProfile(cfg.Chain.ChainName, cfg.Chain.ChainId)
+	if p == nil {
+		p = DefaultChainProfile
+		if cfg.Chain.ChainName == "" && cfg.Chain.ChainId != 0 {
+			cfg.Chain.ChainName = fmt.Sprintf("evm-%d", cfg.Chain.ChainId)
+		}
+	}
+
+	if cfg.Chain.ChainName == "" {
+		cfg.Chain.ChainName = p.Name
+	}
+	if cfg.Chain.ChainId == 0 {
+		cfg.Chain.ChainId = p.ChainId
+	}
+	if cfg.Chain.ChainGroup == "" {
+		cfg.Chain.ChainGroup = p.ChainGroup
+	}
+	if !viper.IsSet("scan.delayed_block_num") {
+		cfg.Scan.DelayedBlockNum = p.DelayedBlockNum
+	}
+	if !viper.IsSet("chain.finality") {
+		cfg.Chain.Finality = p.Finality
+	}
+	if !viper.IsSet("chain.max_logs_range") {
+		cfg.Chain.MaxLogsRange = p.MaxLogsRange
+	}
+	if !viper.IsSet("chain.block_receipts") {
+		cfg.Chain.BlockReceipts = p.BlockReceipts
+	}
+
+	if len(p.EventTopics) > 0 || len(p.Protocols) > 0 {
+		if cfg.Filters == nil {
+			cfg.Filters = &IndexFilter{}
+		}
+		if len(cfg.Filters.EventTopics) == 0 {
+			cfg.Filters.EventTopics = append([]string(nil), p.EventTopics...)
+		}
+
+		// the configured contracts keep their events indexed
+		if len(p.Protocols) > 0 && !viper.IsSet("filters.whitelist.protocols") {
+			if cfg.Filters.Whitelist == nil {
+				cfg.Filters.Whitelist = &Whitelist{}
+			}
+			cfg.Filters.Whitelist.Protocols = appendProtocols(append([]string(nil), p.Protocols...), contractProtocols(&cfg.Chain)...)
+		}
+	}
+}
+
+// contractProtocols token protocols of the configured marketplace, bridge & wrapper contracts, asc-20 by default
+func contractProtocols(chain *ChainConfig) []string {
+	protocols := make([]string, 0)
+	for _, item := range chain.Marketplaces {
+		protocols = append(protocols, item.Protocol)
+	}
+	for _, item := range chain.Bridges {
+		protocols = append(protocols, item.Protocol)
+	}
+	for _, item := range chain.Wrappers {
+		protocols = append(protocols, item.Protocol)
+	}
+
+	for i, v := range protocols {
+		if v = strings.ToLower(strings.TrimSpace(v)); v == "" {
+			v = "asc-20"
+		}
+		protocols[i] = v
+	}
+	return protocols
+}
+
+func appendProtocols(protocols []string, items ...string) []string {
+	for _, item := range items {
+		found := false
+		for _, v := range protocols {
+			if strings.EqualFold(v, item) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			protocols = append(protocols, item)
+		}
+	}
+	return protocols
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package config
+
+import (
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/model"
+	"testing"
+)
+
+func TestApplyChainProfile(t *testing.T) {
+	defer viper.Reset()
+
+	// by name
+	cfg := &Config{Chain: ChainConfig{ChainName: "Avalanche", Rpc: "http://127.0.0.1:9650"}}
+	ApplyChainProfile(cfg)
+	assert.Equal(t, 43114, cfg.Chain.ChainId)
+	assert.Equal(t, model.EvmChainGroup, cfg.Chain.ChainGroup)
+	assert.Equal(t, uint64(10), cfg.Scan.DelayedBlockNum)
+	assert.True(t, cfg.Chain.Finality)
+	assert.Equal(t, uint64(2048), cfg.Chain.MaxLogsRange)
+	assert.Len(t, cfg.Filters.EventTopics, 3)
+	assert.Equal(t, []string{"asc-20", "ethscriptions"}, cfg.Filters.Whitelist.Protocols)
+
+	// by id, configured filters are kept, the protocols of the configured contracts stay indexed
+	cfg = &Config{
+		Chain: ChainConfig{
+			ChainId:      56,
+			Marketplaces: []*MarketplaceConfig{{Name: "market"}, {Name: "market2", Protocol: "BSC-20"}},
+			Bridges:      []*BridgeConfig{{Name: "bridge", Protocol: "erc-20"}},
+		},
+		Filters: &IndexFilter{EventTopics: []string{"0x01"}, Whitelist: &Whitelist{Ticks: []string{"bnbs"}}},
+	}
+	ApplyChainProfile(cfg)
+	assert.Equal(t, model.ChainBSC, cfg.Chain.ChainName)
+	assert.True(t, cfg.Chain.BlockReceipts)
+	assert.Equal(t, []string{"0x01"}, cfg.Filters.EventTopics)
+	assert.Equal(t, []string{"bnbs"}, cfg.Filters.Whitelist.Ticks)
+	assert.Equal(t, []string{"bsc-20", "ethscriptions", "asc-20", "erc-20"}, cfg.Filters.Whitelist.Protocols)
+
+	// a protocol whitelist set in the config file is kept, an empty one indexes every protocol
+	viper.Set("filters.whitelist.protocols", []string{})
+	cfg = &Config{Chain: ChainConfig{ChainName: model.ChainPolygon}}
+	ApplyChainProfile(cfg)
+	assert.Nil(t, cfg.Filters.Whitelist)
+
+	// unknown chains get the evm defaults, keys set in the config file are kept even if zero
+	viper.Set("scan.delayed_block_num", 0)
+	viper.Set("chain.max_logs_range", 0)
+	cfg = &Config{Chain: ChainConfig{ChainId: 8453}}
+	ApplyChainProfile(cfg)
+	assert.Equal(t, "evm-8453", cfg.Chain.ChainName)
+	assert.Equal(t, model.EvmChainGroup, cfg.Chain.ChainGroup)
+	assert.Equal(t, uint64(0), cfg.Scan.DelayedBlockNum)
+	assert.Equal(t, uint64(0), cfg.Chain.MaxLogsRange)
+	assert.False(t, cfg.Chain.Finality)
+	assert.Nil(t, cfg.Filters)
+
+	// btc
+	assert.Equal(t, model.BtcChainGroup, ChainProfileOf(model.ChainBTC).ChainGroup)
+	assert.Equal(t, DefaultChainProfile, ChainProfileOf("unknown"))
+}
@@ -0,0 +1,71 @@
+Use
+tap_indexer;
+
+DROP TABLE IF EXISTS `rune_balances`;
+CREATE TABLE `rune_balances` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `chain` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `rune_id` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'block:tx',
+  `outpoint` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'txid:vout',
+  `address` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+  `amount` decimal(40,0) NOT NULL,
+  `status` tinyint(1) NOT NULL COMMENT 'utxo status',
+  `block_height` int unsigned NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uqx_chain_outpoint_rune_id` (`chain`,`outpoint`,`rune_id`),
+  KEY `idx_address` (`address`),
+  KEY `idx_rune_id` (`rune_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+DROP TABLE IF EXISTS `rune_events`;
+CREATE TABLE `rune_events` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `chain` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `rune_id` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'block:tx',
+  `event` varchar(16) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'etch/mint/transfer/burn',
+  `tx_hash` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `vout` int NOT NULL DEFAULT '-1' COMMENT 'receiving output',
+  `address` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+  `amount` decimal(40,0) NOT NULL,
+  `block_height` int unsigned NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_rune_id` (`rune_id`),
+  KEY `idx_tx_hash` (`tx_hash`),
+  KEY `idx_address` (`address`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+DROP TABLE IF EXISTS `runes`;
+CREATE TABLE `runes` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `chain` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `rune_id` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'block:tx',
+  `rune` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `spaced_rune` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `number` bigint unsigned NOT NULL,
+  `divisibility` tinyint unsigned NOT NULL DEFAULT '0',
+  `spacers` int unsigned NOT NULL DEFAULT '0',
+  `symbol` varchar(8) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL DEFAULT '',
+  `etching` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'etching tx hash',
+  `premine` decimal(40,0) NOT NULL DEFAULT '0',
+  `supply` decimal(40,0) NOT NULL DEFAULT '0',
+  `burned` decimal(40,0) NOT NULL DEFAULT '0',
+  `mints` bigint unsigned NOT NULL DEFAULT '0',
+  `cap` decimal(40,0) NOT NULL DEFAULT '0' COMMENT 'mint cap',
+  `limit` decimal(40,0) NOT NULL DEFAULT '0' COMMENT 'mint amount',
+  `height_start` bigint unsigned DEFAULT NULL,
+  `height_end` bigint unsigned DEFAULT NULL,
+  `offset_start` bigint unsigned DEFAULT NULL,
+  `offset_end` bigint unsigned DEFAULT NULL,
+  `turbo` tinyint(1) NOT NULL DEFAULT '0',
+  `block_height` int unsigned NOT NULL,
+  `index` int unsigned NOT NULL COMMENT 'tx index in block',
+  `timestamp` bigint NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uqx_chain_rune_id` (`chain`,`rune_id`),
+  UNIQUE KEY `uqx_chain_rune` (`chain`,`rune`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
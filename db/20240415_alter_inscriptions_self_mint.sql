@@ -0,0 +1,4 @@
+Use
+tap_indexer;
+
+ALTER TABLE inscriptions ADD self_mint tinyint(1) NOT NULL DEFAULT 0  COMMENT "brc-20 self mint tick";
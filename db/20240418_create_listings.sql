@@ -0,0 +1,31 @@
+Use
+tap_indexer;
+
+DROP TABLE IF EXISTS `listings`;
+CREATE TABLE `listings` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `chain` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `protocol` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin NOT NULL,
+  `tick` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin NOT NULL,
+  `list_id` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'list tx hash',
+  `marketplace` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `seller` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `buyer` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+  `amount` decimal(38,18) NOT NULL,
+  `price` decimal(65,0) NOT NULL DEFAULT '0' COMMENT 'total price in wei',
+  `unit_price` decimal(65,18) NOT NULL DEFAULT '0.000000000000000000',
+  `status` tinyint NOT NULL COMMENT '1-active, 2-cancelled, 3-filled',
+  `list_tx_hash` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `close_tx_hash` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+  `list_block` bigint unsigned NOT NULL,
+  `close_block` bigint unsigned NOT NULL DEFAULT '0',
+  `list_timestamp` bigint NOT NULL,
+  `close_timestamp` bigint NOT NULL DEFAULT '0',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uqx_chain_list_id` (`chain`,`list_id`),
+  KEY `idx_tick_status` (`protocol`,`tick`,`status`),
+  KEY `idx_seller` (`seller`),
+  KEY `idx_buyer` (`buyer`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
@@ -0,0 +1,23 @@
+Use
+tap_indexer;
+
+DROP TABLE IF EXISTS `tick_candles`;
+CREATE TABLE `tick_candles` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `chain` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `protocol` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin NOT NULL,
+  `tick` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin NOT NULL,
+  `period` varchar(8) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '1m, 1h, 1d',
+  `open_time` bigint NOT NULL COMMENT 'unix timestamp of the period start',
+  `open` decimal(65,18) NOT NULL COMMENT 'wei per token',
+  `high` decimal(65,18) NOT NULL,
+  `low` decimal(65,18) NOT NULL,
+  `close` decimal(65,18) NOT NULL,
+  `volume` decimal(38,18) NOT NULL COMMENT 'traded token amount',
+  `turnover` decimal(65,0) NOT NULL COMMENT 'traded value in wei',
+  `trades` int unsigned NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uqx_chain_tick_period_open_time` (`chain`,`protocol`,`tick`,`period`,`open_time`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
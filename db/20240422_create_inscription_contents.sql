@@ -0,0 +1,24 @@
+Use
+tap_indexer;
+
+ALTER TABLE ethscriptions ADD `number` bigint unsigned NOT NULL DEFAULT 0 COMMENT 'chain-wide creation sequence' AFTER `previous_owner`,
+    ADD `content_type` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' AFTER `number`,
+    ADD `content_size` int unsigned NOT NULL DEFAULT 0 AFTER `content_type`,
+    ADD `content_sha256` char(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' AFTER `content_size`,
+    ADD KEY `idx_chain_owner_number` (`chain`,`owner`,`number`);
+
+-- number existing ethscriptions in creation order, contents are filled by re-indexing
+UPDATE ethscriptions e JOIN (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY chain ORDER BY block_height, id) - 1 AS rn FROM ethscriptions
+) n ON e.id = n.id SET e.number = n.rn;
+
+DROP TABLE IF EXISTS `inscription_contents`;
+CREATE TABLE `inscription_contents` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `sha256` char(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `size` int unsigned NOT NULL,
+  `content` mediumblob NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uqx_sha256` (`sha256`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
@@ -0,0 +1,26 @@
+Use
+tap_indexer;
+
+ALTER TABLE txs ADD `inscription_number` bigint NOT NULL DEFAULT '-1' COMMENT 'chain-wide inscription number, -1 when not an inscription',
+    ADD `mint_sn` bigint unsigned NOT NULL DEFAULT '0' COMMENT 'per-tick mint sequence number',
+    ADD KEY `idx_chain_inscription_number` (`chain`,`inscription_number`),
+    ADD KEY `idx_chain_protocol_tick_mint_sn` (`chain`,`protocol`,`tick`,`mint_sn`);
+
+-- number the indexed evm txs in block & tx index order, the rows of a multi result tx share the number of the tx
+UPDATE txs t JOIN (
+    SELECT id, DENSE_RANK() OVER (PARTITION BY chain ORDER BY block_height, position_in_block) - 1 AS rn FROM txs WHERE chain <> 'btc'
+) n ON t.id = n.id SET t.inscription_number = n.rn;
+
+UPDATE txs t JOIN (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY chain, protocol, tick ORDER BY block_height, position_in_block, id) AS sn FROM txs WHERE chain <> 'btc' AND op = 'mint'
+) n ON t.id = n.id SET t.mint_sn = n.sn;
+
+UPDATE inscriptions_stats s JOIN (
+    SELECT chain, protocol, tick, MAX(mint_sn) AS sn FROM txs WHERE chain <> 'btc' AND op = 'mint' GROUP BY chain, protocol, tick
+) m ON s.chain = m.chain AND s.protocol = m.protocol AND s.tick = m.tick SET s.last_sn = m.sn;
+
+UPDATE inscriptions i JOIN txs t ON t.chain = i.chain AND t.protocol = i.protocol AND t.tick = i.tick AND t.op = 'deploy'
+SET i.inscription_number = t.inscription_number WHERE i.chain <> 'btc';
+
+UPDATE inscriptions_stats s JOIN inscriptions i ON s.chain = i.chain AND s.sid = i.sid
+SET s.inscription_number = i.inscription_number WHERE s.chain <> 'btc';
@@ -0,0 +1,4 @@
+Use
+tap_indexer;
+
+ALTER TABLE inscriptions_stats ADD `burned` decimal(38,18) unsigned NOT NULL DEFAULT '0.000000000000000000' COMMENT 'received by burn addresses' AFTER `last_sn`;
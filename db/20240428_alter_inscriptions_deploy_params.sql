@@ -0,0 +1,9 @@
+Use
+tap_indexer;
+
+ALTER TABLE inscriptions
+    ADD `start_block` bigint unsigned NOT NULL DEFAULT '0' COMMENT 'mint start block, 0 unbounded' AFTER `self_mint`,
+    ADD `end_block` bigint unsigned NOT NULL DEFAULT '0' COMMENT 'mint end block, 0 unbounded' AFTER `start_block`,
+    ADD `max_mints_per_address` bigint unsigned NOT NULL DEFAULT '0' COMMENT 'max mints per address, 0 unbounded' AFTER `end_block`,
+    ADD `max_mints_per_block` bigint unsigned NOT NULL DEFAULT '0' COMMENT 'max mints per block, 0 unbounded' AFTER `max_mints_per_address`,
+    ADD `deployer_only` tinyint(1) NOT NULL DEFAULT '0' COMMENT 'only the deployer can mint' AFTER `max_mints_per_block`;
@@ -0,0 +1,34 @@
+Use
+tap_indexer;
+
+DROP TABLE IF EXISTS `bridge_transfers`;
+CREATE TABLE `bridge_transfers` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `bridge` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'bridge name, the same on all chains',
+  `bridge_id` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'transfer id shared by the lock & its release / mint',
+  `protocol` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin NOT NULL,
+  `tick` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin NOT NULL,
+  `amount` decimal(38,18) NOT NULL,
+  `sender` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+  `recipient` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+  `source_chain` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+  `source_contract` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT 'holder of the locked tokens',
+  `source_tx_hash` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+  `source_block` bigint unsigned NOT NULL DEFAULT '0',
+  `source_timestamp` bigint NOT NULL DEFAULT '0',
+  `dest_chain` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+  `dest_contract` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+  `dest_tx_hash` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+  `dest_block` bigint unsigned NOT NULL DEFAULT '0',
+  `dest_timestamp` bigint NOT NULL DEFAULT '0',
+  `settle_type` varchar(16) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT 'release / mint',
+  `status` tinyint NOT NULL COMMENT '1-pending, 2-settled',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uqx_bridge_id` (`bridge`,`bridge_id`),
+  KEY `idx_source_chain_tick` (`source_chain`,`protocol`,`tick`),
+  KEY `idx_dest_chain_tick` (`dest_chain`,`protocol`,`tick`),
+  KEY `idx_sender` (`sender`),
+  KEY `idx_recipient` (`recipient`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
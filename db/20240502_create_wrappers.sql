@@ -0,0 +1,21 @@
+Use
+tap_indexer;
+
+DROP TABLE IF EXISTS `wrappers`;
+CREATE TABLE `wrappers` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `chain` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `name` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'wrapper name',
+  `protocol` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin NOT NULL,
+  `tick` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin NOT NULL,
+  `address` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'holder of the locked inscription tokens',
+  `contract` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'erc-20 contract',
+  `supply` decimal(38,18) NOT NULL DEFAULT '0' COMMENT 'erc-20 supply, minted - burned',
+  `locked` decimal(38,18) NOT NULL DEFAULT '0' COMMENT 'inscription balance of the wrapper address',
+  `block_height` bigint unsigned NOT NULL DEFAULT '0' COMMENT 'last updated block',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uqx_chain_name` (`chain`,`name`),
+  KEY `idx_chain_tick` (`chain`,`protocol`,`tick`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
@@ -0,0 +1,5 @@
+Use
+tap_indexer;
+
+-- evm balances left the available balance empty before the freeze operations
+UPDATE `balances` SET `available` = `balance` WHERE `chain` <> 'btc';
@@ -0,0 +1,7 @@
+Use
+tap_indexer;
+
+-- ethscriptions content uniqueness is checked on the whole data uri, rows indexed before
+-- have an empty uri_sha256 & must be re-synced to take part in the check
+ALTER TABLE `ethscriptions` ADD `uri_sha256` char(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT 'sha256 of the creation data uri' AFTER `content_sha256`,
+    ADD KEY `idx_chain_uri_sha256` (`chain`,`uri_sha256`);
@@ -0,0 +1,7 @@
+Use
+tap_indexer;
+
+ALTER TABLE `utxos` ADD `satpoint` varchar(160) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '<txid>:<vout>:<offset> of the inscribed sat' AFTER `tx_hash`;
+
+-- unspent transfer inscriptions never moved, they still sit on the first sat of the reveal output
+UPDATE `utxos` SET `satpoint` = CONCAT(`tx_hash`, ':0:0') WHERE `satpoint` = '';
@@ -0,0 +1,9 @@
+Use
+tap_indexer;
+
+-- ethscriptions were numbered by a separate creation counter, renumber them with the chain-wide inscription number of the creation tx
+UPDATE `ethscriptions` e JOIN `txs` t ON t.`chain` = e.`chain` AND t.`tx_hash` = UNHEX(SUBSTRING(e.`ethscription_id`, 3))
+SET e.`number` = t.`inscription_number`
+WHERE t.`inscription_number` >= 0;
+
+ALTER TABLE `ethscriptions` MODIFY `number` bigint unsigned NOT NULL DEFAULT 0 COMMENT 'chain-wide inscription number of the creation tx';
@@ -0,0 +1,11 @@
+Use
+tap_indexer;
+
+ALTER TABLE inscriptions_stats ADD `bridged` decimal(38,18) unsigned NOT NULL DEFAULT '0.000000000000000000' COMMENT 'minted from tokens locked on another chain' AFTER `burned`;
+
+-- bridged mints indexed before the column existed
+UPDATE inscriptions_stats s JOIN (
+    SELECT dest_chain, protocol, tick, SUM(amount) AS amount FROM bridge_transfers
+    WHERE settle_type = 'mint' AND dest_tx_hash <> '' GROUP BY dest_chain, protocol, tick
+) b ON b.dest_chain = s.chain AND b.protocol = s.protocol AND b.tick = s.tick
+SET s.`bridged` = b.amount;
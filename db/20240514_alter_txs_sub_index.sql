@@ -0,0 +1,4 @@
+Use
+tap_indexer;
+
+ALTER TABLE `txs` ADD `sub_index` int unsigned NOT NULL DEFAULT '0' COMMENT 'smart account inner call position within the tx' AFTER `mint_sn`;
@@ -0,0 +1,6 @@
+Use
+tap_indexer;
+
+-- token inscriptions (asc-20, brc-20, ...) are content objects too, kept with their protocol, ethscriptions have none.
+-- token content objects are built while indexing, blocks indexed before must be re-synced to get them
+ALTER TABLE `ethscriptions` ADD `protocol` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT 'token protocol, empty for ethscriptions' AFTER `chain`;
@@ -77,6 +77,40 @@ CREATE TABLE `block` (
 
 
 
+DROP TABLE IF EXISTS `bridge_transfers`;
+CREATE TABLE `bridge_transfers` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `bridge` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'bridge name, the same on all chains',
+  `bridge_id` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'transfer id shared by the lock & its release / mint',
+  `protocol` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin NOT NULL,
+  `tick` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin NOT NULL,
+  `amount` decimal(38,18) NOT NULL,
+  `sender` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+  `recipient` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+  `source_chain` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+  `source_contract` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT 'holder of the locked tokens',
+  `source_tx_hash` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+  `source_block` bigint unsigned NOT NULL DEFAULT '0',
+  `source_timestamp` bigint NOT NULL DEFAULT '0',
+  `dest_chain` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+  `dest_contract` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+  `dest_tx_hash` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+  `dest_block` bigint unsigned NOT NULL DEFAULT '0',
+  `dest_timestamp` bigint NOT NULL DEFAULT '0',
+  `settle_type` varchar(16) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT 'release / mint',
+  `status` tinyint NOT NULL COMMENT '1-pending, 2-settled',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uqx_bridge_id` (`bridge`,`bridge_id`),
+  KEY `idx_source_chain_tick` (`source_chain`,`protocol`,`tick`),
+  KEY `idx_dest_chain_tick` (`dest_chain`,`protocol`,`tick`),
+  KEY `idx_sender` (`sender`),
+  KEY `idx_recipient` (`recipient`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+
+
 DROP TABLE IF EXISTS `chain_info`;
 CREATE TABLE `chain_info` (
   `id` int unsigned NOT NULL AUTO_INCREMENT,
@@ -120,6 +154,44 @@ CREATE TABLE `chain_stats_hour` (
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
 
 
+DROP TABLE IF EXISTS `ethscriptions`;
+CREATE TABLE `ethscriptions` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `chain` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `protocol` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT 'token protocol, empty for ethscriptions',
+  `ethscription_id` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'creation tx hash',
+  `creator` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `owner` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `previous_owner` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+  `number` bigint unsigned NOT NULL DEFAULT 0 COMMENT 'chain-wide inscription number of the creation tx',
+  `content_type` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+  `content_size` int unsigned NOT NULL DEFAULT 0,
+  `content_sha256` char(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+  `uri_sha256` char(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT 'sha256 of the creation data uri',
+  `block_height` int unsigned NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uqx_chain_ethscription_id` (`chain`,`ethscription_id`),
+  KEY `idx_owner` (`owner`),
+  KEY `idx_chain_owner_number` (`chain`,`owner`,`number`),
+  KEY `idx_chain_uri_sha256` (`chain`,`uri_sha256`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+
+DROP TABLE IF EXISTS `inscription_contents`;
+CREATE TABLE `inscription_contents` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `sha256` char(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `size` int unsigned NOT NULL,
+  `content` mediumblob NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uqx_sha256` (`sha256`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+
+
 DROP TABLE IF EXISTS `inscriptions`;
 CREATE TABLE `inscriptions` (
   `id` int unsigned NOT NULL AUTO_INCREMENT,
@@ -139,6 +211,12 @@ CREATE TABLE `inscriptions` (
   `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
   `inscription_id` varchar(256) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT 'inscription id',
   `inscription_number` bigint NOT NULL DEFAULT '0' COMMENT 'inscription number',
+  `self_mint` tinyint(1) NOT NULL DEFAULT '0' COMMENT 'brc-20 self mint tick',
+  `start_block` bigint unsigned NOT NULL DEFAULT '0' COMMENT 'mint start block, 0 unbounded',
+  `end_block` bigint unsigned NOT NULL DEFAULT '0' COMMENT 'mint end block, 0 unbounded',
+  `max_mints_per_address` bigint unsigned NOT NULL DEFAULT '0' COMMENT 'max mints per address, 0 unbounded',
+  `max_mints_per_block` bigint unsigned NOT NULL DEFAULT '0' COMMENT 'max mints per block, 0 unbounded',
+  `deployer_only` tinyint(1) NOT NULL DEFAULT '0' COMMENT 'only the deployer can mint',
   PRIMARY KEY (`id`),
   UNIQUE KEY `uq_chain_protocol_name` (`chain`,`protocol`,`tick`),
   UNIQUE KEY `uq_chain_sid` (`chain`,`sid`),
@@ -159,6 +237,8 @@ CREATE TABLE `inscriptions_stats` (
   `mint_first_block` bigint unsigned NOT NULL,
   `mint_last_block` bigint unsigned NOT NULL,
   `last_sn` int unsigned NOT NULL,
+  `burned` decimal(38,18) unsigned NOT NULL DEFAULT '0.000000000000000000' COMMENT 'received by burn addresses',
+  `bridged` decimal(38,18) unsigned NOT NULL DEFAULT '0.000000000000000000' COMMENT 'minted from tokens locked on another chain',
   `holders` int unsigned NOT NULL,
   `tx_cnt` bigint unsigned NOT NULL,
   `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
@@ -173,6 +253,135 @@ CREATE TABLE `inscriptions_stats` (
 
 
 
+DROP TABLE IF EXISTS `listings`;
+CREATE TABLE `listings` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `chain` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `protocol` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin NOT NULL,
+  `tick` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin NOT NULL,
+  `list_id` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'list tx hash',
+  `marketplace` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `seller` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `buyer` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+  `amount` decimal(38,18) NOT NULL,
+  `price` decimal(65,0) NOT NULL DEFAULT '0' COMMENT 'total price in wei',
+  `unit_price` decimal(65,18) NOT NULL DEFAULT '0.000000000000000000',
+  `status` tinyint NOT NULL COMMENT '1-active, 2-cancelled, 3-filled',
+  `list_tx_hash` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `close_tx_hash` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+  `list_block` bigint unsigned NOT NULL,
+  `close_block` bigint unsigned NOT NULL DEFAULT '0',
+  `list_timestamp` bigint NOT NULL,
+  `close_timestamp` bigint NOT NULL DEFAULT '0',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uqx_chain_list_id` (`chain`,`list_id`),
+  KEY `idx_tick_status` (`protocol`,`tick`,`status`),
+  KEY `idx_seller` (`seller`),
+  KEY `idx_buyer` (`buyer`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+
+
+DROP TABLE IF EXISTS `rune_balances`;
+CREATE TABLE `rune_balances` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `chain` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `rune_id` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'block:tx',
+  `outpoint` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'txid:vout',
+  `address` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+  `amount` decimal(40,0) NOT NULL,
+  `status` tinyint(1) NOT NULL COMMENT 'utxo status',
+  `block_height` int unsigned NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uqx_chain_outpoint_rune_id` (`chain`,`outpoint`,`rune_id`),
+  KEY `idx_address` (`address`),
+  KEY `idx_rune_id` (`rune_id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+
+
+DROP TABLE IF EXISTS `rune_events`;
+CREATE TABLE `rune_events` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `chain` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `rune_id` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'block:tx',
+  `event` varchar(16) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'etch/mint/transfer/burn',
+  `tx_hash` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `vout` int NOT NULL DEFAULT '-1' COMMENT 'receiving output',
+  `address` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '',
+  `amount` decimal(40,0) NOT NULL,
+  `block_height` int unsigned NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_rune_id` (`rune_id`),
+  KEY `idx_tx_hash` (`tx_hash`),
+  KEY `idx_address` (`address`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+
+
+DROP TABLE IF EXISTS `runes`;
+CREATE TABLE `runes` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `chain` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `rune_id` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'block:tx',
+  `rune` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `spaced_rune` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `number` bigint unsigned NOT NULL,
+  `divisibility` tinyint unsigned NOT NULL DEFAULT '0',
+  `spacers` int unsigned NOT NULL DEFAULT '0',
+  `symbol` varchar(8) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL DEFAULT '',
+  `etching` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'etching tx hash',
+  `premine` decimal(40,0) NOT NULL DEFAULT '0',
+  `supply` decimal(40,0) NOT NULL DEFAULT '0',
+  `burned` decimal(40,0) NOT NULL DEFAULT '0',
+  `mints` bigint unsigned NOT NULL DEFAULT '0',
+  `cap` decimal(40,0) NOT NULL DEFAULT '0' COMMENT 'mint cap',
+  `limit` decimal(40,0) NOT NULL DEFAULT '0' COMMENT 'mint amount',
+  `height_start` bigint unsigned DEFAULT NULL,
+  `height_end` bigint unsigned DEFAULT NULL,
+  `offset_start` bigint unsigned DEFAULT NULL,
+  `offset_end` bigint unsigned DEFAULT NULL,
+  `turbo` tinyint(1) NOT NULL DEFAULT '0',
+  `block_height` int unsigned NOT NULL,
+  `index` int unsigned NOT NULL COMMENT 'tx index in block',
+  `timestamp` bigint NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uqx_chain_rune_id` (`chain`,`rune_id`),
+  UNIQUE KEY `uqx_chain_rune` (`chain`,`rune`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+
+
+DROP TABLE IF EXISTS `tick_candles`;
+CREATE TABLE `tick_candles` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `chain` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `protocol` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin NOT NULL,
+  `tick` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin NOT NULL,
+  `period` varchar(8) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT '1m, 1h, 1d',
+  `open_time` bigint NOT NULL COMMENT 'unix timestamp of the period start',
+  `open` decimal(65,18) NOT NULL COMMENT 'wei per token',
+  `high` decimal(65,18) NOT NULL,
+  `low` decimal(65,18) NOT NULL,
+  `close` decimal(65,18) NOT NULL,
+  `volume` decimal(38,18) NOT NULL COMMENT 'traded token amount',
+  `turnover` decimal(65,0) NOT NULL COMMENT 'traded value in wei',
+  `trades` int unsigned NOT NULL,
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uqx_chain_tick_period_open_time` (`chain`,`protocol`,`tick`,`period`,`open_time`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+
+
 DROP TABLE IF EXISTS `txs`;
 CREATE TABLE `txs` (
   `id` bigint unsigned NOT NULL AUTO_INCREMENT,
@@ -193,10 +402,15 @@ CREATE TABLE `txs` (
   `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
   `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
   `content` varchar(2048) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT 'inscription content',
+  `inscription_number` bigint NOT NULL DEFAULT '-1' COMMENT 'chain-wide inscription number, -1 when not an inscription',
+  `mint_sn` bigint unsigned NOT NULL DEFAULT '0' COMMENT 'per-tick mint sequence number',
+  `sub_index` int unsigned NOT NULL DEFAULT '0' COMMENT 'smart account inner call position within the tx',
   PRIMARY KEY (`id`,`block_time`),
   KEY `idx_tx_hash_chain` (`tx_hash`(12),`chain`(4)),
   KEY `idx_chain_protocol_tick` (`chain`,`protocol`,`tick`),
-  KEY `idx_chain_block_height` (`chain`,`block_height`)
+  KEY `idx_chain_block_height` (`chain`,`block_height`),
+  KEY `idx_chain_inscription_number` (`chain`,`inscription_number`),
+  KEY `idx_chain_protocol_tick_mint_sn` (`chain`,`protocol`,`tick`,`mint_sn`)
 ) ENGINE=InnoDB AUTO_INCREMENT=397752991 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci
 PARTITION BY RANGE(UNIX_TIMESTAMP(block_time)) (
     PARTITION p202301 VALUES LESS THAN (UNIX_TIMESTAMP('2023-01-01 00:00:00')),  -- 2023.1
@@ -241,6 +455,7 @@ CREATE TABLE `utxos` (
   `amount` decimal(38,18) NOT NULL,
   `root_hash` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
   `tx_hash` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `satpoint` varchar(160) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL DEFAULT '' COMMENT '<txid>:<vout>:<offset> of the inscribed sat',
   `status` tinyint(1) NOT NULL COMMENT 'tx status',
   `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
   `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
@@ -250,3 +465,24 @@ CREATE TABLE `utxos` (
   KEY `idx_address` (`address`),
   KEY `idx_inscription_number` (`inscription_number`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+
+
+DROP TABLE IF EXISTS `wrappers`;
+CREATE TABLE `wrappers` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `chain` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL,
+  `name` varchar(64) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'wrapper name',
+  `protocol` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin NOT NULL,
+  `tick` varchar(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_0900_bin NOT NULL,
+  `address` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'holder of the locked inscription tokens',
+  `contract` varchar(128) CHARACTER SET utf8mb4 COLLATE utf8mb4_general_ci NOT NULL COMMENT 'erc-20 contract',
+  `supply` decimal(38,18) NOT NULL DEFAULT '0' COMMENT 'erc-20 supply, minted - burned',
+  `locked` decimal(38,18) NOT NULL DEFAULT '0' COMMENT 'inscription balance of the wrapper address',
+  `block_height` bigint unsigned NOT NULL DEFAULT '0' COMMENT 'last updated block',
+  `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uqx_chain_name` (`chain`,`name`),
+  KEY `idx_chain_tick` (`chain`,`protocol`,`tick`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
@@ -37,6 +37,9 @@ import (
 type Balance struct {
 	sid   uint64
 	ticks *sync.Map
+
+	// deferred balances created while ticks are updated concurrently get their sid by AssignSid
+	deferred bool
 }
 
 type BalanceItem struct {
@@ -79,7 +82,7 @@ func (d *Balance) Update(protocol, tick string, addr string, b *BalanceItem) *Ba
  * create addr tick's balance
  ***************************************/
 func (d *Balance) Create(protocol, tick string, addr string, b *BalanceItem) *BalanceItem {
-	if b.SID <= 0 {
+	if b.SID <= 0 && !d.deferred {
 		d.sid++
 		b.SID = d.sid
 	}
@@ -95,6 +98,32 @@ func (d *Balance) Create(protocol, tick string, addr string, b *BalanceItem) *Ba
 	return balanceItem
 }
 
+// DeferSid
+/***************************************
+ * defer the sid of new balances, concurrent updates of different ticks
+ * must not race on the sid & sids must follow the tx order
+ ***************************************/
+func (d *Balance) DeferSid(deferred bool) {
+	d.deferred = deferred
+}
+
+// AssignSid
+/***************************************
+ * assign the next sid to a balance created while deferred
+ ***************************************/
+func (d *Balance) AssignSid(protocol, tick string, addr string) uint64 {
+	ok, balanceItem := d.Get(protocol, tick, addr)
+	if !ok {
+		return 0
+	}
+
+	if balanceItem.SID <= 0 {
+		d.sid++
+		balanceItem.SID = d.sid
+	}
+	return balanceItem.SID
+}
+
 // SetSid set auto_increment id
 func (d *Balance) SetSid(sid uint64) {
 	if sid > d.sid {
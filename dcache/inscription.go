@@ -47,6 +47,22 @@ type Tick struct {
 	LimitPerMint decimal.Decimal
 	TotalSupply  decimal.Decimal
 	Decimals     int8
+	// SelfMint brc-20 self mint tick, only children of the deploy inscription can mint
+	SelfMint      bool
+	InscriptionId string
+	DeployBy      string
+
+	// deploy params, 0 means unbounded
+	StartBlock         uint64
+	EndBlock           uint64
+	MaxMintsPerAddress uint64
+	MaxMintsPerBlock   uint64
+	DeployerOnly       bool
+}
+
+// MintCapped mints of capped ticks are counted by the MintCounter
+func (t *Tick) MintCapped() bool {
+	return t.MaxMintsPerAddress > 0 || t.MaxMintsPerBlock > 0
 }
 
 func NewInscription() *Inscription {
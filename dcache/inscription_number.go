@@ -0,0 +1,71 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package dcache
+
+import "sync/atomic"
+
+// InscriptionNumber
+/*****************************************************
+ * chain-wide inscription number sequence
+ * valid inscription txs are numbered in block & tx index order, starting at 0
+ ****************************************************/
+type InscriptionNumber struct {
+	next int64
+}
+
+func NewInscriptionNumber() *InscriptionNumber {
+	return &InscriptionNumber{}
+}
+
+// Next
+/***************************************
+ * assign the next inscription number
+ ***************************************/
+func (d *InscriptionNumber) Next() int64 {
+	return atomic.AddInt64(&d.next, 1) - 1
+}
+
+// Last
+/***************************************
+ * last assigned inscription number, -1 before any
+ ***************************************/
+func (d *InscriptionNumber) Last() int64 {
+	return atomic.LoadInt64(&d.next) - 1
+}
+
+// Set set the next inscription number
+func (d *InscriptionNumber) Set(next int64) {
+	atomic.StoreInt64(&d.next, next)
+}
+
+// Resume
+/***************************************
+ * continue the sequence after the last persisted number, from 0 when none was persisted
+ ***************************************/
+func (d *InscriptionNumber) Resume(last int64, found bool) {
+	if !found {
+		d.Set(0)
+		return
+	}
+	d.Set(last + 1)
+}
@@ -45,6 +45,9 @@ type InsStats struct {
 	Minted  decimal.Decimal
 	Holders int64
 	TxCnt   uint64
+	LastSN  uint64 // last mint sequence number
+	Burned  decimal.Decimal
+	Bridged decimal.Decimal // bridged in, minted from tokens locked on another chain
 }
 
 func NewInscriptionStats() *InscriptionStats {
@@ -114,6 +117,48 @@ func (d *InscriptionStats) Mint(protocol, tick string, amount decimal.Decimal) *
 	return insStats
 }
 
+// MintSN
+/***************************************
+ * assign the next mint sequence number of the tick
+ ***************************************/
+func (d *InscriptionStats) MintSN(protocol, tick string) uint64 {
+	ok, insStats := d.Get(protocol, tick)
+	if !ok {
+		return 0
+	}
+
+	insStats.LastSN++
+	return insStats.LastSN
+}
+
+// Burn
+/***************************************
+ * add the amount received by burn addresses
+ ***************************************/
+func (d *InscriptionStats) Burn(protocol, tick string, amount decimal.Decimal) *InsStats {
+	ok, insStats := d.Get(protocol, tick)
+	if !ok {
+		return nil
+	}
+
+	insStats.Burned = insStats.Burned.Add(amount)
+	return insStats
+}
+
+// Bridge
+/***************************************
+ * add the amount bridged in from another chain
+ ***************************************/
+func (d *InscriptionStats) Bridge(protocol, tick string, amount decimal.Decimal) *InsStats {
+	ok, insStats := d.Get(protocol, tick)
+	if !ok {
+		return nil
+	}
+
+	insStats.Bridged = insStats.Bridged.Add(amount)
+	return insStats
+}
+
 func (d *InscriptionStats) Holders(protocol, tick string, incr int64) *InsStats {
 	ok, insStats := d.Get(protocol, tick)
 	if !ok {
@@ -23,6 +23,7 @@
 package dcache
 
 import (
+	"github.com/shopspring/decimal"
 	"github.com/uxuycom/indexer/storage"
 	"github.com/uxuycom/indexer/xylog"
 	"time"
@@ -33,18 +34,24 @@ import (
  * Do all cache operate
  ****************************************************/
 type Manager struct {
-	chain            string
-	db               *storage.DBClient
-	Balance          *Balance
-	UTXO             *UTXO
-	Inscription      *Inscription
-	InscriptionStats *InscriptionStats
+	chain             string
+	db                *storage.DBClient
+	Balance           *Balance
+	UTXO              *UTXO
+	Inscription       *Inscription
+	InscriptionStats  *InscriptionStats
+	Ethscription      *Ethscription
+	Runes             *Runes
+	InscriptionNumber *InscriptionNumber
+	MintCounter       *MintCounter
+	Wrapper           *Wrapper
 }
 
 func NewManager(db *storage.DBClient, chain string) *Manager {
 	e := &Manager{
-		db:    db,
-		chain: chain,
+		db:      db,
+		chain:   chain,
+		Wrapper: NewWrapper(),
 	}
 
 	if db == nil {
@@ -55,9 +62,30 @@ func NewManager(db *storage.DBClient, chain string) *Manager {
 	e.initInscriptionStatsCache(chain)
 	e.initBalanceCache(chain)
 	e.initUtxoCache()
+	e.initEthscriptionCache(chain)
+	e.initRunesCache(chain)
+	e.initInscriptionNumber(chain)
+	e.initMintCounter(chain)
+	e.initWrappers(chain)
 	return e
 }
 
+// NewMemoryManager empty caches without the db data source, txs replayed on it only live in memory
+func NewMemoryManager(chain string) *Manager {
+	return &Manager{
+		chain:             chain,
+		Balance:           NewBalance(),
+		UTXO:              NewUTXO(),
+		Inscription:       NewInscription(),
+		InscriptionStats:  NewInscriptionStats(),
+		Ethscription:      NewEthscription(),
+		Runes:             NewRunes(),
+		InscriptionNumber: NewInscriptionNumber(),
+		MintCounter:       NewMintCounter(),
+		Wrapper:           NewWrapper(),
+	}
+}
+
 func (h *Manager) GetDataSource() *storage.DBClient {
 	return h.db
 }
@@ -85,11 +113,20 @@ func (h *Manager) initInscriptionCache(chain string) {
 
 		for _, v := range items {
 			h.Inscription.Create(v.Protocol, v.Tick, &Tick{
-				SID:          v.SID,
-				TransferType: v.TransferType,
-				LimitPerMint: v.LimitPerMint,
-				TotalSupply:  v.TotalSupply,
-				Decimals:     v.Decimals,
+				SID:           v.SID,
+				TransferType:  v.TransferType,
+				LimitPerMint:  v.LimitPerMint,
+				TotalSupply:   v.TotalSupply,
+				Decimals:      v.Decimals,
+				SelfMint:      v.SelfMint,
+				InscriptionId: v.InscriptionId,
+				DeployBy:      v.DeployBy,
+
+				StartBlock:         v.StartBlock,
+				EndBlock:           v.EndBlock,
+				MaxMintsPerAddress: v.MaxMintsPerAddress,
+				MaxMintsPerBlock:   v.MaxMintsPerBlock,
+				DeployerOnly:       v.DeployerOnly,
 			})
 
 			if v.SID > maxSid {
@@ -134,6 +171,9 @@ func (h *Manager) initInscriptionStatsCache(chain string) {
 				Minted:  v.Minted,
 				Holders: int64(v.Holders),
 				TxCnt:   v.TxCnt,
+				LastSN:  v.LastSN,
+				Burned:  v.Burned,
+				Bridged: v.Bridged,
 			})
 
 			if v.SID > maxSid {
@@ -215,7 +255,9 @@ func (h *Manager) initUtxoCache() {
 		}
 
 		for _, v := range utxos {
-			h.UTXO.Add(v.Protocol, v.Tick, v.RootHash, v.Address, v.Amount, v.InscriptionId)
+			if err := h.UTXO.Add(v.Protocol, v.Tick, v.Satpoint, v.Address, v.Amount, v.InscriptionId); err != nil {
+				xylog.Logger.Fatalf("failed to initialize utxos cache data. inscription[%s] err:%v", v.InscriptionId, err)
+			}
 		}
 
 		//update id index
@@ -223,3 +265,167 @@ func (h *Manager) initUtxoCache() {
 	}
 	xylog.Logger.Infof("load utxos data finished, cost ts:%v", time.Since(startTs))
 }
+
+func (h *Manager) initEthscriptionCache(chain string) {
+	h.Ethscription = NewEthscription()
+
+	startTs := time.Now()
+	idx := 0
+	start := uint64(0)
+	limit := 10000
+	xylog.Logger.Infof("load ethscriptions data start...")
+	for {
+		items, err := h.db.GetEthscriptionsByIdLimit(chain, start, limit)
+		if err != nil {
+			xylog.Logger.Fatalf("failed to initialize ethscription cache data. err:%v", err)
+		}
+		idx++
+		xylog.Logger.Infof("load ethscriptions ret, items[%d], idx:%d", len(items), idx)
+
+		if len(items) <= 0 {
+			break
+		}
+
+		for _, v := range items {
+			h.Ethscription.Create(v.EthscriptionId, &EthscriptionItem{
+				Creator:       v.Creator,
+				Owner:         v.Owner,
+				PreviousOwner: v.PreviousOwner,
+				UriSha256:     v.UriSha256,
+			})
+		}
+
+		//update id index
+		start = items[len(items)-1].ID
+	}
+	xylog.Logger.Infof("load ethscriptions data finished, cost ts:%v", time.Since(startTs))
+}
+
+func (h *Manager) initInscriptionNumber(chain string) {
+	h.InscriptionNumber = NewInscriptionNumber()
+
+	last, ok, err := h.db.FindLastInscriptionNumber(chain)
+	if err != nil {
+		xylog.Logger.Fatalf("failed to initialize inscription number. err:%v", err)
+	}
+	h.InscriptionNumber.Resume(last, ok)
+	xylog.Logger.Infof("load inscription number finished, next:%d", h.InscriptionNumber.Last()+1)
+}
+
+func (h *Manager) initMintCounter(chain string) {
+	h.MintCounter = NewMintCounter()
+
+	startTs := time.Now()
+	items, err := h.db.GetMintCappedInscriptions(chain)
+	if err != nil {
+		xylog.Logger.Fatalf("failed to initialize mint counter. err:%v", err)
+	}
+
+	for _, v := range items {
+		if v.MaxMintsPerAddress <= 0 {
+			continue
+		}
+
+		counts, err := h.db.GetMintCountsByAddress(chain, v.Protocol, v.Tick)
+		if err != nil {
+			xylog.Logger.Fatalf("failed to initialize mint counter. tick[%s-%s], err:%v", v.Protocol, v.Tick, err)
+		}
+		for address, count := range counts {
+			h.MintCounter.SetAddressMints(v.Protocol, v.Tick, address, count)
+		}
+	}
+	xylog.Logger.Infof("load mint counter finished, ticks[%d], cost ts:%v", len(items), time.Since(startTs))
+}
+
+func (h *Manager) initWrappers(chain string) {
+	items, err := h.db.GetWrappers(chain, "", "")
+	if err != nil {
+		xylog.Logger.Fatalf("failed to initialize wrappers. err:%v", err)
+	}
+
+	for _, v := range items {
+		h.Wrapper.Restore(v.Name, v.Supply)
+	}
+	xylog.Logger.Infof("load wrappers finished, wrappers[%d]", len(items))
+}
+
+func (h *Manager) initRunesCache(chain string) {
+	h.Runes = NewRunes()
+
+	startTs := time.Now()
+	idx := 0
+	start := uint64(0)
+	limit := 10000
+	xylog.Logger.Infof("load runes data start...")
+	for {
+		items, err := h.db.GetRunesByIdLimit(chain, start, limit)
+		if err != nil {
+			xylog.Logger.Fatalf("failed to initialize runes cache data. err:%v", err)
+		}
+		idx++
+		xylog.Logger.Infof("load runes ret, items[%d], idx:%d", len(items), idx)
+
+		if len(items) <= 0 {
+			break
+		}
+
+		for _, v := range items {
+			h.Runes.Create(&RuneEntry{
+				Id:           v.RuneId,
+				Block:        uint64(v.BlockHeight),
+				Tx:           uint32(v.Index),
+				Rune:         v.Rune,
+				Spacers:      uint32(v.Spacers),
+				Number:       uint64(v.Number),
+				Divisibility: uint8(v.Divisibility),
+				Symbol:       v.Symbol,
+				Premine:      v.Premine,
+				Burned:       v.Burned,
+				Mints:        uint64(v.Mints),
+				Terms: &RuneTerms{
+					Amount:      v.Limit,
+					Cap:         v.Cap,
+					HeightStart: v.HeightStart,
+					HeightEnd:   v.HeightEnd,
+					OffsetStart: v.OffsetStart,
+					OffsetEnd:   v.OffsetEnd,
+				},
+				Turbo:     v.Turbo,
+				Etching:   v.Etching,
+				Timestamp: v.Timestamp,
+			})
+		}
+
+		//update id index
+		start = uint64(items[len(items)-1].Id)
+	}
+
+	start = 0
+	xylog.Logger.Infof("load rune balances data start...")
+	for {
+		items, err := h.db.GetUnspentRuneBalancesByIdLimit(chain, start, limit)
+		if err != nil {
+			xylog.Logger.Fatalf("failed to initialize rune balances cache data. err:%v", err)
+		}
+
+		if len(items) <= 0 {
+			break
+		}
+
+		for _, v := range items {
+			ok, output := h.Runes.GetOutput(v.Outpoint)
+			if !ok {
+				output = &RuneOutput{
+					Address:  v.Address,
+					Balances: make(map[string]decimal.Decimal, 1),
+				}
+				h.Runes.AddOutput(v.Outpoint, output)
+			}
+			output.Balances[v.RuneId] = v.Amount
+		}
+
+		//update id index
+		start = items[len(items)-1].ID
+	}
+	xylog.Logger.Infof("load runes data finished, cost ts:%v", time.Since(startTs))
+}
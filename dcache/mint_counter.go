@@ -0,0 +1,99 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package dcache
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// MintCounter
+/*****************************************************
+ * mint counts of ticks deployed with mint caps
+ * per address counts are loaded on start, per block counts only track the current block
+ ****************************************************/
+type MintCounter struct {
+	addresses *sync.Map
+	blocks    *sync.Map
+}
+
+type blockMints struct {
+	height uint64
+	count  uint64
+}
+
+func NewMintCounter() *MintCounter {
+	return &MintCounter{
+		addresses: &sync.Map{},
+		blocks:    &sync.Map{},
+	}
+}
+
+func (d *MintCounter) tickIdx(protocol, tick string) string {
+	return fmt.Sprintf("%s_%s", strings.ToLower(protocol), strings.ToLower(tick))
+}
+
+func (d *MintCounter) addressIdx(protocol, tick, address string) string {
+	return fmt.Sprintf("%s_%s_%s", strings.ToLower(protocol), strings.ToLower(tick), strings.ToLower(address))
+}
+
+// Add
+/***************************************
+ * count a mint of the address at the block height
+ ***************************************/
+func (d *MintCounter) Add(protocol, tick, address string, height uint64) {
+	d.SetAddressMints(protocol, tick, address, d.AddressMints(protocol, tick, address)+1)
+
+	idx := d.tickIdx(protocol, tick)
+	if v, ok := d.blocks.Load(idx); ok {
+		if item := v.(*blockMints); item.height == height {
+			item.count++
+			return
+		}
+	}
+	d.blocks.Store(idx, &blockMints{height: height, count: 1})
+}
+
+// AddressMints mints of the address
+func (d *MintCounter) AddressMints(protocol, tick, address string) uint64 {
+	if v, ok := d.addresses.Load(d.addressIdx(protocol, tick, address)); ok {
+		return v.(uint64)
+	}
+	return 0
+}
+
+// SetAddressMints set mints of the address
+func (d *MintCounter) SetAddressMints(protocol, tick, address string, count uint64) {
+	d.addresses.Store(d.addressIdx(protocol, tick, address), count)
+}
+
+// BlockMints mints of the tick at the block height
+func (d *MintCounter) BlockMints(protocol, tick string, height uint64) uint64 {
+	if v, ok := d.blocks.Load(d.tickIdx(protocol, tick)); ok {
+		if item := v.(*blockMints); item.height == height {
+			return item.count
+		}
+	}
+	return 0
+}
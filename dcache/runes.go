@@ -0,0 +1,177 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package dcache
+
+import (
+	"github.com/shopspring/decimal"
+	"sync"
+	"sync/atomic"
+)
+
+// Runes
+/*****************************************************
+ * Build cache for all rune entries & unspent rune outputs
+ * Mainly used for mint terms checking & edict allocation
+ ****************************************************/
+type Runes struct {
+	entries *sync.Map // rune id -> rune entry
+	names   *sync.Map // rune name -> rune id
+	outputs *sync.Map // txid:vout -> rune output
+	number  uint64    // etched runes count
+}
+
+type RuneTerms struct {
+	Amount      decimal.Decimal
+	Cap         decimal.Decimal
+	HeightStart *uint64
+	HeightEnd   *uint64
+	OffsetStart *uint64
+	OffsetEnd   *uint64
+}
+
+type RuneEntry struct {
+	Id           string
+	Block        uint64
+	Tx           uint32
+	Rune         string
+	Spacers      uint32
+	Number       uint64
+	Divisibility uint8
+	Symbol       string
+	Premine      decimal.Decimal
+	Burned       decimal.Decimal
+	Mints        uint64
+	Terms        *RuneTerms
+	Turbo        bool
+	Etching      string
+	Timestamp    int64
+}
+
+type RuneOutput struct {
+	Address  string
+	Balances map[string]decimal.Decimal // rune id -> amount
+}
+
+func NewRunes() *Runes {
+	return &Runes{
+		entries: &sync.Map{},
+		names:   &sync.Map{},
+		outputs: &sync.Map{},
+	}
+}
+
+// Create
+/***************************************
+ * Add new etched rune entry
+ ***************************************/
+func (d *Runes) Create(entry *RuneEntry) {
+	d.entries.Store(entry.Id, entry)
+	d.names.Store(entry.Rune, entry.Id)
+	if entry.Number >= atomic.LoadUint64(&d.number) {
+		atomic.StoreUint64(&d.number, entry.Number+1)
+	}
+}
+
+// Get
+/***************************************
+ * get rune entry by rune id (block:tx)
+ ***************************************/
+func (d *Runes) Get(id string) (bool, *RuneEntry) {
+	item, ok := d.entries.Load(id)
+	if !ok {
+		return false, nil
+	}
+	return true, item.(*RuneEntry)
+}
+
+// GetIdByName
+/***************************************
+ * get rune id by rune name without spacers
+ ***************************************/
+func (d *Runes) GetIdByName(name string) (bool, string) {
+	id, ok := d.names.Load(name)
+	if !ok {
+		return false, ""
+	}
+	return true, id.(string)
+}
+
+// Number
+/***************************************
+ * number of the next etched rune
+ ***************************************/
+func (d *Runes) Number() uint64 {
+	return atomic.LoadUint64(&d.number)
+}
+
+// Mint
+/***************************************
+ * increase rune mints count
+ ***************************************/
+func (d *Runes) Mint(id string) {
+	if ok, entry := d.Get(id); ok {
+		entry.Mints++
+	}
+}
+
+// Burn
+/***************************************
+ * increase rune burned amount
+ ***************************************/
+func (d *Runes) Burn(id string, amount decimal.Decimal) {
+	if ok, entry := d.Get(id); ok {
+		entry.Burned = entry.Burned.Add(amount)
+	}
+}
+
+// AddOutput
+/***************************************
+ * Add rune balances of a new output
+ ***************************************/
+func (d *Runes) AddOutput(outpoint string, output *RuneOutput) {
+	d.outputs.Store(outpoint, output)
+}
+
+// GetOutput
+/***************************************
+ * get rune balances of the output
+ ***************************************/
+func (d *Runes) GetOutput(outpoint string) (bool, *RuneOutput) {
+	item, ok := d.outputs.Load(outpoint)
+	if !ok {
+		return false, nil
+	}
+	return true, item.(*RuneOutput)
+}
+
+// SpendOutput
+/***************************************
+ * remove & return rune balances of the spent output
+ ***************************************/
+func (d *Runes) SpendOutput(outpoint string) (bool, *RuneOutput) {
+	item, ok := d.outputs.LoadAndDelete(outpoint)
+	if !ok {
+		return false, nil
+	}
+	return true, item.(*RuneOutput)
+}
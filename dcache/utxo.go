@@ -23,7 +23,9 @@
 package dcache
 
 import (
+	"fmt"
 	"github.com/shopspring/decimal"
+	"strconv"
 	"strings"
 	"sync"
 )
@@ -34,7 +36,8 @@ import (
  * Mainly used for mint & transfer data checking
  ****************************************************/
 type UTXO struct {
-	hashes *sync.Map //record mint hash items
+	outpoints *sync.Map // outpoint (<txid>:<vout>) -> inscriptions sitting on the output
+	ids       *sync.Map // inscription id -> outpoint
 }
 
 type UTXOItem struct {
@@ -43,45 +46,101 @@ type UTXOItem struct {
 	Amount        decimal.Decimal
 	Owner         string
 	InscriptionId string
+	Offset        int64 // sat offset of the inscription within the output
 }
 
 func NewUTXO() *UTXO {
 	return &UTXO{
-		hashes: &sync.Map{},
+		outpoints: &sync.Map{},
+		ids:       &sync.Map{},
 	}
 }
 
+// Satpoint the location of an inscribed sat: <txid>:<vout>:<offset>
+func Satpoint(txid string, vout uint32, offset int64) string {
+	return fmt.Sprintf("%s:%d:%d", txid, vout, offset)
+}
+
+// ParseSatpoint splits the satpoint into the outpoint & the sat offset within the output
+func ParseSatpoint(satpoint string) (string, int64, error) {
+	idx := strings.LastIndex(satpoint, ":")
+	if idx <= 0 || strings.Count(satpoint, ":") != 2 {
+		return "", 0, fmt.Errorf("invalid satpoint[%s]", satpoint)
+	}
+
+	offset, err := strconv.ParseInt(satpoint[idx+1:], 10, 64)
+	if err != nil || offset < 0 {
+		return "", 0, fmt.Errorf("invalid satpoint[%s] offset", satpoint)
+	}
+	return satpoint[:idx], offset, nil
+}
+
 /***************************************
  * idx define utxo unique id
  ***************************************/
-func (d *UTXO) idx(txHash string) string {
-	return strings.ToLower(txHash)
+func (d *UTXO) idx(key string) string {
+	return strings.ToLower(key)
 }
 
 // Add
 /***************************************
- * Add new utxo record
+ * Add new utxo record at the satpoint
  ***************************************/
-func (d *UTXO) Add(protocol, tick, txHash, address string, amount decimal.Decimal, inscriptionId string) {
-	idx := d.idx(txHash)
-	d.hashes.Store(idx, &UTXOItem{
+func (d *UTXO) Add(protocol, tick, satpoint, address string, amount decimal.Decimal, inscriptionId string) error {
+	outpoint, offset, err := ParseSatpoint(satpoint)
+	if err != nil {
+		return err
+	}
+
+	item := &UTXOItem{
 		Protocol:      protocol,
 		Tick:          tick,
 		Amount:        amount,
 		Owner:         address,
 		InscriptionId: inscriptionId,
-	})
+		Offset:        offset,
+	}
+
+	idx := d.idx(outpoint)
+	_, items := d.Get(outpoint)
+	d.outpoints.Store(idx, append(append(make([]*UTXOItem, 0, len(items)+1), items...), item))
+	d.ids.Store(d.idx(inscriptionId), idx)
+	return nil
 }
 
 // Get
 /***************************************
- * get utxo record by mint tx hash
+ * get the utxo records sitting on the outpoint
  ***************************************/
-func (d *UTXO) Get(txHash string) (bool, *UTXOItem) {
-	idx := d.idx(txHash)
-	item, ok := d.hashes.Load(idx)
+func (d *UTXO) Get(outpoint string) (bool, []*UTXOItem) {
+	items, ok := d.outpoints.Load(d.idx(outpoint))
 	if !ok {
 		return false, nil
 	}
-	return true, item.(*UTXOItem)
+	return true, items.([]*UTXOItem)
+}
+
+// Remove
+/***************************************
+ * remove spent utxo record by inscription id
+ ***************************************/
+func (d *UTXO) Remove(inscriptionId string) {
+	outpoint, ok := d.ids.LoadAndDelete(d.idx(inscriptionId))
+	if !ok {
+		return
+	}
+
+	_, items := d.Get(outpoint.(string))
+	left := make([]*UTXOItem, 0, len(items))
+	for _, item := range items {
+		if !strings.EqualFold(item.InscriptionId, inscriptionId) {
+			left = append(left, item)
+		}
+	}
+
+	if len(left) <= 0 {
+		d.outpoints.Delete(outpoint)
+		return
+	}
+	d.outpoints.Store(outpoint, left)
 }
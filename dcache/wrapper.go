@@ -0,0 +1,153 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package dcache
+
+import (
+	"fmt"
+	"github.com/shopspring/decimal"
+	"strings"
+	"sync"
+)
+
+// WrapperItem locked inscription balance & erc-20 supply of a wrapper
+type WrapperItem struct {
+	Name     string
+	Protocol string
+	Tick     string
+	Address  string // holder of the locked inscription tokens
+	Contract string // erc-20 contract
+	Supply   decimal.Decimal
+	Locked   decimal.Decimal
+	diverged bool // last reconciled state
+}
+
+// Diverged the erc-20 supply differs from the locked inscription balance
+func (w *WrapperItem) Diverged() bool {
+	return !w.Supply.Equal(w.Locked)
+}
+
+// Wrapper
+/*****************************************************
+ * wrappers of the chain by name, registered from the config on start
+ * the persisted erc-20 supplies are restored before registering
+ ****************************************************/
+type Wrapper struct {
+	mu        sync.RWMutex
+	items     map[string]*WrapperItem
+	addresses map[string]string
+}
+
+func NewWrapper() *Wrapper {
+	return &Wrapper{
+		items:     make(map[string]*WrapperItem, 4),
+		addresses: make(map[string]string, 4),
+	}
+}
+
+func (d *Wrapper) addressIdx(protocol, tick, address string) string {
+	return fmt.Sprintf("%s_%s_%s", strings.ToLower(protocol), strings.ToLower(tick), strings.ToLower(address))
+}
+
+// Restore the persisted erc-20 supply of the wrapper
+func (d *Wrapper) Restore(name string, supply decimal.Decimal) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if item, ok := d.items[name]; ok {
+		item.Supply = supply
+		return
+	}
+	d.items[name] = &WrapperItem{Name: name, Supply: supply}
+}
+
+// Create register the wrapper, the restored supply is kept
+func (d *Wrapper) Create(item *WrapperItem) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if last, ok := d.items[item.Name]; ok {
+		item.Supply = last.Supply
+	}
+	d.items[item.Name] = item
+	d.addresses[d.addressIdx(item.Protocol, item.Tick, item.Address)] = item.Name
+}
+
+// Get a copy of the wrapper
+func (d *Wrapper) Get(name string) (bool, *WrapperItem) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	item, ok := d.items[name]
+	if !ok || item.Address == "" {
+		return false, nil
+	}
+	cp := *item
+	return true, &cp
+}
+
+// GetByAddress name of the wrapper locking the tick at the address
+func (d *Wrapper) GetByAddress(protocol, tick, address string) (bool, string) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	name, ok := d.addresses[d.addressIdx(protocol, tick, address)]
+	return ok, name
+}
+
+// AddSupply change the erc-20 supply, negative on burns
+func (d *Wrapper) AddSupply(name string, amount decimal.Decimal) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if item, ok := d.items[name]; ok {
+		item.Supply = item.Supply.Add(amount)
+	}
+}
+
+// SetLocked set the inscription balance of the wrapper address
+func (d *Wrapper) SetLocked(name string, amount decimal.Decimal) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if item, ok := d.items[name]; ok {
+		item.Locked = amount
+	}
+}
+
+// Reconcile copies of the wrappers whose diverged state changed since the last reconcile
+func (d *Wrapper) Reconcile() []*WrapperItem {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	items := make([]*WrapperItem, 0, 1)
+	for _, item := range d.items {
+		if item.Address == "" || item.Diverged() == item.diverged {
+			continue
+		}
+		item.diverged = item.Diverged()
+
+		cp := *item
+		items = append(items, &cp)
+	}
+	return items
+}
@@ -50,6 +50,68 @@ func (tc *TxResultHandler) UpdateCache(r *TxResult) {
 	if r.Transfer != nil {
 		tc.updateTransferCache(r)
 	}
+
+	if r.Ethscription != nil {
+		tc.updateEthscriptionCache(r)
+	}
+
+	if r.Wrap != nil {
+		tc.updateWrapCache(r)
+	}
+
+	if r.Freeze != nil {
+		tc.updateFreezeCache(r)
+	}
+}
+
+// updateFreezeCache freeze & unfreeze only move the available balance, the overall balance is unchanged
+func (tc *TxResultHandler) updateFreezeCache(r *TxResult) {
+	tc.cache.InscriptionStats.TxCnt(r.MD.Protocol, r.MD.Tick, 1)
+
+	_, balance := tc.cache.Balance.Get(r.MD.Protocol, r.MD.Tick, r.Freeze.Address)
+	available := balance.Available.Sub(r.Freeze.Amount)
+	if r.MD.Operate == OperateUnfreeze {
+		available = balance.Available.Add(r.Freeze.Amount)
+	}
+	tc.cache.Balance.Update(r.MD.Protocol, r.MD.Tick, r.Freeze.Address, &dcache.BalanceItem{
+		Available: available,
+		Overall:   balance.Overall,
+	})
+}
+
+func (tc *TxResultHandler) updateWrapCache(r *TxResult) {
+	amount := r.Wrap.Amount
+	if r.MD.Operate == OperateWrapBurn {
+		amount = amount.Neg()
+	}
+	tc.cache.Wrapper.AddSupply(r.Wrap.Name, amount)
+}
+
+// updateWrapperLocked the balance of a wrapper address is the locked balance of the wrapper
+func (tc *TxResultHandler) updateWrapperLocked(protocol, tick, address string) {
+	ok, name := tc.cache.Wrapper.GetByAddress(protocol, tick, address)
+	if !ok {
+		return
+	}
+
+	if ok, balance := tc.cache.Balance.Get(protocol, tick, address); ok {
+		tc.cache.Wrapper.SetLocked(name, balance.Overall)
+	}
+}
+
+func (tc *TxResultHandler) updateEthscriptionCache(r *TxResult) {
+	if r.MD.Operate == OperateCreate {
+		item := &dcache.EthscriptionItem{
+			Creator: r.Ethscription.From,
+			Owner:   r.Ethscription.To,
+		}
+		if r.Ethscription.Content != nil {
+			item.UriSha256 = r.Ethscription.Content.UriSha256
+		}
+		tc.cache.Ethscription.Create(r.Ethscription.Id, item)
+		return
+	}
+	tc.cache.Ethscription.Transfer(r.Ethscription.Id, r.Ethscription.To)
 }
 
 func (tc *TxResultHandler) updateDeployCache(r *TxResult) {
@@ -58,6 +120,14 @@ func (tc *TxResultHandler) updateDeployCache(r *TxResult) {
 		LimitPerMint: r.Deploy.MintLimit,
 		TotalSupply:  r.Deploy.MaxSupply,
 		Decimals:     r.Deploy.Decimal,
+		DeployBy:     r.Tx.From,
+	}
+	if p := r.Deploy.Params; p != nil {
+		t.StartBlock = p.StartBlock
+		t.EndBlock = p.EndBlock
+		t.MaxMintsPerAddress = p.MaxMintsPerAddress
+		t.MaxMintsPerBlock = p.MaxMintsPerBlock
+		t.DeployerOnly = p.DeployerOnly
 	}
 	tc.cache.Inscription.Create(r.MD.Protocol, r.MD.Tick, t)
 
@@ -69,15 +139,26 @@ func (tc *TxResultHandler) updateDeployCache(r *TxResult) {
 }
 
 func (tc *TxResultHandler) updateMintCache(r *TxResult) {
-	//Update mint stats
-	tc.cache.InscriptionStats.Mint(r.MD.Protocol, r.MD.Tick, r.Mint.Amount)
+	//Update mint stats, bridge mints credit tokens locked on another chain as the bridged supply
 	tc.cache.InscriptionStats.TxCnt(r.MD.Protocol, r.MD.Tick, 1)
+	if r.Mint.Bridge {
+		tc.cache.InscriptionStats.Bridge(r.MD.Protocol, r.MD.Tick, r.Mint.Amount)
+	} else {
+		tc.cache.InscriptionStats.Mint(r.MD.Protocol, r.MD.Tick, r.Mint.Amount)
+		r.Mint.SN = tc.cache.InscriptionStats.MintSN(r.MD.Protocol, r.MD.Tick)
+
+		//Count mints of capped ticks
+		if ok, tick := tc.cache.Inscription.Get(r.MD.Protocol, r.MD.Tick); ok && tick.MintCapped() {
+			tc.cache.MintCounter.Add(r.MD.Protocol, r.MD.Tick, r.Mint.Minter, r.Block.Number.Uint64())
+		}
+	}
 
 	//Update minter balances
 	ok, balance := tc.cache.Balance.Get(r.MD.Protocol, r.MD.Tick, r.Mint.Minter)
 	if !ok {
 		tc.cache.Balance.Create(r.MD.Protocol, r.MD.Tick, r.Mint.Minter, &dcache.BalanceItem{
-			Overall: r.Mint.Amount,
+			Available: r.Mint.Amount,
+			Overall:   r.Mint.Amount,
 		})
 		tc.cache.InscriptionStats.Holders(r.MD.Protocol, r.MD.Tick, 1)
 
@@ -88,11 +169,12 @@ func (tc *TxResultHandler) updateMintCache(r *TxResult) {
 			tc.cache.InscriptionStats.Holders(r.MD.Protocol, r.MD.Tick, 1)
 		}
 
-		amount := balance.Overall.Add(r.Mint.Amount)
 		tc.cache.Balance.Update(r.MD.Protocol, r.MD.Tick, r.Mint.Minter, &dcache.BalanceItem{
-			Overall: amount,
+			Available: balance.Available.Add(r.Mint.Amount),
+			Overall:   balance.Overall.Add(r.Mint.Amount),
 		})
 	}
+	tc.updateWrapperLocked(r.MD.Protocol, r.MD.Tick, r.Mint.Minter)
 }
 
 func (tc *TxResultHandler) updateTransferCache(r *TxResult) {
@@ -108,37 +190,52 @@ func (tc *TxResultHandler) updateTransferCache(r *TxResult) {
 	holders := int64(0)
 	_, senderBalance := tc.cache.Balance.Get(r.MD.Protocol, r.MD.Tick, r.Transfer.Sender)
 	senderAmount := senderBalance.Overall.Sub(sendTotalAmount)
-	if senderAmount.LessThanOrEqual(decimal.Zero) {
+	if senderAmount.LessThanOrEqual(decimal.Zero) && !r.Transfer.SenderBurn {
 		holders--
 	}
 	tc.cache.Balance.Update(r.MD.Protocol, r.MD.Tick, r.Transfer.Sender, &dcache.BalanceItem{
-		Overall: senderAmount,
+		Available: senderBalance.Available.Sub(sendTotalAmount),
+		Overall:   senderAmount,
 	})
 
 	for _, item := range r.Transfer.Receives {
+		// burned supply, the burn address is still credited but never counted as a holder
+		if item.Burn {
+			tc.cache.InscriptionStats.Burn(r.MD.Protocol, r.MD.Tick, item.Amount)
+		}
+
 		ok, receiveBalance := tc.cache.Balance.Get(r.MD.Protocol, r.MD.Tick, item.Address)
 		if !ok {
-			holders++
+			if !item.Burn {
+				holders++
+			}
 
 			receiveAmount := item.Amount
 			tc.cache.Balance.Create(r.MD.Protocol, r.MD.Tick, item.Address, &dcache.BalanceItem{
-				Overall: receiveAmount,
+				Available: receiveAmount,
+				Overall:   receiveAmount,
 			})
 
 			//mark minter init
 			item.Init = true
 		} else {
-			if receiveBalance.Overall.LessThanOrEqual(decimal.Zero) {
+			if receiveBalance.Overall.LessThanOrEqual(decimal.Zero) && !item.Burn {
 				holders++
 			}
 
-			receiveAmount := receiveBalance.Overall.Add(item.Amount)
 			tc.cache.Balance.Update(r.MD.Protocol, r.MD.Tick, item.Address, &dcache.BalanceItem{
-				Overall: receiveAmount,
+				Available: receiveBalance.Available.Add(item.Amount),
+				Overall:   receiveBalance.Overall.Add(item.Amount),
 			})
 		}
 	}
 
+	//Update locked balances of the wrapper addresses
+	tc.updateWrapperLocked(r.MD.Protocol, r.MD.Tick, r.Transfer.Sender)
+	for _, item := range r.Transfer.Receives {
+		tc.updateWrapperLocked(r.MD.Protocol, r.MD.Tick, item.Address)
+	}
+
 	if holders == 0 {
 		return
 	}
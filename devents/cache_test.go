@@ -0,0 +1,232 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package devents
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/xylog"
+	"math/big"
+	"testing"
+)
+
+func init() {
+	xylog.InitLog(logrus.DebugLevel, "")
+}
+
+func TestUpdateTransferCacheBurn(t *testing.T) {
+	const (
+		protocol = "asc-20"
+		tick     = "avav"
+		sender   = "0x1111111111111111111111111111111111111111"
+		receiver = "0x2222222222222222222222222222222222222222"
+		burner   = "0x000000000000000000000000000000000000dead"
+	)
+	cache := dcache.NewManager(nil, "avalanche")
+	cache.Balance = dcache.NewBalance()
+	cache.InscriptionStats = dcache.NewInscriptionStats()
+	cache.InscriptionStats.Create(protocol, tick, &dcache.InsStats{Minted: decimal.NewFromInt(100), Holders: 1})
+	cache.Balance.Create(protocol, tick, sender, &dcache.BalanceItem{Overall: decimal.NewFromInt(100)})
+
+	tc := NewTxResultHandler(cache)
+	tc.UpdateCache(&TxResult{
+		MD: &MetaData{Protocol: protocol, Tick: tick, Operate: OperateTransfer},
+		Transfer: &Transfer{
+			Sender: sender,
+			Receives: []*Receive{
+				{Address: receiver, Amount: decimal.NewFromInt(30)},
+				{Address: burner, Amount: decimal.NewFromInt(70), Burn: true},
+			},
+		},
+	})
+
+	_, stats := cache.InscriptionStats.Get(protocol, tick)
+	if !stats.Burned.Equal(decimal.NewFromInt(70)) {
+		t.Fatalf("burned got %s, want 70", stats.Burned)
+	}
+
+	// the sender is emptied and the burn address is not a holder
+	if stats.Holders != 1 {
+		t.Fatalf("holders got %d, want 1", stats.Holders)
+	}
+
+	if _, balance := cache.Balance.Get(protocol, tick, burner); !balance.Overall.Equal(decimal.NewFromInt(70)) {
+		t.Fatalf("burn address balance got %s, want 70", balance.Overall)
+	}
+	// a burn address sending its tokens out never was a holder
+	const receiver2 = "0x3333333333333333333333333333333333333333"
+	tc.UpdateCache(&TxResult{
+		MD: &MetaData{Protocol: protocol, Tick: tick, Operate: OperateTransfer},
+		Transfer: &Transfer{
+			Sender:     burner,
+			SenderBurn: true,
+			Receives:   []*Receive{{Address: receiver2, Amount: decimal.NewFromInt(70)}},
+		},
+	})
+
+	_, stats = cache.InscriptionStats.Get(protocol, tick)
+	if stats.Holders != 2 {
+		t.Fatalf("holders after the burn address transfer got %d, want 2", stats.Holders)
+	}
+}
+
+func TestUpdateMintCacheBridge(t *testing.T) {
+	const (
+		protocol  = "asc-20"
+		tick      = "avav"
+		recipient = "0x2222222222222222222222222222222222222222"
+	)
+	cache := dcache.NewManager(nil, "avalanche")
+	cache.Balance = dcache.NewBalance()
+	cache.InscriptionStats = dcache.NewInscriptionStats()
+	cache.InscriptionStats.Create(protocol, tick, &dcache.InsStats{Minted: decimal.NewFromInt(100), Holders: 1, LastSN: 5})
+
+	r := &TxResult{
+		MD:   &MetaData{Protocol: protocol, Tick: tick, Operate: OperateBridgeMint},
+		Mint: &Mint{Minter: recipient, Amount: decimal.NewFromInt(30), Bridge: true},
+	}
+	NewTxResultHandler(cache).UpdateCache(r)
+
+	// bridged tokens are credited as the bridged supply without touching the minted supply & mint sequence
+	_, stats := cache.InscriptionStats.Get(protocol, tick)
+	if !stats.Minted.Equal(decimal.NewFromInt(100)) || stats.LastSN != 5 || r.Mint.SN != 0 {
+		t.Fatalf("minted got %s last sn[%d] sn[%d], want 100 5 0", stats.Minted, stats.LastSN, r.Mint.SN)
+	}
+	if !stats.Bridged.Equal(decimal.NewFromInt(30)) {
+		t.Fatalf("bridged got %s, want 30", stats.Bridged)
+	}
+
+	if stats.Holders != 2 {
+		t.Fatalf("holders got %d, want 2", stats.Holders)
+	}
+
+	if _, balance := cache.Balance.Get(protocol, tick, recipient); !balance.Overall.Equal(decimal.NewFromInt(30)) {
+		t.Fatalf("recipient balance got %s, want 30", balance.Overall)
+	}
+}
+
+func TestUpdateCacheWrapper(t *testing.T) {
+	const (
+		protocol = "asc-20"
+		tick     = "avav"
+		sender   = "0x1111111111111111111111111111111111111111"
+		wrapper  = "0x2222222222222222222222222222222222222222"
+	)
+	cache := dcache.NewManager(nil, "avalanche")
+	cache.Balance = dcache.NewBalance()
+	cache.InscriptionStats = dcache.NewInscriptionStats()
+	cache.InscriptionStats.Create(protocol, tick, &dcache.InsStats{Minted: decimal.NewFromInt(100), Holders: 1})
+	cache.Balance.Create(protocol, tick, sender, &dcache.BalanceItem{Overall: decimal.NewFromInt(100)})
+	cache.Wrapper.Create(&dcache.WrapperItem{Name: "w", Protocol: protocol, Tick: tick, Address: wrapper})
+
+	// locked by the transfer, diverged until the erc-20 mint
+	tc := NewTxResultHandler(cache)
+	tc.UpdateCache(&TxResult{
+		MD: &MetaData{Protocol: protocol, Tick: tick, Operate: OperateTransfer},
+		Transfer: &Transfer{
+			Sender:   sender,
+			Receives: []*Receive{{Address: wrapper, Amount: decimal.NewFromInt(40)}},
+		},
+	})
+	if items := cache.Wrapper.Reconcile(); len(items) != 1 || !items[0].Diverged() || !items[0].Locked.Equal(decimal.NewFromInt(40)) {
+		t.Fatalf("wrapper must diverge after the lock, got %+v", items)
+	}
+
+	tc.UpdateCache(&TxResult{
+		MD:   &MetaData{Protocol: protocol, Tick: tick, Operate: OperateWrapMint},
+		Wrap: &Wrap{Name: "w", Amount: decimal.NewFromInt(40)},
+	})
+	if items := cache.Wrapper.Reconcile(); len(items) != 1 || items[0].Diverged() {
+		t.Fatalf("wrapper must reconcile after the mint, got %+v", items)
+	}
+
+	// burned & released together, no state change
+	tc.UpdateCache(&TxResult{
+		MD:   &MetaData{Protocol: protocol, Tick: tick, Operate: OperateWrapBurn},
+		Wrap: &Wrap{Name: "w", Amount: decimal.NewFromInt(15)},
+	})
+	tc.UpdateCache(&TxResult{
+		MD: &MetaData{Protocol: protocol, Tick: tick, Operate: OperateTransfer},
+		Transfer: &Transfer{
+			Sender:   wrapper,
+			Receives: []*Receive{{Address: sender, Amount: decimal.NewFromInt(15)}},
+		},
+	})
+	if items := cache.Wrapper.Reconcile(); len(items) != 0 {
+		t.Fatalf("no wrapper state change expected, got %+v", items)
+	}
+
+	_, w := cache.Wrapper.Get("w")
+	if !w.Supply.Equal(decimal.NewFromInt(25)) || !w.Locked.Equal(decimal.NewFromInt(25)) {
+		t.Fatalf("wrapper supply[%s] locked[%s], want 25", w.Supply, w.Locked)
+	}
+}
+
+func TestUpdateCacheFreeze(t *testing.T) {
+	const (
+		protocol = "asc-20"
+		tick     = "avav"
+		holder   = "0x1111111111111111111111111111111111111111"
+		receiver = "0x2222222222222222222222222222222222222222"
+	)
+	cache := dcache.NewManager(nil, "avalanche")
+	cache.Balance = dcache.NewBalance()
+	cache.InscriptionStats = dcache.NewInscriptionStats()
+	cache.InscriptionStats.Create(protocol, tick, &dcache.InsStats{})
+	cache.Inscription = dcache.NewInscription()
+	cache.Inscription.Create(protocol, tick, &dcache.Tick{})
+
+	tc := NewTxResultHandler(cache)
+	tc.UpdateCache(&TxResult{
+		MD:    &MetaData{Protocol: protocol, Tick: tick, Operate: OperateMint},
+		Block: &xycommon.RpcBlock{Number: big.NewInt(1)},
+		Mint:  &Mint{Minter: holder, Amount: decimal.NewFromInt(100)},
+	})
+	tc.UpdateCache(&TxResult{
+		MD:     &MetaData{Protocol: protocol, Tick: tick, Operate: OperateFreeze},
+		Freeze: &Freeze{Address: holder, Amount: decimal.NewFromInt(70)},
+	})
+	tc.UpdateCache(&TxResult{
+		MD: &MetaData{Protocol: protocol, Tick: tick, Operate: OperateTransfer},
+		Transfer: &Transfer{
+			Sender:   holder,
+			Receives: []*Receive{{Address: receiver, Amount: decimal.NewFromInt(30)}},
+		},
+	})
+	tc.UpdateCache(&TxResult{
+		MD:     &MetaData{Protocol: protocol, Tick: tick, Operate: OperateUnfreeze},
+		Freeze: &Freeze{Address: holder, Amount: decimal.NewFromInt(20)},
+	})
+
+	_, balance := cache.Balance.Get(protocol, tick, holder)
+	if !balance.Overall.Equal(decimal.NewFromInt(70)) || !balance.Available.Equal(decimal.NewFromInt(20)) {
+		t.Fatalf("holder overall[%s] available[%s], want 70 & 20", balance.Overall, balance.Available)
+	}
+
+	_, balance = cache.Balance.Get(protocol, tick, receiver)
+	if !balance.Overall.Equal(decimal.NewFromInt(30)) || !balance.Available.Equal(decimal.NewFromInt(30)) {
+		t.Fatalf("receiver overall[%s] available[%s], want 30", balance.Overall, balance.Available)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package devents
+
+import (
+	"fmt"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/model"
+)
+
+// mergeCandleTrade merges a filled listing into the candles of every period,
+// fills must be merged in block & tx order so that open / close are kept correct
+func mergeCandleTrade(candles map[string]*model.TickCandles, item *model.Listings) {
+	if item.Status != model.ListingStatusFilled || item.UnitPrice.LessThanOrEqual(decimal.Zero) {
+		return
+	}
+
+	for period, seconds := range model.CandlePeriods {
+		openTime := item.CloseTimestamp - item.CloseTimestamp%seconds
+		key := fmt.Sprintf("%s_%s_%s_%s_%d", item.Chain, item.Protocol, item.Tick, period, openTime)
+
+		candle, ok := candles[key]
+		if !ok {
+			candles[key] = &model.TickCandles{
+				Chain:    item.Chain,
+				Protocol: item.Protocol,
+				Tick:     item.Tick,
+				Period:   period,
+				OpenTime: openTime,
+				Open:     item.UnitPrice,
+				High:     item.UnitPrice,
+				Low:      item.UnitPrice,
+				Close:    item.UnitPrice,
+				Volume:   item.Amount,
+				Turnover: item.Price,
+				Trades:   1,
+			}
+			continue
+		}
+
+		if item.UnitPrice.GreaterThan(candle.High) {
+			candle.High = item.UnitPrice
+		}
+		if item.UnitPrice.LessThan(candle.Low) {
+			candle.Low = item.UnitPrice
+		}
+		candle.Close = item.UnitPrice
+		candle.Volume = candle.Volume.Add(item.Amount)
+		candle.Turnover = candle.Turnover.Add(item.Price)
+		candle.Trades++
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package devents
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/model"
+	"testing"
+)
+
+func TestMergeCandleTrade(t *testing.T) {
+	fill := func(ts int64, amount, price int64) *model.Listings {
+		return &model.Listings{
+			Chain:          model.ChainAVAX,
+			Protocol:       "asc-20",
+			Tick:           "avav",
+			Amount:         decimal.NewFromInt(amount),
+			Price:          decimal.NewFromInt(price),
+			UnitPrice:      decimal.NewFromInt(price).DivRound(decimal.NewFromInt(amount), 18),
+			Status:         model.ListingStatusFilled,
+			CloseTimestamp: ts,
+		}
+	}
+
+	candles := make(map[string]*model.TickCandles)
+	mergeCandleTrade(candles, fill(1713571210, 10, 50)) // 5
+	mergeCandleTrade(candles, fill(1713571230, 10, 80)) // 8
+	mergeCandleTrade(candles, fill(1713571250, 10, 30)) // 3
+	mergeCandleTrade(candles, fill(1713571270, 10, 60)) // 6, next minute
+	mergeCandleTrade(candles, &model.Listings{Status: model.ListingStatusCancelled, CloseTimestamp: 1713571280})
+
+	counts := make(map[string]int)
+	for _, c := range candles {
+		counts[c.Period]++
+	}
+	if counts[model.CandlePeriod1m] != 2 || counts[model.CandlePeriod1h] != 1 || counts[model.CandlePeriod1d] != 1 {
+		t.Fatalf("unexpected candles count %v", counts)
+	}
+
+	for _, c := range candles {
+		if c.Period == model.CandlePeriod1m && c.OpenTime == 1713571260 {
+			if c.Trades != 1 || !c.Open.Equal(decimal.NewFromInt(6)) {
+				t.Fatalf("unexpected minute candle %+v", c)
+			}
+			continue
+		}
+
+		expectedTrades := uint64(4)
+		expectedClose := decimal.NewFromInt(6)
+		if c.Period == model.CandlePeriod1m {
+			expectedTrades = 3
+			expectedClose = decimal.NewFromInt(3)
+		}
+
+		if c.OpenTime%model.CandlePeriods[c.Period] != 0 {
+			t.Fatalf("candle[%s] open time[%d] not aligned", c.Period, c.OpenTime)
+		}
+		if c.Trades != expectedTrades || !c.Open.Equal(decimal.NewFromInt(5)) || !c.High.Equal(decimal.NewFromInt(8)) ||
+			!c.Low.Equal(decimal.NewFromInt(3)) || !c.Close.Equal(expectedClose) {
+			t.Fatalf("unexpected candle %+v", c)
+		}
+		if !c.Volume.Equal(decimal.NewFromInt(int64(expectedTrades) * 10)) {
+			t.Fatalf("unexpected candle[%s] volume %s", c.Period, c.Volume)
+		}
+	}
+}
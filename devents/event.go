@@ -43,16 +43,18 @@ type Event struct {
 }
 
 type DEvent struct {
-	ctx    context.Context
-	events chan *Event
-	db     *storage.DBClient
+	ctx        context.Context
+	events     chan *Event
+	db         *storage.DBClient
+	chainGroup model.ChainGroup // configured chain group, btc balances are upserted
 }
 
-func NewDEvents(ctx context.Context, db *storage.DBClient) *DEvent {
+func NewDEvents(ctx context.Context, db *storage.DBClient, chainGroup model.ChainGroup) *DEvent {
 	return &DEvent{
-		ctx:    ctx,
-		db:     db,
-		events: make(chan *Event, 1024),
+		ctx:        ctx,
+		db:         db,
+		events:     make(chan *Event, 1024),
+		chainGroup: chainGroup,
 	}
 }
 
@@ -247,7 +249,7 @@ func (h *DEvent) Sink(db *storage.DBClient) bool {
 		//update balances
 		if items := dm.Balances[DBActionCreate]; len(items) > 0 {
 
-			if chain == model.ChainBTC {
+			if h.chainGroup == model.BtcChainGroup {
 				if err := db.InsertOrUpdateBalances(tx, items); err != nil {
 					xylog.Logger.Errorf("failed insert or update balances records. err=%s", err)
 					return err
@@ -286,6 +288,114 @@ func (h *DEvent) Sink(db *storage.DBClient) bool {
 			}
 		}
 
+		// insert ethscriptions
+		if items := dm.Ethscriptions[DBActionCreate]; len(items) > 0 {
+			if err := db.BatchAddEthscriptions(tx, items); err != nil {
+				xylog.Logger.Errorf("failed insert ethscriptions records. err=%s", err)
+				return err
+			}
+		}
+
+		// insert inscription contents, the same content is stored once
+		if items := dm.Contents; len(items) > 0 {
+			if err := db.BatchAddInscriptionContents(tx, items); err != nil {
+				xylog.Logger.Errorf("failed insert inscription contents records. err=%s", err)
+				return err
+			}
+		}
+
+		// update ethscriptions owners
+		if items := dm.Ethscriptions[DBActionUpdate]; len(items) > 0 {
+			err := db.BatchUpdateEthscriptions(tx, chain, items)
+			if err != nil {
+				xylog.Logger.Errorf("failed update ethscriptions records. err=%s", err)
+				return err
+			}
+		}
+
+		// insert runes
+		if items := dm.Runes[DBActionCreate]; len(items) > 0 {
+			if err := db.BatchAddRunes(tx, items); err != nil {
+				xylog.Logger.Errorf("failed insert runes records. err=%s", err)
+				return err
+			}
+		}
+
+		// update runes mints & burned
+		if items := dm.Runes[DBActionUpdate]; len(items) > 0 {
+			err := db.BatchUpdateRunes(tx, chain, items)
+			if err != nil {
+				xylog.Logger.Errorf("failed update runes records. err=%s", err)
+				return err
+			}
+		}
+
+		// insert rune balances
+		if items := dm.RuneBalances[DBActionCreate]; len(items) > 0 {
+			if err := db.BatchAddRuneBalances(tx, items); err != nil {
+				xylog.Logger.Errorf("failed insert rune balances records. err=%s", err)
+				return err
+			}
+		}
+
+		// spend rune balances
+		if items := dm.RuneBalances[DBActionUpdate]; len(items) > 0 {
+			err := db.BatchUpdateRuneBalances(tx, chain, items)
+			if err != nil {
+				xylog.Logger.Errorf("failed update rune balances records. err=%s", err)
+				return err
+			}
+		}
+
+		// insert rune events
+		if len(dm.RuneEvents) > 0 {
+			if err := db.BatchAddRuneEvents(tx, dm.RuneEvents); err != nil {
+				xylog.Logger.Errorf("failed insert rune events records. err=%s", err)
+				return err
+			}
+		}
+
+		// insert listings
+		if items := dm.Listings[DBActionCreate]; len(items) > 0 {
+			if err := db.BatchAddListings(tx, items); err != nil {
+				xylog.Logger.Errorf("failed insert listings records. err=%s", err)
+				return err
+			}
+		}
+
+		// close listings, cancelled / filled
+		if items := dm.Listings[DBActionUpdate]; len(items) > 0 {
+			err := db.UpsertClosedListings(tx, items)
+			if err != nil {
+				xylog.Logger.Errorf("failed update listings records. err=%s", err)
+				return err
+			}
+		}
+
+		// upsert bridge transfers, pending until both sides are indexed
+		if len(dm.BridgeTransfers) > 0 {
+			if err := db.UpsertBridgeTransfers(tx, dm.BridgeTransfers); err != nil {
+				xylog.Logger.Errorf("failed upsert bridge transfers records. err=%s", err)
+				return err
+			}
+		}
+
+		// save wrapper supplies & locked balances
+		if len(dm.Wrappers) > 0 {
+			if err := db.UpsertWrappers(tx, dm.Wrappers); err != nil {
+				xylog.Logger.Errorf("failed upsert wrappers records. err=%s", err)
+				return err
+			}
+		}
+
+		// merge tick candles
+		if len(dm.TickCandles) > 0 {
+			if err := db.UpsertTickCandles(tx, dm.TickCandles); err != nil {
+				xylog.Logger.Errorf("failed upsert tick candles records. err=%s", err)
+				return err
+			}
+		}
+
 		// record block status
 		if err := db.SaveLastBlock(tx, dm.BlockStatus); err != nil {
 			xylog.Logger.Errorf("failed to save block information. err=%s", err)
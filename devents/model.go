@@ -24,10 +24,12 @@ package devents
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/shopspring/decimal"
 	"github.com/uxuycom/indexer/model"
 	"github.com/uxuycom/indexer/xylog"
+	"strings"
 	"time"
 )
 
@@ -46,19 +48,66 @@ type DBModelEvent struct {
 	AddressTxs       []*model.AddressTxs
 	BalanceTxs       []*model.BalanceTxn
 	UTXOs            map[DBAction]*model.UTXO
+	Ethscriptions    map[DBAction]*model.Ethscriptions
+	Contents         *model.InscriptionContents
+	Runes            map[DBAction][]*model.Runes
+	RuneBalances     map[DBAction][]*model.RuneBalances
+	RuneEvents       []*model.RuneEvents
+	Listings         map[DBAction]*model.Listings
+	BridgeTransfer   *model.BridgeTransfers
+	Wrappers         []*model.Wrappers
 }
 
 func (tc *TxResultHandler) BuildModel(r *TxResult) *DBModelEvent {
 	dm := &DBModelEvent{}
 
+	// wrapper erc-20 events only change the wrapped supply, no inscription tx
+	if r.Wrap != nil {
+		dm.Wrappers = tc.BuildWrappers(r)
+		return dm
+	}
+
 	dm.Tx = tc.BuildTx(r)
+
+	// ethscriptions have no tick, only tx & ownership records
+	if r.Ethscription != nil {
+		dm.Ethscriptions = tc.BuildEthscription(r)
+		dm.Contents = tc.BuildContent(r)
+		dm.AddressTxs = tc.BuildAddressTxs(r)
+		return dm
+	}
+	dm.Ethscriptions = tc.BuildTokenContent(r)
+	dm.Contents = tc.BuildContent(r)
 	dm.Inscriptions = tc.BuildInscription(r)
 	dm.InscriptionStats = tc.BuildInscriptionStat(r)
 	dm.BalanceTxs, dm.Balances = tc.BuildBalance(r)
 	dm.AddressTxs = tc.BuildAddressTxs(r)
+	dm.Listings = tc.BuildListing(r)
+	dm.BridgeTransfer = tc.BuildBridgeTransfer(r)
+	dm.Wrappers = tc.BuildWrappers(r)
 	return dm
 }
 
+// FinalizeModel set the inscription number & the deferred balance sids of a model built in a concurrent tick shard,
+// models must be finalized in tx order so the numbers & sids match sequential parsing
+func (tc *TxResultHandler) FinalizeModel(r *TxResult, dm *DBModelEvent, number int64) {
+	r.Number = number
+	if dm.Tx != nil {
+		dm.Tx.InscriptionNumber = number
+	}
+	if item := dm.Ethscriptions[DBActionCreate]; item != nil {
+		item.Number = uint64(number)
+	}
+
+	for _, action := range []DBAction{DBActionCreate, DBActionUpdate} {
+		for _, item := range dm.Balances[action] {
+			if item.SID <= 0 {
+				item.SID = tc.cache.Balance.AssignSid(item.Protocol, item.Tick, item.Address)
+			}
+		}
+	}
+}
+
 func (tc *TxResultHandler) BuildInscription(e *TxResult) map[DBAction]*model.Inscriptions {
 	if e.Deploy == nil {
 		return nil
@@ -67,17 +116,25 @@ func (tc *TxResultHandler) BuildInscription(e *TxResult) map[DBAction]*model.Ins
 	_, d := tc.cache.Inscription.Get(e.MD.Protocol, e.MD.Tick)
 	ret := make(map[DBAction]*model.Inscriptions, 1)
 	ret[DBActionCreate] = &model.Inscriptions{
-		SID:          d.SID,
-		Chain:        e.MD.Chain,
-		Protocol:     e.MD.Protocol,
-		Tick:         e.MD.Tick,
-		Name:         e.Deploy.Name,
-		LimitPerMint: e.Deploy.MintLimit,
-		TotalSupply:  e.Deploy.MaxSupply,
-		DeployBy:     e.Tx.From,
-		DeployHash:   e.Tx.Hash,
-		DeployTime:   time.Unix(int64(e.Block.Time), 0),
-		Decimals:     e.Deploy.Decimal,
+		SID:               d.SID,
+		InscriptionNumber: e.Number,
+		Chain:             e.MD.Chain,
+		Protocol:          e.MD.Protocol,
+		Tick:              e.MD.Tick,
+		Name:              e.Deploy.Name,
+		LimitPerMint:      e.Deploy.MintLimit,
+		TotalSupply:       e.Deploy.MaxSupply,
+		DeployBy:          e.Tx.From,
+		DeployHash:        e.Tx.Hash,
+		DeployTime:        time.Unix(int64(e.Block.Time), 0),
+		Decimals:          e.Deploy.Decimal,
+	}
+	if p := e.Deploy.Params; p != nil {
+		ret[DBActionCreate].StartBlock = p.StartBlock
+		ret[DBActionCreate].EndBlock = p.EndBlock
+		ret[DBActionCreate].MaxMintsPerAddress = p.MaxMintsPerAddress
+		ret[DBActionCreate].MaxMintsPerBlock = p.MaxMintsPerBlock
+		ret[DBActionCreate].DeployerOnly = p.DeployerOnly
 	}
 	return ret
 }
@@ -93,6 +150,9 @@ func (tc *TxResultHandler) BuildInscriptionStat(e *TxResult) map[DBAction]*model
 		Minted:   d.Minted,
 		Holders:  uint64(d.Holders),
 		TxCnt:    d.TxCnt,
+		LastSN:   d.LastSN,
+		Burned:   d.Burned,
+		Bridged:  d.Bridged,
 	}
 
 	// update mint stats
@@ -113,6 +173,7 @@ func (tc *TxResultHandler) BuildInscriptionStat(e *TxResult) map[DBAction]*model
 	}
 
 	if e.Deploy != nil {
+		data.InscriptionNumber = e.Number
 		return map[DBAction]*model.InscriptionsStats{
 			DBActionCreate: data,
 		}
@@ -123,6 +184,219 @@ func (tc *TxResultHandler) BuildInscriptionStat(e *TxResult) map[DBAction]*model
 	}
 }
 
+func (tc *TxResultHandler) BuildEthscription(e *TxResult) map[DBAction]*model.Ethscriptions {
+	if e.MD.Operate == OperateCreate {
+		items := map[DBAction]*model.Ethscriptions{
+			DBActionCreate: {
+				Chain:          e.MD.Chain,
+				EthscriptionId: e.Ethscription.Id,
+				Creator:        e.Ethscription.From,
+				Owner:          e.Ethscription.To,
+				Number:         uint64(e.Number),
+				BlockHeight:    e.Block.Number.Uint64(),
+				CreatedAt:      time.Unix(int64(e.Block.Time), 0),
+				UpdatedAt:      time.Unix(int64(e.Block.Time), 0),
+			},
+		}
+		if c := e.Ethscription.Content; c != nil {
+			item := items[DBActionCreate]
+			item.ContentType = c.ContentType
+			item.ContentSize = len(c.Data)
+			item.ContentSha256 = c.Sha256
+			item.UriSha256 = c.UriSha256
+		}
+		return items
+	}
+
+	return map[DBAction]*model.Ethscriptions{
+		DBActionUpdate: {
+			Chain:          e.MD.Chain,
+			EthscriptionId: e.Ethscription.Id,
+			Owner:          e.Ethscription.To,
+			PreviousOwner:  e.Ethscription.From,
+			UpdatedAt:      time.Unix(int64(e.Block.Time), 0),
+		},
+	}
+}
+
+// BuildTokenContent content object of a token inscription, kept in the ethscriptions table with its protocol.
+// The token protocols track no ownership, the owner is the recipient of the inscribing tx
+func (tc *TxResultHandler) BuildTokenContent(e *TxResult) map[DBAction]*model.Ethscriptions {
+	c := e.MD.Content
+	if c == nil {
+		return nil
+	}
+
+	// the results of a tx share one content object
+	id := e.Tx.Hash
+	if e.Tx.SubIndex > 0 {
+		id = fmt.Sprintf("%s:%d", e.Tx.Hash, e.Tx.SubIndex)
+	}
+	return map[DBAction]*model.Ethscriptions{
+		DBActionCreate: {
+			Chain:          e.MD.Chain,
+			Protocol:       e.MD.Protocol,
+			EthscriptionId: id,
+			Creator:        e.Tx.From,
+			Owner:          e.Tx.To,
+			Number:         uint64(e.Number),
+			ContentType:    c.ContentType,
+			ContentSize:    len(c.Data),
+			ContentSha256:  c.Sha256,
+			BlockHeight:    e.Block.Number.Uint64(),
+			CreatedAt:      time.Unix(int64(e.Block.Time), 0),
+			UpdatedAt:      time.Unix(int64(e.Block.Time), 0),
+		},
+	}
+}
+
+// BuildContent content store record of a created inscription, deduplicated by sha256 on sink
+func (tc *TxResultHandler) BuildContent(e *TxResult) *model.InscriptionContents {
+	c := e.MD.Content
+	if e.Ethscription != nil {
+		if e.MD.Operate != OperateCreate {
+			return nil
+		}
+		c = e.Ethscription.Content
+	}
+	if c == nil {
+		return nil
+	}
+
+	return &model.InscriptionContents{
+		Sha256:    c.Sha256,
+		Size:      len(c.Data),
+		Content:   c.Data,
+		CreatedAt: time.Unix(int64(e.Block.Time), 0),
+	}
+}
+
+func (tc *TxResultHandler) BuildListing(e *TxResult) map[DBAction]*model.Listings {
+	if e.Listing == nil {
+		return nil
+	}
+
+	item := &model.Listings{
+		Chain:       e.MD.Chain,
+		Protocol:    e.MD.Protocol,
+		Tick:        e.MD.Tick,
+		ListId:      strings.ToLower(e.Listing.ListId),
+		Marketplace: strings.ToLower(e.Listing.Marketplace),
+		Seller:      strings.ToLower(e.Listing.Seller),
+		Amount:      e.Listing.Amount,
+	}
+
+	if e.MD.Operate == OperateList {
+		item.Status = model.ListingStatusActive
+		item.ListTxHash = e.Tx.Hash
+		item.ListBlock = e.Block.Number.Uint64()
+		item.ListTimestamp = int64(e.Block.Time)
+		return map[DBAction]*model.Listings{
+			DBActionCreate: item,
+		}
+	}
+
+	item.Status = model.ListingStatusCancelled
+	if e.MD.Operate == OperateExchange {
+		item.Status = model.ListingStatusFilled
+		item.Buyer = strings.ToLower(e.Listing.Buyer)
+	}
+	item.Price = e.Listing.Price
+	if e.Listing.Amount.GreaterThan(decimal.Zero) {
+		item.UnitPrice = e.Listing.Price.DivRound(e.Listing.Amount, 18)
+	}
+	item.CloseTxHash = e.Tx.Hash
+	item.CloseBlock = e.Block.Number.Uint64()
+	item.CloseTimestamp = int64(e.Block.Time)
+	return map[DBAction]*model.Listings{
+		DBActionUpdate: item,
+	}
+}
+
+// BuildBridgeTransfer the lock builds the source side of the bridge transfer, the release / mint the destination side
+func (tc *TxResultHandler) BuildBridgeTransfer(e *TxResult) *model.BridgeTransfers {
+	if e.Bridge == nil {
+		return nil
+	}
+
+	item := &model.BridgeTransfers{
+		Bridge:    e.Bridge.Name,
+		BridgeId:  e.Bridge.Id,
+		Protocol:  e.MD.Protocol,
+		Tick:      e.MD.Tick,
+		Amount:    e.Bridge.Amount,
+		Sender:    strings.ToLower(e.Bridge.From),
+		Recipient: strings.ToLower(e.Bridge.To),
+		Status:    model.BridgeTransferStatusPending,
+	}
+
+	if e.MD.Operate == OperateBridgeLock {
+		item.SourceChain = e.MD.Chain
+		item.SourceContract = e.Bridge.Contract
+		item.SourceTxHash = e.Tx.Hash
+		item.SourceBlock = e.Block.Number.Uint64()
+		item.SourceTimestamp = int64(e.Block.Time)
+		item.DestChain = e.Bridge.Chain
+		return item
+	}
+
+	item.SourceChain = e.Bridge.Chain
+	item.DestChain = e.MD.Chain
+	item.DestContract = e.Bridge.Contract
+	item.DestTxHash = e.Tx.Hash
+	item.DestBlock = e.Block.Number.Uint64()
+	item.DestTimestamp = int64(e.Block.Time)
+	item.SettleType = model.BridgeSettleRelease
+	if e.MD.Operate == OperateBridgeMint {
+		item.SettleType = model.BridgeSettleMint
+	}
+	return item
+}
+
+// BuildWrappers the wrappers whose supply or locked balance is changed by the tx result
+func (tc *TxResultHandler) BuildWrappers(e *TxResult) []*model.Wrappers {
+	names := make([]string, 0, 1)
+	if e.Wrap != nil {
+		names = append(names, e.Wrap.Name)
+	}
+
+	addresses := make([]string, 0, 2)
+	if e.Mint != nil {
+		addresses = append(addresses, e.Mint.Minter)
+	}
+	if e.Transfer != nil {
+		addresses = append(addresses, e.Transfer.Sender)
+		for _, item := range e.Transfer.Receives {
+			addresses = append(addresses, item.Address)
+		}
+	}
+	for _, address := range addresses {
+		if ok, name := tc.cache.Wrapper.GetByAddress(e.MD.Protocol, e.MD.Tick, address); ok {
+			names = append(names, name)
+		}
+	}
+
+	items := make([]*model.Wrappers, 0, len(names))
+	for _, name := range names {
+		ok, w := tc.cache.Wrapper.Get(name)
+		if !ok {
+			continue
+		}
+		items = append(items, &model.Wrappers{
+			Chain:       e.MD.Chain,
+			Name:        w.Name,
+			Protocol:    w.Protocol,
+			Tick:        w.Tick,
+			Address:     w.Address,
+			Contract:    w.Contract,
+			Supply:      w.Supply,
+			Locked:      w.Locked,
+			BlockHeight: e.Block.Number.Uint64(),
+		})
+	}
+	return items
+}
+
 type AddressTxEvent struct {
 	Address        string
 	RelatedAddress string
@@ -140,11 +414,32 @@ func (tc *TxResultHandler) BuildAddressTxEvents(e *TxResult) []*AddressTxEvent {
 
 	if e.Mint != nil {
 		items = append(items, &AddressTxEvent{
-			Address: e.Tx.To,
+			Address: e.Mint.Minter,
 			Amount:  e.Mint.Amount,
 		})
 	}
 
+	if e.Ethscription != nil {
+		items = append(items, &AddressTxEvent{
+			Address:        e.Ethscription.From,
+			RelatedAddress: e.Ethscription.To,
+			Amount:         decimal.Zero,
+		})
+		items = append(items, &AddressTxEvent{
+			Address:        e.Ethscription.To,
+			RelatedAddress: e.Ethscription.From,
+			Amount:         decimal.Zero,
+		})
+	}
+
+	// freeze & unfreeze leave the overall balance unchanged
+	if e.Freeze != nil {
+		items = append(items, &AddressTxEvent{
+			Address: e.Freeze.Address,
+			Amount:  decimal.Zero,
+		})
+	}
+
 	if e.Transfer != nil {
 		sendTotalAmount := decimal.Zero
 		for _, item := range e.Transfer.Receives {
@@ -207,6 +502,18 @@ func (tc *TxResultHandler) getEventByOperate(operate string) model.TxEvent {
 		return model.TransactionEventDelist
 	case OperateExchange:
 		return model.TransactionEventExchange
+	case OperateCreate:
+		return model.TransactionEventCreate
+	case OperateBridgeLock:
+		return model.TransactionEventBridgeLock
+	case OperateBridgeRelease:
+		return model.TransactionEventBridgeRelease
+	case OperateBridgeMint:
+		return model.TransactionEventBridgeMint
+	case OperateFreeze:
+		return model.TransactionEventFreeze
+	case OperateUnfreeze:
+		return model.TransactionEventUnfreeze
 	}
 	return model.TxEvent(0)
 }
@@ -238,6 +545,18 @@ func (tc *TxResultHandler) BuildBalanceTxEvents(e *TxResult) []BalanceTxEvent {
 		})
 	}
 
+	if e.Freeze != nil {
+		_, balance := tc.cache.Balance.Get(e.MD.Protocol, e.MD.Tick, e.Freeze.Address)
+		items = append(items, BalanceTxEvent{
+			Action:           DBActionUpdate,
+			SID:              balance.SID,
+			Address:          e.Freeze.Address,
+			Amount:           decimal.Zero,
+			AvailableBalance: balance.Available,
+			OverallBalance:   balance.Overall,
+		})
+	}
+
 	if e.Transfer != nil {
 		sendTotalAmount := decimal.Zero
 		for _, item := range e.Transfer.Receives {
@@ -322,6 +641,9 @@ func (tc *TxResultHandler) BuildTx(e *TxResult) *model.Transaction {
 		Gas:             e.Tx.Gas.Int64(),
 		GasPrice:        e.Tx.GasPrice.Int64(),
 		Content:         e.MD.Data,
+
+		InscriptionNumber: e.Number,
+		SubIndex:          e.Tx.SubIndex,
 	}
 	if e.Tx.ChainID == nil {
 		trx.ChainId = 0
@@ -329,13 +651,18 @@ func (tc *TxResultHandler) BuildTx(e *TxResult) *model.Transaction {
 		trx.ChainId = e.Tx.ChainID.Int64()
 	}
 	switch trx.Op {
-	case OperateMint:
+	case OperateMint, OperateBridgeMint:
 		if e.Mint != nil {
 			trx.Amount = e.Mint.Amount
+			trx.MintSN = e.Mint.SN
 		}
 	case OperateDeploy:
 		trx.Amount = decimal.NewFromInt(0)
-	case OperateTransfer:
+	case OperateFreeze, OperateUnfreeze:
+		if e.Freeze != nil {
+			trx.Amount = e.Freeze.Amount
+		}
+	case OperateTransfer, OperateBridgeLock, OperateBridgeRelease:
 		if e.Transfer != nil {
 			amount := decimal.NewFromInt(0)
 			for _, v := range e.Transfer.Receives {
@@ -352,6 +679,15 @@ type DBModelsFattened struct {
 	InscriptionStats map[DBAction][]*model.InscriptionsStats
 	Balances         map[DBAction][]*model.Balances
 	UTXOs            map[DBAction][]*model.UTXO
+	Ethscriptions    map[DBAction][]*model.Ethscriptions
+	Contents         []*model.InscriptionContents
+	Runes            map[DBAction][]*model.Runes
+	RuneBalances     map[DBAction][]*model.RuneBalances
+	RuneEvents       []*model.RuneEvents
+	Listings         map[DBAction][]*model.Listings
+	BridgeTransfers  []*model.BridgeTransfers
+	Wrappers         []*model.Wrappers
+	TickCandles      []*model.TickCandles
 	Txs              []*model.Transaction
 	AddressTxs       []*model.AddressTxs
 	BalanceTxs       []*model.BalanceTxn
@@ -363,6 +699,15 @@ type DBModels struct {
 	InscriptionStats map[DBAction]map[uint32]*model.InscriptionsStats
 	Balances         map[DBAction]map[uint64]*model.Balances
 	UTXOs            map[DBAction]map[string]*model.UTXO
+	Ethscriptions    map[DBAction]map[string]*model.Ethscriptions
+	Contents         map[string]*model.InscriptionContents
+	Runes            map[DBAction]map[string]*model.Runes
+	RuneBalances     map[DBAction]map[string]*model.RuneBalances
+	RuneEvents       []*model.RuneEvents
+	Listings         map[DBAction]map[string]*model.Listings
+	BridgeTransfers  []*model.BridgeTransfers
+	Wrappers         map[string]*model.Wrappers
+	TickCandles      map[string]*model.TickCandles
 	Txs              map[string]*model.Transaction
 	AddressTxs       []*model.AddressTxs
 	BalanceTxs       []*model.BalanceTxn
@@ -386,9 +731,29 @@ func BuildDBUpdateModel(blocksEvents []*Event) (dmf *DBModelsFattened) {
 			DBActionCreate: make(map[string]*model.UTXO, 100),
 			DBActionUpdate: make(map[string]*model.UTXO, 100),
 		},
-		Txs:        make(map[string]*model.Transaction, len(blocksEvents)*2),
-		AddressTxs: make([]*model.AddressTxs, 0, len(blocksEvents)*2),
-		BalanceTxs: make([]*model.BalanceTxn, 0, len(blocksEvents)*2),
+		Ethscriptions: map[DBAction]map[string]*model.Ethscriptions{
+			DBActionCreate: make(map[string]*model.Ethscriptions, 100),
+			DBActionUpdate: make(map[string]*model.Ethscriptions, 100),
+		},
+		Runes: map[DBAction]map[string]*model.Runes{
+			DBActionCreate: make(map[string]*model.Runes, 100),
+			DBActionUpdate: make(map[string]*model.Runes, 100),
+		},
+		RuneBalances: map[DBAction]map[string]*model.RuneBalances{
+			DBActionCreate: make(map[string]*model.RuneBalances, 100),
+			DBActionUpdate: make(map[string]*model.RuneBalances, 100),
+		},
+		Listings: map[DBAction]map[string]*model.Listings{
+			DBActionCreate: make(map[string]*model.Listings, 100),
+			DBActionUpdate: make(map[string]*model.Listings, 100),
+		},
+		Contents:    make(map[string]*model.InscriptionContents, 100),
+		Wrappers:    make(map[string]*model.Wrappers, 4),
+		TickCandles: make(map[string]*model.TickCandles, 100),
+		RuneEvents:  make([]*model.RuneEvents, 0, len(blocksEvents)*2),
+		Txs:         make(map[string]*model.Transaction, len(blocksEvents)*2),
+		AddressTxs:  make([]*model.AddressTxs, 0, len(blocksEvents)*2),
+		BalanceTxs:  make([]*model.BalanceTxn, 0, len(blocksEvents)*2),
 	}
 	for _, blockEvent := range blocksEvents {
 
@@ -421,11 +786,14 @@ func BuildDBUpdateModel(blocksEvents []*Event) (dmf *DBModelsFattened) {
 				dm.InscriptionStats[action][item.SID] = item
 			}
 
-			txIdx := common.Bytes2Hex(event.Tx.TxHash)
-			if _, ok := dm.Txs[txIdx]; ok {
-				xylog.Logger.Debugf("tx[%s] exist & force update", txIdx)
+			// one txs row per tx hash & smart account sub index, all results of a tx share its inscription number & the last result is kept
+			if event.Tx != nil {
+				txIdx := fmt.Sprintf("%s:%d", common.Bytes2Hex(event.Tx.TxHash), event.Tx.SubIndex)
+				if _, ok := dm.Txs[txIdx]; ok {
+					xylog.Logger.Debugf("tx[%s] exist & force update", txIdx)
+				}
+				dm.Txs[txIdx] = event.Tx
 			}
-			dm.Txs[txIdx] = event.Tx
 
 			if len(event.AddressTxs) > 0 {
 				dm.AddressTxs = append(dm.AddressTxs, event.AddressTxs...)
@@ -435,6 +803,32 @@ func BuildDBUpdateModel(blocksEvents []*Event) (dmf *DBModelsFattened) {
 				dm.BalanceTxs = append(dm.BalanceTxs, event.BalanceTxs...)
 			}
 
+			for action, item := range event.Listings {
+				if action == DBActionUpdate {
+					mergeCandleTrade(dm.TickCandles, item)
+
+					// listing opened & closed in the same batch, close it before insert
+					if created, ok := dm.Listings[DBActionCreate][item.ListId]; ok {
+						item.ListTxHash = created.ListTxHash
+						item.ListBlock = created.ListBlock
+						item.ListTimestamp = created.ListTimestamp
+						dm.Listings[DBActionCreate][item.ListId] = item
+						continue
+					}
+				}
+				dm.Listings[action][item.ListId] = item
+			}
+
+			// bridge transfers are upserted in order, both sides may meet in one batch
+			if event.BridgeTransfer != nil {
+				dm.BridgeTransfers = append(dm.BridgeTransfers, event.BridgeTransfer)
+			}
+
+			// latest state of the wrappers
+			for _, item := range event.Wrappers {
+				dm.Wrappers[item.Name] = item
+			}
+
 			for action, item := range event.UTXOs {
 				if _, ok := dm.UTXOs[action][item.InscriptionId]; ok {
 					xylog.Logger.Debugf("utxo sn[%s] exist & force update, tick[%s]", item.InscriptionId, item.Tick)
@@ -442,6 +836,35 @@ func BuildDBUpdateModel(blocksEvents []*Event) (dmf *DBModelsFattened) {
 				dm.UTXOs[action][item.InscriptionId] = item
 			}
 
+			for action, item := range event.Ethscriptions {
+				if _, ok := dm.Ethscriptions[action][item.EthscriptionId]; ok {
+					xylog.Logger.Debugf("ethscription[%s] exist & force update", item.EthscriptionId)
+				}
+				dm.Ethscriptions[action][item.EthscriptionId] = item
+			}
+
+			if event.Contents != nil {
+				dm.Contents[event.Contents.Sha256] = event.Contents
+			}
+
+			for action, items := range event.Runes {
+				for _, item := range items {
+					dm.Runes[action][item.RuneId] = item
+				}
+			}
+
+			// balances are created per rune of the output, spent per output
+			for _, item := range event.RuneBalances[DBActionCreate] {
+				dm.RuneBalances[DBActionCreate][item.Outpoint+"/"+item.RuneId] = item
+			}
+			for _, item := range event.RuneBalances[DBActionUpdate] {
+				dm.RuneBalances[DBActionUpdate][item.Outpoint] = item
+			}
+
+			if len(event.RuneEvents) > 0 {
+				dm.RuneEvents = append(dm.RuneEvents, event.RuneEvents...)
+			}
+
 			for action, items := range event.Balances {
 				for _, item := range items {
 					if _, ok := dm.Balances[action][item.SID]; ok {
@@ -479,10 +902,30 @@ func BuildDBUpdateModel(blocksEvents []*Event) (dmf *DBModelsFattened) {
 			DBActionCreate: make([]*model.UTXO, 0, 100),
 			DBActionUpdate: make([]*model.UTXO, 0, 100),
 		},
-		Txs:         make([]*model.Transaction, 0, len(dm.Txs)),
-		AddressTxs:  dm.AddressTxs,
-		BalanceTxs:  dm.BalanceTxs,
-		BlockStatus: bs,
+		Ethscriptions: map[DBAction][]*model.Ethscriptions{
+			DBActionCreate: make([]*model.Ethscriptions, 0, 100),
+			DBActionUpdate: make([]*model.Ethscriptions, 0, 100),
+		},
+		Runes: map[DBAction][]*model.Runes{
+			DBActionCreate: make([]*model.Runes, 0, 100),
+			DBActionUpdate: make([]*model.Runes, 0, 100),
+		},
+		RuneBalances: map[DBAction][]*model.RuneBalances{
+			DBActionCreate: make([]*model.RuneBalances, 0, 100),
+			DBActionUpdate: make([]*model.RuneBalances, 0, 100),
+		},
+		Listings: map[DBAction][]*model.Listings{
+			DBActionCreate: make([]*model.Listings, 0, 100),
+			DBActionUpdate: make([]*model.Listings, 0, 100),
+		},
+		BridgeTransfers: dm.BridgeTransfers,
+		Wrappers:        make([]*model.Wrappers, 0, len(dm.Wrappers)),
+		TickCandles:     make([]*model.TickCandles, 0, len(dm.TickCandles)),
+		RuneEvents:      dm.RuneEvents,
+		Txs:             make([]*model.Transaction, 0, len(dm.Txs)),
+		AddressTxs:      dm.AddressTxs,
+		BalanceTxs:      dm.BalanceTxs,
+		BlockStatus:     bs,
 	}
 
 	// flatten tx
@@ -490,6 +933,11 @@ func BuildDBUpdateModel(blocksEvents []*Event) (dmf *DBModelsFattened) {
 		dmf.Txs = append(dmf.Txs, tx)
 	}
 
+	// flatten wrappers
+	for _, item := range dm.Wrappers {
+		dmf.Wrappers = append(dmf.Wrappers, item)
+	}
+
 	// flatten inscription records
 	for _, item := range dm.Inscriptions[DBActionCreate] {
 		dmf.Inscriptions[DBActionCreate] = append(dmf.Inscriptions[DBActionCreate], item)
@@ -521,5 +969,47 @@ func BuildDBUpdateModel(blocksEvents []*Event) (dmf *DBModelsFattened) {
 	for _, item := range dm.UTXOs[DBActionUpdate] {
 		dmf.UTXOs[DBActionUpdate] = append(dmf.UTXOs[DBActionUpdate], item)
 	}
+
+	// flatten ethscriptions records
+	for _, item := range dm.Ethscriptions[DBActionCreate] {
+		dmf.Ethscriptions[DBActionCreate] = append(dmf.Ethscriptions[DBActionCreate], item)
+	}
+	for _, item := range dm.Ethscriptions[DBActionUpdate] {
+		dmf.Ethscriptions[DBActionUpdate] = append(dmf.Ethscriptions[DBActionUpdate], item)
+	}
+
+	// flatten runes records
+	for _, item := range dm.Runes[DBActionCreate] {
+		dmf.Runes[DBActionCreate] = append(dmf.Runes[DBActionCreate], item)
+	}
+	for _, item := range dm.Runes[DBActionUpdate] {
+		dmf.Runes[DBActionUpdate] = append(dmf.Runes[DBActionUpdate], item)
+	}
+
+	// flatten rune balances records
+	for _, item := range dm.RuneBalances[DBActionCreate] {
+		dmf.RuneBalances[DBActionCreate] = append(dmf.RuneBalances[DBActionCreate], item)
+	}
+	for _, item := range dm.RuneBalances[DBActionUpdate] {
+		dmf.RuneBalances[DBActionUpdate] = append(dmf.RuneBalances[DBActionUpdate], item)
+	}
+
+	// flatten listings records
+	for _, item := range dm.Listings[DBActionCreate] {
+		dmf.Listings[DBActionCreate] = append(dmf.Listings[DBActionCreate], item)
+	}
+	for _, item := range dm.Listings[DBActionUpdate] {
+		dmf.Listings[DBActionUpdate] = append(dmf.Listings[DBActionUpdate], item)
+	}
+
+	// flatten contents records
+	for _, item := range dm.Contents {
+		dmf.Contents = append(dmf.Contents, item)
+	}
+
+	// flatten tick candles records
+	for _, item := range dm.TickCandles {
+		dmf.TickCandles = append(dmf.TickCandles, item)
+	}
 	return dmf
 }
@@ -0,0 +1,144 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package devents
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/dcache"
+	"math/big"
+	"testing"
+)
+
+func TestBuildModelInscriptionNumber(t *testing.T) {
+	const (
+		chain    = "ethereum"
+		protocol = "ethscriptions"
+		hash     = "0x00000000000000000000000000000000000000000000000000000000000000aa"
+		creator  = "0x1111111111111111111111111111111111111111"
+		owner    = "0x2222222222222222222222222222222222222222"
+		receiver = "0x3333333333333333333333333333333333333333"
+	)
+	tc := NewTxResultHandler(dcache.NewMemoryManager(chain))
+	block := &xycommon.RpcBlock{Number: big.NewInt(10), Time: 1700000000}
+	tx := &xycommon.RpcTransaction{
+		Hash:        hash,
+		BlockNumber: big.NewInt(10),
+		TxIndex:     big.NewInt(0),
+		Gas:         big.NewInt(21000),
+		GasPrice:    big.NewInt(1),
+	}
+
+	// ethscriptions carry the chain-wide number of the creation tx
+	create := &TxResult{
+		MD:           &MetaData{Chain: chain, Protocol: protocol, Operate: OperateCreate},
+		Block:        block,
+		Tx:           tx,
+		Number:       7,
+		Ethscription: &Ethscription{Id: hash, From: creator, To: owner, Content: &Content{ContentType: "text/plain"}},
+	}
+	dm := tc.BuildModel(create)
+	assert.Equal(t, uint64(7), dm.Ethscriptions[DBActionCreate].Number)
+	assert.Equal(t, int64(7), dm.Tx.InscriptionNumber)
+
+	// a tx with several results keeps one txs row with its number, every result stays in the address txs
+	transfer := &TxResult{
+		MD:           &MetaData{Chain: chain, Protocol: protocol, Operate: OperateTransfer},
+		Block:        block,
+		Tx:           tx,
+		Number:       7,
+		Ethscription: &Ethscription{Id: hash, From: owner, To: receiver},
+	}
+	dmf := BuildDBUpdateModel([]*Event{{Chain: chain, Items: []*DBModelEvent{dm, tc.BuildModel(transfer)}}})
+	assert.Len(t, dmf.Txs, 1)
+	assert.Equal(t, int64(7), dmf.Txs[0].InscriptionNumber)
+	assert.Equal(t, OperateTransfer, dmf.Txs[0].Op)
+	assert.Len(t, dmf.AddressTxs, 4)
+}
+
+func TestBuildModelTokenContent(t *testing.T) {
+	const (
+		chain    = "avalanche"
+		protocol = "asc-20"
+		tick     = "avav"
+		hash     = "0x00000000000000000000000000000000000000000000000000000000000000bb"
+		sender   = "0x1111111111111111111111111111111111111111"
+	)
+	cache := dcache.NewMemoryManager(chain)
+	cache.InscriptionStats.Create(protocol, tick, &dcache.InsStats{Minted: decimal.NewFromInt(10), Holders: 1})
+	receivers := []string{"0x2222222222222222222222222222222222222222", "0x3333333333333333333333333333333333333333"}
+	for _, address := range append([]string{sender}, receivers...) {
+		cache.Balance.Create(protocol, tick, address, &dcache.BalanceItem{Overall: decimal.NewFromInt(10)})
+	}
+	tc := NewTxResultHandler(cache)
+	block := &xycommon.RpcBlock{Number: big.NewInt(10), Time: 1700000000}
+	tx := &xycommon.RpcTransaction{
+		Hash:        hash,
+		From:        sender,
+		To:          sender,
+		BlockNumber: big.NewInt(10),
+		TxIndex:     big.NewInt(0),
+		Gas:         big.NewInt(21000),
+		GasPrice:    big.NewInt(1),
+	}
+
+	// the results of a batch transfer share the metadata & one content object
+	data := `{"p":"asc-20","op":"transfer","tick":"avav","to":[]}`
+	md := &MetaData{Chain: chain, Protocol: protocol, Operate: OperateTransfer, Tick: tick, Data: data, Content: NewContent("", []byte(data))}
+	models := make([]*DBModelEvent, 0, 2)
+	for _, receiver := range receivers {
+		r := &TxResult{
+			MD:       md,
+			Block:    block,
+			Tx:       tx,
+			Transfer: &Transfer{Sender: sender, Receives: []*Receive{{Address: receiver, Amount: decimal.NewFromInt(1)}}},
+		}
+		dm := tc.BuildModel(r)
+		tc.FinalizeModel(r, dm, 9)
+		models = append(models, dm)
+	}
+
+	dmf := BuildDBUpdateModel([]*Event{{Chain: chain, Items: models}})
+	assert.Len(t, dmf.Ethscriptions[DBActionCreate], 1)
+	item := dmf.Ethscriptions[DBActionCreate][0]
+	assert.Equal(t, protocol, item.Protocol)
+	assert.Equal(t, hash, item.EthscriptionId)
+	assert.Equal(t, sender, item.Creator)
+	assert.Equal(t, uint64(9), item.Number)
+	assert.Equal(t, len(data), item.ContentSize)
+	assert.Equal(t, md.Content.Sha256, item.ContentSha256)
+
+	assert.Len(t, dmf.Contents, 1)
+	assert.Equal(t, []byte(data), dmf.Contents[0].Content)
+
+	// event log results carry no inscribed content
+	dm := tc.BuildModel(&TxResult{
+		MD:       &MetaData{Chain: chain, Protocol: protocol, Operate: OperateTransfer, Tick: tick},
+		Block:    block,
+		Tx:       tx,
+		Transfer: &Transfer{Sender: sender},
+	})
+	assert.Nil(t, dm.Ethscriptions)
+	assert.Nil(t, dm.Contents)
+}
@@ -23,6 +23,8 @@
 package devents
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"github.com/shopspring/decimal"
 	"github.com/uxuycom/indexer/client/xycommon"
 )
@@ -34,6 +36,21 @@ const (
 	OperateList     string = "list"
 	OperateDelist   string = "delist"
 	OperateExchange string = "exchange"
+	OperateCreate   string = "create"
+
+	// OperateFreeze / OperateUnfreeze move balances between available & locked
+	OperateFreeze   string = "freeze"
+	OperateUnfreeze string = "unfreeze"
+
+	// OperateBridge bridge contract events, resolved to lock / release / mint per event
+	OperateBridge        string = "bridge"
+	OperateBridgeLock    string = "bridge_lock"
+	OperateBridgeRelease string = "bridge_release"
+	OperateBridgeMint    string = "bridge_mint"
+
+	// OperateWrapMint / OperateWrapBurn erc-20 mint & burn events of a wrapper, changing the wrapped supply
+	OperateWrapMint string = "wrap_mint"
+	OperateWrapBurn string = "wrap_burn"
 )
 
 type MetaData struct {
@@ -42,6 +59,8 @@ type MetaData struct {
 	Operate  string `json:"op"`
 	Tick     string `json:"tick"`
 	Data     string
+	Parent   string   `json:"-"` // btc inscription parent id
+	Content  *Content `json:"-"` // inscribed payload of a token inscription, nil for event logs
 }
 
 func (original *MetaData) Copy() *MetaData {
@@ -51,6 +70,8 @@ func (original *MetaData) Copy() *MetaData {
 		Operate:  original.Operate,
 		Tick:     original.Tick,
 		Data:     original.Data,
+		Parent:   original.Parent,
+		Content:  original.Content,
 	}
 }
 
@@ -59,30 +80,110 @@ type Deploy struct {
 	MaxSupply decimal.Decimal
 	MintLimit decimal.Decimal
 	Decimal   int8
+	Params    *DeployParams // nil unless the deploy_params rule is active
+}
+
+// DeployParams optional deploy mint windows & caps, 0 means unbounded
+type DeployParams struct {
+	StartBlock         uint64
+	EndBlock           uint64
+	MaxMintsPerAddress uint64
+	MaxMintsPerBlock   uint64
+	DeployerOnly       bool
 }
 
 type Mint struct {
 	Minter string
 	Amount decimal.Decimal
 	Init   bool
+	SN     uint64 // per-tick mint sequence, assigned on cache update
+	Bridge bool   // bridge mint, credited without mint stats & sequence
 }
 
 type Receive struct {
 	Address string
 	Amount  decimal.Decimal
 	Init    bool
+	Burn    bool // burn address, counted as burned supply & never a holder
 }
 
 type Transfer struct {
-	Sender   string
-	Receives []*Receive
+	Sender     string
+	SenderBurn bool // burn address sender, never counted as a holder
+	Receives   []*Receive
+}
+
+// Freeze amount moved from the available into the locked balance of the address, back on unfreeze
+type Freeze struct {
+	Address string
+	Amount  decimal.Decimal
+}
+
+// Ethscription records a creation (From is the creator) or an ownership change
+type Ethscription struct {
+	Id      string
+	From    string
+	To      string
+	Content *Content // creation only
+}
+
+// Content decoded data uri payload of an inscription
+type Content struct {
+	ContentType string
+	Sha256      string
+	UriSha256   string // sha256 of the whole data uri, ethscriptions content uniqueness key
+	Data        []byte
+}
+
+// NewContent content of the inscribed payload, keyed by sha256 in the content store
+func NewContent(contentType string, data []byte) *Content {
+	sum := sha256.Sum256(data)
+	return &Content{
+		ContentType: contentType,
+		Sha256:      hex.EncodeToString(sum[:]),
+		Data:        data,
+	}
+}
+
+// Listing marketplace order book change, list opens the listing, delist / exchange closes it
+type Listing struct {
+	ListId      string
+	Marketplace string
+	Seller      string
+	Buyer       string
+	Amount      decimal.Decimal
+	Price       decimal.Decimal // total price in wei, zero on list
+}
+
+// Bridge one side of a bridge transfer, the lock on the source chain or the release / mint on the destination chain
+type Bridge struct {
+	Name     string
+	Contract string
+	Id       string
+	From     string
+	To       string
+	Amount   decimal.Decimal
+	Chain    string // counterpart chain
+}
+
+// Wrap erc-20 mint / burn of a wrapper, the amount is always positive
+type Wrap struct {
+	Name    string
+	Account string
+	Amount  decimal.Decimal
 }
 
 type TxResult struct {
-	MD       *MetaData
-	Block    *xycommon.RpcBlock
-	Tx       *xycommon.RpcTransaction
-	Mint     *Mint
-	Deploy   *Deploy
-	Transfer *Transfer
+	Number       int64 // chain-wide inscription number of the tx
+	MD           *MetaData
+	Block        *xycommon.RpcBlock
+	Tx           *xycommon.RpcTransaction
+	Mint         *Mint
+	Deploy       *Deploy
+	Transfer     *Transfer
+	Ethscription *Ethscription
+	Listing      *Listing
+	Bridge       *Bridge
+	Wrap         *Wrap
+	Freeze       *Freeze
 }
@@ -0,0 +1,187 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package explorer
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/client/beacon"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol"
+	"github.com/uxuycom/indexer/protocol/blob"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// blobSidecar the beacon api sidecar of the payload & its versioned hash
+func blobSidecar(t *testing.T, payload []byte) (map[string]string, string) {
+	blobs, err := blob.Encode(payload)
+	assert.NoError(t, err)
+
+	var b kzg4844.Blob
+	copy(b[:], blobs[0])
+	commitment, err := kzg4844.BlobToCommitment(b)
+	assert.NoError(t, err)
+	proof, err := kzg4844.ComputeBlobProof(b, commitment)
+	assert.NoError(t, err)
+
+	return map[string]string{
+		"index":          "0",
+		"blob":           hexutil.Encode(b[:]),
+		"kzg_commitment": hexutil.Encode(commitment[:]),
+		"kzg_proof":      hexutil.Encode(proof[:]),
+	}, beacon.VersionedHash(commitment[:]).String()
+}
+
+// beaconStandIn serves the genesis, spec & the blob sidecars of slot 5
+func beaconStandIn(sidecars []map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var data interface{}
+		switch r.URL.Path {
+		case "/eth/v1/beacon/genesis":
+			data = map[string]string{"genesis_time": "1700000000"}
+		case "/eth/v1/config/spec":
+			data = map[string]string{"SECONDS_PER_SLOT": "12"}
+		case "/eth/v1/beacon/blob_sidecars/5":
+			data = sidecars
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": data})
+	}))
+}
+
+func TestBlobInscriptions(t *testing.T) {
+	mint, mintHash := blobSidecar(t, []byte(`data:,{"p":"asc-20","op":"mint","tick":"blob","amt":"1000"}`))
+	brc20, brc20Hash := blobSidecar(t, []byte(`data:,{"p":"brc-20","op":"deploy","tick":"blob","max":"1000","lim":"10"}`))
+	rollup, _ := blobSidecar(t, []byte("rollup batch"))
+	server := beaconStandIn([]map[string]string{rollup, mint, brc20})
+	defer server.Close()
+
+	cfg := &config.Config{
+		Chain: config.ChainConfig{ChainName: "eth", ChainGroup: "evm", BeaconRpc: server.URL, Rules: []*config.RuleActivation{
+			{Protocol: "asc-20", Rule: "blobs", Value: "true"},
+		}},
+	}
+	cache := dcache.NewMemoryManager(cfg.Chain.ChainName)
+	assert.NoError(t, protocol.InitProtocols(cfg, cache))
+	e := &Explorer{
+		config:          cfg,
+		dCache:          cache,
+		txResultHandler: devents.NewTxResultHandler(cache),
+		beacon:          beacon.NewClient(cfg.Chain.BeaconRpc),
+	}
+
+	newTx := func(idx int, data string, blobHashes ...string) *xycommon.RpcTransaction {
+		return &xycommon.RpcTransaction{
+			BlockNumber:         big.NewInt(100),
+			TxIndex:             big.NewInt(int64(idx)),
+			Type:                big.NewInt(3),
+			Hash:                fmt.Sprintf("0x%064x", idx),
+			From:                fmt.Sprintf("0x%040x", 1),
+			To:                  fmt.Sprintf("0x%040x", 1),
+			Input:               "0x" + hex.EncodeToString([]byte(data)),
+			Gas:                 big.NewInt(0),
+			GasPrice:            big.NewInt(0),
+			BlobVersionedHashes: blobHashes,
+		}
+	}
+	block := &xycommon.RpcBlock{Number: big.NewInt(100), Time: 1700000000 + 5*12, Transactions: []*xycommon.RpcTransaction{
+		newTx(0, `data:,{"p":"asc-20","op":"deploy","tick":"blob","max":"1000000","lim":"1000"}`),
+		newTx(1, "", mintHash),
+		// blobs rule not active for brc-20
+		newTx(2, "", brc20Hash),
+	}}
+
+	assert.NoError(t, e.attachBlobs(context.Background(), block))
+	txs := e.extractTxsFromBlock(block)
+	if assert.Len(t, txs, 2) {
+		assert.Equal(t, block.Transactions[1].Hash, txs[1].Hash)
+	}
+
+	txs, metas := e.tryFilterTxs(txs)
+	models, err := e.parseTxs(block, txs, metas)
+	assert.Nil(t, err)
+	if assert.Len(t, models, 2) {
+		assert.Equal(t, "mint", models[1].Tx.Op)
+		assert.Equal(t, "blob", models[1].Tx.Tick)
+	}
+
+	// sidecars not served or not matching the commitment fail the block
+	missing := &xycommon.RpcBlock{Number: big.NewInt(101), Time: block.Time + 12, Transactions: []*xycommon.RpcTransaction{newTx(0, "", mintHash)}}
+	assert.Error(t, e.attachBlobs(context.Background(), missing))
+
+	// calldata inscriptions take precedence, their sidecars are not fetched
+	calldata := &xycommon.RpcBlock{Number: big.NewInt(101), Time: block.Time + 12, Transactions: []*xycommon.RpcTransaction{
+		newTx(0, `data:,{"p":"asc-20","op":"mint","tick":"blob","amt":"1"}`, mintHash),
+	}}
+	assert.NoError(t, e.attachBlobs(context.Background(), calldata))
+	assert.Nil(t, calldata.Transactions[0].Blobs)
+
+	mint["kzg_proof"] = rollup["kzg_proof"]
+	tampered := beaconStandIn([]map[string]string{mint})
+	defer tampered.Close()
+	e.beacon = beacon.NewClient(tampered.URL)
+	assert.Error(t, e.attachBlobs(context.Background(), block))
+}
+
+func TestBlobsBeforeActivation(t *testing.T) {
+	fetched := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched = true
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		Chain: config.ChainConfig{ChainName: "eth", ChainGroup: "evm", BeaconRpc: server.URL, Rules: []*config.RuleActivation{
+			{Protocol: "asc-20", Rule: "blobs", Height: 200, Value: "true"},
+		}},
+	}
+	cache := dcache.NewMemoryManager(cfg.Chain.ChainName)
+	assert.NoError(t, protocol.InitProtocols(cfg, cache))
+	e := &Explorer{config: cfg, dCache: cache, beacon: beacon.NewClient(cfg.Chain.BeaconRpc)}
+
+	tx := &xycommon.RpcTransaction{BlockNumber: big.NewInt(100), Type: big.NewInt(3), Hash: fmt.Sprintf("0x%064x", 1),
+		BlobVersionedHashes: []string{fmt.Sprintf("0x01%062x", 1)}}
+	block := &xycommon.RpcBlock{Number: big.NewInt(100), Time: 1700000000, Transactions: []*xycommon.RpcTransaction{tx}}
+
+	// no sidecars are fetched below the activation height, pruned ones can't stall the sync
+	assert.NoError(t, e.attachBlobs(context.Background(), block))
+	assert.False(t, fetched)
+
+	block.Number = big.NewInt(200)
+	assert.Error(t, e.attachBlobs(context.Background(), block))
+	assert.True(t, fetched)
+}
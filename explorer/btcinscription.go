@@ -0,0 +1,234 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package explorer
+
+import (
+	"context"
+	"fmt"
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/client/btc"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol"
+	btcRunes "github.com/uxuycom/indexer/protocol/btc/runes"
+	"github.com/uxuycom/indexer/xyerrors"
+	"github.com/uxuycom/indexer/xylog"
+	"math"
+)
+
+// parseBtcTxEvents builds brc-20 events of the tx from the raw node data:
+// transfer inscriptions sent by the tx inputs first, then the new inscription
+// revealed in the first input witness.
+func (e *Explorer) parseBtcTxEvents(block *xycommon.RpcBlock, tx btcjson.TxRawResult) ([]*xycommon.BlockEvent, error) {
+	events, err := e.parseBtcTransfers(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if event := e.parseBtcInscription(block, tx); event != nil {
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// parseBtcTransfers follows the transfer inscriptions sitting on the spent outpoints, the inscribed sat
+// at offset N of the inputs lands at offset N of the outputs
+func (e *Explorer) parseBtcTransfers(tx btcjson.TxRawResult) ([]*xycommon.BlockEvent, error) {
+	type spent struct {
+		vin  int
+		utxo *dcache.UTXOItem
+	}
+
+	spents := make([]spent, 0, 1)
+	for i, vin := range tx.Vin {
+		if vin.IsCoinBase() {
+			continue
+		}
+
+		if ok, items := e.dCache.UTXO.Get(btcRunes.Outpoint(vin.Txid, vin.Vout)); ok {
+			for _, item := range items {
+				spents = append(spents, spent{vin: i, utxo: item})
+			}
+		}
+	}
+
+	if len(spents) <= 0 {
+		return nil, nil
+	}
+
+	// sat offsets of the inputs, only the inputs before the last inscribed one are needed
+	offsets, err := e.btcInputOffsets(tx.Vin[:spents[len(spents)-1].vin])
+	if err != nil {
+		return nil, xyerrors.ErrInternal.WrapCause(err)
+	}
+
+	events := make([]*xycommon.BlockEvent, 0, len(spents))
+	for _, s := range spents {
+		to := btcSatReceiver(tx, offsets[s.vin]+s.utxo.Offset)
+
+		// brc-20: transfer inscription spent as fee returns to the sender
+		if to == "" {
+			to = s.utxo.Owner
+		}
+		events = append(events, &xycommon.BlockEvent{
+			Type:          OperateTransfer,
+			Tick:          s.utxo.Tick,
+			InscriptionId: s.utxo.InscriptionId,
+			Amount:        s.utxo.Amount.Shift(int32(defaultTickDecimals)).String(),
+			From:          &xycommon.OkxAddress{Address: s.utxo.Owner},
+			To:            &xycommon.OkxAddress{Address: to},
+			Valid:         true,
+		})
+	}
+	return events, nil
+}
+
+// parseBtcInscription the inscription sits on the first sat of the first input,
+// which always lands in the first output
+func (e *Explorer) parseBtcInscription(block *xycommon.RpcBlock, tx btcjson.TxRawResult) *xycommon.BlockEvent {
+	if len(tx.Vin) <= 0 || len(tx.Vout) <= 0 || tx.Vin[0].IsCoinBase() {
+		return nil
+	}
+
+	owner := tx.Vout[0].ScriptPubKey.Address
+	if owner == "" {
+		return nil
+	}
+
+	rpcTx := &xycommon.RpcTransaction{
+		Hash: tx.Txid,
+		From: owner,
+		To:   owner,
+		Vin:  tx.Vin,
+		Vout: tx.Vout,
+	}
+	pt, md := protocol.GetProtocol(e.config, rpcTx)
+	if pt == nil {
+		return nil
+	}
+
+	if !e.protocolEnabled(md.Protocol) || !e.tickEnabled(md.Tick) {
+		return nil
+	}
+
+	txResults, err := pt.Parse(block, rpcTx, md)
+	if err != nil || len(txResults) <= 0 {
+		xylog.Logger.Infof("inscription data parsed failed. md[%v], tx[%s], err[%v]", md, tx.Txid, err)
+		return nil
+	}
+
+	r := txResults[0]
+	event := &xycommon.BlockEvent{
+		Tick:          md.Tick,
+		InscriptionId: fmt.Sprintf("%si0", tx.Txid),
+		From:          &xycommon.OkxAddress{Address: owner},
+		To:            &xycommon.OkxAddress{Address: owner},
+		Valid:         true,
+		Content:       md.Data,
+	}
+
+	amount := decimal.Zero
+	switch {
+	case r.Deploy != nil:
+		event.Type = OperateDeploy
+	case r.Mint != nil:
+		event.Type = OperateMint
+		amount = r.Mint.Amount
+	case r.Transfer != nil && md.Operate == devents.OperateTransfer:
+		event.Type = OperateInscribeTransfer
+		amount = r.Transfer.Receives[0].Amount
+	default:
+		return nil
+	}
+	event.Amount = amount.Shift(int32(defaultTickDecimals)).String()
+	return event
+}
+
+// btcInputOffsets the starting sat offset of each input & the end offset of the last one,
+// the prevout values are fetched from the node in one batch
+func (e *Explorer) btcInputOffsets(vins []btcjson.Vin) ([]int64, error) {
+	offsets := make([]int64, len(vins)+1)
+	if len(vins) <= 0 {
+		return offsets, nil
+	}
+
+	txids := make([]string, 0, len(vins))
+	for _, vin := range vins {
+		txids = append(txids, vin.Txid)
+	}
+
+	prevTxs, err := e.btcPrevTxs(txids)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, vin := range vins {
+		value, err := btcOutputValue(prevTxs[vin.Txid], vin.Txid, vin.Vout)
+		if err != nil {
+			return nil, err
+		}
+		offsets[i+1] = offsets[i] + value
+	}
+	return offsets, nil
+}
+
+// btcSatReceiver finds the output holding the sat at offset of the tx inputs.
+// Returns empty address if the sat is spent as fee.
+func btcSatReceiver(tx btcjson.TxRawResult, offset int64) string {
+	start := int64(0)
+	for _, vout := range tx.Vout {
+		value := btcToSats(vout.Value)
+		if offset >= start && offset < start+value {
+			return vout.ScriptPubKey.Address
+		}
+		start += value
+	}
+	return ""
+}
+
+func (e *Explorer) btcPrevTxsFetcher(txids []string) (map[string]*btcjson.TxRawResult, error) {
+	bClient := e.node.(*btc.BClient)
+	return bClient.GetMultiRawTransactionVerbose(context.Background(), txids)
+}
+
+func btcOutputValue(tx *btcjson.TxRawResult, txid string, vout uint32) (int64, error) {
+	if tx != nil {
+		for _, item := range tx.Vout {
+			if item.N == vout {
+				return btcToSats(item.Value), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("output %s:%d not found", txid, vout)
+}
+
+func btcToSats(value float64) int64 {
+	return int64(math.Round(value * 1e8))
+}
+
+// inscriptionGenesisSatpoint a revealed inscription sits on the first sat of the first output
+func inscriptionGenesisSatpoint(txid string) string {
+	return dcache.Satpoint(txid, 0, 0)
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package explorer
+
+import (
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/model"
+	"math/big"
+	"testing"
+)
+
+func btcVout(n uint32, sats int64, address string) btcjson.Vout {
+	return btcjson.Vout{N: n, Value: float64(sats) / 1e8, ScriptPubKey: btcjson.ScriptPubKeyResult{Address: address}}
+}
+
+func TestParseBtcTransfers(t *testing.T) {
+	cache := dcache.NewMemoryManager("btc")
+	e := &Explorer{config: &config.Config{Chain: config.ChainConfig{ChainName: "btc"}}, dCache: cache}
+
+	// the transfer inscription sits at offset 500 of output 1
+	assert.NoError(t, cache.UTXO.Add("brc-20", "ordi", dcache.Satpoint("aa", 1, 500), "bc1sender", decimal.NewFromInt(10), "bbi0"))
+
+	fetched := make([][]string, 0)
+	e.btcPrevTxs = func(txids []string) (map[string]*btcjson.TxRawResult, error) {
+		fetched = append(fetched, txids)
+		return map[string]*btcjson.TxRawResult{
+			"p0": {Txid: "p0", Vout: []btcjson.Vout{btcVout(0, 1000, "bc1other")}},
+		}, nil
+	}
+
+	tests := []struct {
+		name    string
+		vin     []btcjson.Vin
+		vout    []btcjson.Vout
+		to      string
+		fetches int
+	}{
+		{
+			name:    "other output of the genesis tx",
+			vin:     []btcjson.Vin{{Txid: "aa", Vout: 0}},
+			vout:    []btcjson.Vout{btcVout(0, 1000, "bc1receiver")},
+			fetches: 0,
+		},
+		{
+			name:    "offset within the first input",
+			vin:     []btcjson.Vin{{Txid: "aa", Vout: 1}},
+			vout:    []btcjson.Vout{btcVout(0, 400, "bc1change"), btcVout(1, 600, "bc1receiver")},
+			to:      "bc1receiver",
+			fetches: 0,
+		},
+		{
+			name:    "offset after a previous input",
+			vin:     []btcjson.Vin{{Txid: "p0", Vout: 0}, {Txid: "aa", Vout: 1}},
+			vout:    []btcjson.Vout{btcVout(0, 1200, "bc1change"), btcVout(1, 1000, "bc1receiver")},
+			to:      "bc1receiver",
+			fetches: 1,
+		},
+		{
+			name:    "spent as fee returns to the sender",
+			vin:     []btcjson.Vin{{Txid: "aa", Vout: 1}},
+			vout:    []btcjson.Vout{btcVout(0, 300, "bc1receiver")},
+			to:      "bc1sender",
+			fetches: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetched = fetched[:0]
+			events, err := e.parseBtcTransfers(btcjson.TxRawResult{Txid: "cc", Vin: tt.vin, Vout: tt.vout})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.fetches, len(fetched))
+			if tt.to == "" {
+				assert.Empty(t, events)
+				return
+			}
+			assert.Equal(t, 1, len(events))
+			assert.Equal(t, "bbi0", events[0].InscriptionId)
+			assert.Equal(t, "bc1sender", events[0].From.Address)
+			assert.Equal(t, tt.to, events[0].To.Address)
+		})
+	}
+
+	cache.UTXO.Remove("bbi0")
+	ok, _ := cache.UTXO.Get("aa:1")
+	assert.False(t, ok)
+}
+
+func TestBtcLocalBalances(t *testing.T) {
+	cache := dcache.NewMemoryManager("btc")
+	e := &Explorer{config: &config.Config{Chain: config.ChainConfig{ChainName: "btc"}}, dCache: cache}
+	block := &xycommon.RpcBlock{Number: big.NewInt(840000), Time: 1713571767}
+	amount := func(v int64) string {
+		return decimal.NewFromInt(v).Shift(int32(defaultTickDecimals)).String()
+	}
+	apply := func(txid string, event *xycommon.BlockEvent) *devents.DBModelEvent {
+		dm, err := e.buildModel(txid, event, 0, btcjson.TxRawResult{Txid: txid}, block)
+		assert.NoError(t, err)
+		return dm
+	}
+	assertBalance := func(address string, overall, available int64) {
+		ok, balance := cache.Balance.Get(defaultProtocol, "ordi", address)
+		assert.True(t, ok)
+		assert.Equal(t, decimal.NewFromInt(overall).String(), balance.Overall.String(), address)
+		assert.Equal(t, decimal.NewFromInt(available).String(), balance.Available.String(), address)
+	}
+
+	apply("d0", &xycommon.BlockEvent{
+		Type: OperateDeploy, Tick: "ordi", InscriptionId: "d0i0", Amount: "0", Valid: true,
+		From: &xycommon.OkxAddress{Address: "bc1deployer"}, To: &xycommon.OkxAddress{Address: "bc1deployer"},
+		Content: `{"p":"brc-20","op":"deploy","tick":"ordi","max":"1000","lim":"100"}`,
+	})
+	apply("m0", &xycommon.BlockEvent{
+		Type: OperateMint, Tick: "ordi", InscriptionId: "m0i0", Amount: amount(100), Valid: true,
+		From: &xycommon.OkxAddress{Address: "bc1sender"}, To: &xycommon.OkxAddress{Address: "bc1sender"},
+	})
+
+	// inscribe transfer locks 40 of the available balance
+	dm := apply("tt", &xycommon.BlockEvent{
+		Type: OperateInscribeTransfer, Tick: "ordi", InscriptionId: "tti0", Amount: amount(40), Valid: true,
+		From: &xycommon.OkxAddress{Address: "bc1sender"}, To: &xycommon.OkxAddress{Address: "bc1sender"},
+	})
+	assert.Equal(t, "tt:0:0", dm.UTXOs[devents.DBActionCreate].Satpoint)
+	assertBalance("bc1sender", 100, 60)
+
+	// sending the inscription moves the locked amount to the receiver
+	events, err := e.parseBtcTransfers(btcjson.TxRawResult{
+		Txid: "xx",
+		Vin:  []btcjson.Vin{{Txid: "tt", Vout: 0}},
+		Vout: []btcjson.Vout{btcVout(0, 546, "bc1receiver")},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(events))
+	dm = apply("xx", events[0])
+	assert.EqualValues(t, model.UTXOStatusSpent, dm.UTXOs[devents.DBActionUpdate].Status)
+	assertBalance("bc1sender", 60, 60)
+	assertBalance("bc1receiver", 40, 40)
+
+	// the inscription is consumed, spending its output again moves nothing
+	ok, _ := cache.UTXO.Get("tt:0")
+	assert.False(t, ok)
+}
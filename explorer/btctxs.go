@@ -24,24 +24,21 @@ package explorer
 
 import (
 	"context"
-	"encoding/hex"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"github.com/btcsuite/btcd/btcjson"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/shopspring/decimal"
 	"github.com/uxuycom/indexer/client/btc"
 	"github.com/uxuycom/indexer/client/xycommon"
-	"github.com/uxuycom/indexer/dcache"
 	"github.com/uxuycom/indexer/devents"
 	"github.com/uxuycom/indexer/model"
+	btcBrc20 "github.com/uxuycom/indexer/protocol/btc/brc20"
 	"github.com/uxuycom/indexer/xyerrors"
 	"github.com/uxuycom/indexer/xylog"
-	"golang.org/x/sync/errgroup"
 	"math"
+	"math/big"
 	"strings"
-	"sync"
 	"time"
 )
 
@@ -57,35 +54,21 @@ func (e *Explorer) handleBtcTxs(block *xycommon.RpcBlock) *xyerrors.InsError {
 	defer func() {
 		xylog.Logger.Infof("handle tx use time [%v] block[%v]", time.Since(startTime), block.Number)
 	}()
-	xylog.Logger.Infof("handleTxs begin txs[%v] len[%v] block[%v]", block.Txs, len(block.Txs), block.Number)
+	xylog.Logger.Infof("handleTxs begin txs len[%v] block[%v]", len(block.RpcTxs), block.Number)
 
 	var blockTxs []*devents.DBModelEvent
-	txIdx, btcTxs := e.convertTxIdx(block.RpcTxs)
-	addressBalances, err := e.getAddressBalanceFromBlock(block.Txs, txIdx, block.Number.Int64())
-	if err != nil {
-		return xyerrors.ErrInternal
-	}
-	xylog.Logger.Infof("getAddressBalanceFromBlock use time[%v] block[%v]", time.Since(startTime), block.Number)
 
 	startRangTxTime := time.Now()
-	for _, tx := range block.Txs {
-
-		idx, ok := txIdx[tx.Txid]
-		btcTx, _ := btcTxs[tx.Txid]
-		if !ok {
-			xylog.Logger.Errorf("the transaction does not exist on the block for the original node. txid[%s]", tx.Txid)
+	for idx, tx := range block.RpcTxs {
+		events, err := e.parseBtcTxEvents(block, tx)
+		if err != nil {
+			xylog.Logger.Errorf("parse tx events error. txid[%s] err[%v]", tx.Txid, err)
 			return xyerrors.ErrInternal
 		}
-		for _, event := range tx.Events {
-			if !event.Valid {
-				continue
-			}
-			if event.From.Address == "" || event.To.Address == "" {
-				xylog.Logger.Infof("the from or to address is empty and the transaction is skipped. txid[%s] blockHash[%s]", tx.Txid, block.Hash)
-				continue
-			}
+
+		for _, event := range events {
 			xylog.Logger.Infof("binding transaction data. txid[%s] block[%d]", tx.Txid, block.Number)
-			dm, err := e.buildModel(tx.Txid, event, idx, btcTx, block, addressBalances)
+			dm, err := e.buildModel(tx.Txid, event, idx, tx, block)
 			if err != nil {
 				xylog.Logger.Errorf("handleTxs error. err[%s]", err)
 				return xyerrors.ErrInternal
@@ -93,126 +76,133 @@ func (e *Explorer) handleBtcTxs(block *xycommon.RpcBlock) *xyerrors.InsError {
 			blockTxs = append(blockTxs, dm)
 			xylog.Logger.Infof("binding transaction data end. txid[%s] block[%d]", tx.Txid, block.Number)
 		}
+
+		dm, err := e.handleRunesTx(block, idx, tx)
+		if err != nil {
+			xylog.Logger.Errorf("handle runes tx error. txid[%s] err[%v]", tx.Txid, err)
+			return xyerrors.ErrInternal
+		}
+		if dm != nil {
+			blockTxs = append(blockTxs, dm)
+		}
 	}
 
 	xylog.Logger.Infof("handleTxs  end. block[%d] use time[%v]", block.Number, time.Since(startRangTxTime))
+	if e.balanceChecks != nil && len(blockTxs) > 0 {
+		e.enqueueBalanceCheck(block.Number.Int64(), blockTxs)
+	}
 	e.writeDBAsync(block, blockTxs)
 	return nil
 }
 
-func (e *Explorer) convertTxIdx(txs []btcjson.TxRawResult) (map[string]int, map[string]btcjson.TxRawResult) {
-	idTxs := make(map[string]int, len(txs))
-	newTxs := make(map[string]btcjson.TxRawResult, len(txs))
-	for idx, v := range txs {
-		idTxs[v.Txid] = idx
-		newTxs[v.Txid] = v
-	}
-	return idTxs, newTxs
-}
+// ord catch up wait of a balance cross check
+var (
+	crossCheckWaitRounds   = 10
+	crossCheckWaitInterval = 3 * time.Second
+)
 
-func (e *Explorer) getAddressBalanceFromBlock(txs []*xycommon.Tx, txIdx map[string]int, blockNumber int64) (map[string]*xycommon.AddressBalance, error) {
-
-	addrBals := e.getAddressFromTx(txs, txIdx)
-	balanceMap := &sync.Map{}
-	g, _ := errgroup.WithContext(context.Background())
-
-	addrChan := make(chan int, 20)
-	//newBlockNumber, err1 := btcClient.BlockNumber(context.Background())
-	//if err1 != nil {
-	//	return nil, err1
-	//}
-	for _, v := range addrBals {
-		addr := v
-		key := fmt.Sprintf("%s_%s", addr.Tick, addr.Address)
-		addrChan <- 1
-		//logs.Logger.Infof("------- address[%s] tick[%s]", addr.Address, addr.Tick)
-		g.Go(func() error {
-			defer func() {
-				<-addrChan
-			}()
-			ordBalance, availableBalance, err := e.GetAddressBalance(addr.Address, addr.Tick)
-			xylog.Logger.Infof("address[%s] tick[%s] ordBalance[%v]  availableBalance[%v] err[%v]", addr.Address, addr.Tick, ordBalance, availableBalance, err)
-			if err != nil {
-				xylog.Logger.Errorf("address[%s] tick[%s] ordBalance[%v]  availableBalance[%v] err[%v]", addr.Address, addr.Tick, ordBalance, availableBalance, err)
-				return err
-			}
+// balanceCheck local balances of a block, compared with the ord api once the ord server is at the same height
+type balanceCheck struct {
+	blockNumber int64
+	balances    []model.Balances // copies, the models are written concurrently
+}
 
-			////// balance not change send lark
-			//ok, cBalance := e.dCache.Balance.Get(defaultProtocol, addr.Tick, addr.Address)
-			//if ok && cBalance.Overall.Equal(ordBalance) && cBalance.Available.Equal(availableBalance) && newBlockNumber == blockNumber {
-			//	go e.larkNotification(addr, cBalance, ordBalance, availableBalance, blockNumber)
-			//	xylog.Logger.Infof("the balance is not as expected. address[%s] tick[%s] ordBalance[%v]  availableBalance[%v] cacheBalance[%v] cacheAvailableBalance[%v]", addr.Address, addr.Tick, ordBalance, availableBalance, cBalance.Overall, cBalance.Available)
-			//}
-
-			newAddrBalance := &xycommon.AddressBalance{
-				Address:          addr.Address,
-				OverallBalance:   ordBalance,
-				AvailableBalance: availableBalance,
-				Tick:             addr.Tick,
+// enqueueBalanceCheck hands the block balances to the cross check worker, blocks are skipped while it is busy,
+// the ord api serves the latest balances only, so historical blocks could not be checked anyway
+func (e *Explorer) enqueueBalanceCheck(blockNumber int64, blockTxs []*devents.DBModelEvent) {
+	check := &balanceCheck{blockNumber: blockNumber}
+	for _, dm := range blockTxs {
+		for _, items := range dm.Balances {
+			for _, item := range items {
+				check.balances = append(check.balances, *item)
 			}
-
-			balanceMap.Store(key, newAddrBalance)
-
-			return nil
-		})
+		}
 	}
-	if err := g.Wait(); err != nil {
-		return nil, fmt.Errorf("concurrent block scanning failed. err=%s", err)
+	if len(check.balances) <= 0 {
+		return
 	}
 
-	xylog.Logger.Infof("getAddressBalanceFromBlock len:[%v]", len(addrBals))
-	newBalanceMap := make(map[string]*xycommon.AddressBalance)
+	select {
+	case e.balanceChecks <- check:
+	default:
+		xylog.Logger.Debugf("balance cross check busy, block[%d] skipped", blockNumber)
+	}
+}
 
-	for _, v := range addrBals {
-		key := fmt.Sprintf("%s_%s", v.Tick, v.Address)
-		xylog.Logger.Infof("getAddressBalanceFromBlock range addressBalances key:[%v]", key)
-		if v, ok := balanceMap.Load(key); ok {
-			xylog.Logger.Infof("getAddressBalanceFromBlock range addressBalances key:[%v] load", key)
-			if vv, ok := v.(*xycommon.AddressBalance); ok {
-				xylog.Logger.Infof("getAddressBalanceFromBlock range addressBalances key:[%v] load address[%s] tick[%s] balance[%v] aval[%v]", key, vv.Address, vv.Tick, vv.OverallBalance, vv.AvailableBalance)
-				newBalanceMap[key] = vv
-			}
+func (e *Explorer) crossCheckBalancesTiming() {
+	for {
+		select {
+		case check := <-e.balanceChecks:
+			e.crossCheckBalances(check)
+		case <-e.ctx.Done():
+			return
 		}
 	}
-
-	return newBalanceMap, nil
 }
 
-func (e *Explorer) getAddressFromTx(txs []*xycommon.Tx, txIdx map[string]int) map[string]*xycommon.AddressBalance {
+// crossCheckBalances compares the locally computed balances with the ord api and reports divergences only,
+// the indexed balances never depend on the remote values. Checks run only at the ord server height
+func (e *Explorer) crossCheckBalances(check *balanceCheck) {
+	// the ord server usually lags the node by a few seconds at the tip
+	height, err := e.ordHeight()
+	for i := 0; err == nil && height < check.blockNumber && i < crossCheckWaitRounds; i++ {
+		select {
+		case <-time.After(crossCheckWaitInterval):
+		case <-e.ctx.Done():
+			return
+		}
+		height, err = e.ordHeight()
+	}
+	if err != nil {
+		xylog.Logger.Warnf("balance cross check failed. block[%d] ord height err[%v]", check.blockNumber, err)
+		return
+	}
+	if height != check.blockNumber {
+		xylog.Logger.Debugf("balance cross check skipped. block[%d] ord height[%d]", check.blockNumber, height)
+		return
+	}
 
-	addrBalances := make(map[string]*xycommon.AddressBalance)
-	for _, tx := range txs {
-		if _, ok := txIdx[tx.Txid]; !ok {
+	type divergence struct {
+		item               model.Balances
+		balance, available decimal.Decimal
+	}
+	divergences := make([]*divergence, 0)
+	checked := make(map[string]struct{}, len(check.balances))
+	for i := len(check.balances) - 1; i >= 0; i-- {
+		// the latest record of an address in the block wins
+		item := check.balances[i]
+		key := fmt.Sprintf("%s_%s", item.Tick, item.Address)
+		if _, ok := checked[key]; ok {
 			continue
 		}
+		checked[key] = struct{}{}
 
-		for _, event := range tx.Events {
-			if !event.Valid {
-				xylog.Logger.Infof(" !event.Valid getAddressBalanceFromBlock----- from[%s] to[%s]", event.From.Address, event.To.Address)
-				continue
-			}
+		ordBalance, availableBalance, err := e.ordBalance(item.Address, item.Tick)
+		if err != nil {
+			xylog.Logger.Warnf("balance cross check failed. block[%d] address[%s] tick[%s] err[%v]", check.blockNumber, item.Address, item.Tick, err)
+			continue
+		}
 
-			if event.From.Address == "" || event.To.Address == "" {
-				xylog.Logger.Infof("the from or to address is empty and the transaction is skipped. txid[%s]", tx.Txid)
-				continue
-			}
-			// deploy balance not change don't need dispose
-			if event.Type == "deploy" {
-				continue
-			}
-			fromKey := fmt.Sprintf("%s_%s", event.Tick, event.From.Address)
-			toKey := fmt.Sprintf("%s_%s", event.Tick, event.To.Address)
-			addrBalances[fromKey] = &xycommon.AddressBalance{
-				Address: event.From.Address,
-				Tick:    event.Tick,
-			}
-			addrBalances[toKey] = &xycommon.AddressBalance{
-				Address: event.To.Address,
-				Tick:    event.Tick,
-			}
+		if !ordBalance.Equal(item.Balance) || !availableBalance.Equal(item.Available) {
+			divergences = append(divergences, &divergence{item: item, balance: ordBalance, available: availableBalance})
+		}
+	}
+
+	// balances read after the ord server moved on can't be compared
+	if len(divergences) > 0 {
+		if height, err = e.ordHeight(); err != nil || height != check.blockNumber {
+			xylog.Logger.Debugf("balance cross check discarded. block[%d] ord height[%d] err[%v]", check.blockNumber, height, err)
+			return
 		}
 	}
-	return addrBalances
+	for _, d := range divergences {
+		xylog.Logger.Warnf("balance divergence. block[%d] address[%s] tick[%s] local[%v/%v] ord[%v/%v]",
+			check.blockNumber, d.item.Address, d.item.Tick, d.item.Balance, d.item.Available, d.balance, d.available)
+	}
+}
+
+func (e *Explorer) ordHeightFetcher() (int64, error) {
+	return e.node.(*btc.BClient).OrdHeight(e.ctx)
 }
 
 func (e *Explorer) GetAddressBalance(address string, tick string) (decimal.Decimal, decimal.Decimal, error) {
@@ -260,7 +250,7 @@ func (e *Explorer) convertAmount(amount string, tick string) (decimal.Decimal, e
 	return a, nil
 }
 
-func (e *Explorer) buildModel(txid string, event *xycommon.BlockEvent, idx int, btcTx btcjson.TxRawResult, block *xycommon.RpcBlock, addressBalances map[string]*xycommon.AddressBalance) (*devents.DBModelEvent, error) {
+func (e *Explorer) buildModel(txid string, event *xycommon.BlockEvent, idx int, btcTx btcjson.TxRawResult, block *xycommon.RpcBlock) (*devents.DBModelEvent, error) {
 
 	fromOK, _ := e.dCache.Balance.Get(defaultProtocol, event.Tick, event.From.Address)
 	toOK, _ := e.dCache.Balance.Get(defaultProtocol, event.Tick, event.To.Address)
@@ -270,13 +260,11 @@ func (e *Explorer) buildModel(txid string, event *xycommon.BlockEvent, idx int,
 	startTime := time.Now()
 	if event.Type == "deploy" {
 
-		xylog.Logger.Infof("buildModel - deploy- GetInscription txid[%s]", txid)
-		ins, err := e.GetInscription(event.InscriptionId)
-		xylog.Logger.Infof("buildModel GetInscription end txid[%s]", txid)
+		ins, err := e.GetInscription(event)
 		if err != nil {
 			return nil, err
 		}
-		e.updateDeployCache(event.Tick, ins.LimitPerMint, ins.TotalSupply, defaultTickDecimals)
+		e.updateDeployCache(event.Tick, ins.LimitPerMint, ins.TotalSupply, ins.Decimals, ins.SelfMint, ins.Id)
 		xylog.Logger.Infof("buildModel- deploy- updateDeployCache end txid[%s]", txid)
 	} else if event.Type == "mint" {
 
@@ -287,6 +275,7 @@ func (e *Explorer) buildModel(txid string, event *xycommon.BlockEvent, idx int,
 	} else if event.Type == "transfer" {
 		xylog.Logger.Infof("buildModel- transfer- updateTransferCache end txid[%s]", txid)
 		e.updateTransferCache(event.Tick, event.From.Address, event.To.Address, amount)
+		e.dCache.UTXO.Remove(event.InscriptionId)
 		xylog.Logger.Infof("buildModel- transfer- updateTransferCache end txid[%s]", txid)
 
 	} else if event.Type == "inscribeTransfer" {
@@ -320,7 +309,7 @@ func (e *Explorer) buildModel(txid string, event *xycommon.BlockEvent, idx int,
 	}
 
 	xylog.Logger.Infof("buildModel- buildBalance- begin txid[%s] buildInscriptionStatsStartTime[%v] block[%v]", txid, time.Since(buildInscriptionStatsStartTime), block.Number)
-	txns, balances, err := e.buildBalance(txid, event, block, fromOK, toOK, addressBalances)
+	txns, balances, err := e.buildBalance(txid, event, block, fromOK, toOK)
 	if err != nil {
 		xylog.Logger.Infof("buildModel- buildBalance- err txid[%s], err[%v]", txid, err)
 		return nil, err
@@ -334,7 +323,7 @@ func (e *Explorer) buildModel(txid string, event *xycommon.BlockEvent, idx int,
 	}
 
 	xylog.Logger.Infof("buildModel- buildUTXO- begin txid[%s]", txid)
-	utxos, err := e.buildUTXO(event, block)
+	utxos, err := e.buildUTXO(txid, event)
 	if err != nil {
 		xylog.Logger.Infof("buildModel- buildUTXO- err txid[%s] err[%v]", txid, err)
 		return nil, err
@@ -352,7 +341,7 @@ func (e *Explorer) buildModel(txid string, event *xycommon.BlockEvent, idx int,
 	}, nil
 }
 
-func (e *Explorer) buildUTXO(event *xycommon.BlockEvent, block *xycommon.RpcBlock) (map[devents.DBAction]*model.UTXO, error) {
+func (e *Explorer) buildUTXO(txid string, event *xycommon.BlockEvent) (map[devents.DBAction]*model.UTXO, error) {
 	amount, err := e.convertAmount(event.Amount, event.Tick)
 	if err != nil {
 		xylog.Logger.Errorf("convertAmount error buildUTXO. err[%s]", err)
@@ -365,7 +354,9 @@ func (e *Explorer) buildUTXO(event *xycommon.BlockEvent, block *xycommon.RpcBloc
 				Protocol:          defaultProtocol,
 				Tick:              strings.ToLower(event.Tick),
 				Status:            model.UTXOStatusUnspent,
-				RootHash:          block.TxHash,
+				RootHash:          txid,
+				TxHash:            txid,
+				Satpoint:          inscriptionGenesisSatpoint(txid),
 				Address:           event.To.Address,
 				Amount:            amount,
 				InscriptionId:     event.InscriptionId,
@@ -423,267 +414,69 @@ func (e *Explorer) buildAddressTx(txId string, event *xycommon.BlockEvent, block
 	return txs, nil
 }
 
-func (e *Explorer) buildBalance(txid string, event *xycommon.BlockEvent, block *xycommon.RpcBlock, fromOK, toOK bool, addressBalances map[string]*xycommon.AddressBalance) ([]*model.BalanceTxn, map[devents.DBAction][]*model.Balances, error) {
+// buildBalance builds balance records from the local cache, which has already applied the event
+func (e *Explorer) buildBalance(txid string, event *xycommon.BlockEvent, block *xycommon.RpcBlock, fromOK, toOK bool) ([]*model.BalanceTxn, map[devents.DBAction][]*model.Balances, error) {
 	var txns []*model.BalanceTxn
-	//var balances map[DBAction][]*model.Balances
 	balances := make(map[devents.DBAction][]*model.Balances, 2)
 
-	if event.Type == "mint" {
-		amount, err := e.convertAmount(event.Amount, event.Tick)
-		if err != nil {
-			return nil, nil, err
-		}
-		ok, toBalance := e.dCache.Balance.Get(defaultProtocol, event.Tick, event.To.Address)
-		if !ok {
-			toBalance = e.dCache.Balance.Create(defaultProtocol, event.Tick, event.To.Address, &dcache.BalanceItem{
-				Available: amount,
-				Overall:   amount,
-			})
-			fmt.Printf("txid[%s] event.Tick[%s] event.To.Address[%s]\n", txid, event.Tick, event.To.Address)
-		}
-		//overallBalance := toBalance.Overall.Add(amount)
-		//availableBalance := toBalance.Available.Add(amount)
-		txns = append(txns, &model.BalanceTxn{
-			Chain:     e.config.Chain.ChainName,
-			Protocol:  defaultProtocol,
-			Event:     e.getEventByOperate(event.Type),
-			Address:   event.To.Address,
-			Tick:      strings.ToLower(event.Tick),
-			Amount:    amount,
-			Balance:   toBalance.Overall,
-			Available: toBalance.Available,
-			TxHash:    common.FromHex(txid),
-			CreatedAt: time.Unix(int64(block.Time), 0),
-		})
-
-		var ordBalance decimal.Decimal
-		var availableBalance decimal.Decimal
-		//if event.To.Address == "tb1pxc0h9ccs62el098z8ckjts0hm4xc3ndn959hj2tsnwhllqup446safkerz" {
-		//	fmt.Println("tb1pxc0h9ccs62el098z8ckjts0hm4xc3ndn959hj2tsnwhllqup446safkerz")
-		//}
-		toKey := fmt.Sprintf("%s_%s", event.Tick, event.To.Address)
-		//logs.Logger.Infof("%s: %v", toKey, addressBalances)
-		addrBalance, ok := addressBalances[toKey]
-		if ok {
-			ordBalance = addrBalance.OverallBalance
-			availableBalance = addrBalance.AvailableBalance
-			xylog.Logger.Infof("ok  address[%s] ordBalance[%v] availableBalance[%v]", event.To.Address, ordBalance, availableBalance)
-		} else {
-			var err1 error
-			ordBalance, availableBalance, err1 = e.GetAddressBalance(event.To.Address, event.Tick)
-			xylog.Logger.Infof("!ok  address[%s] ordBalance[%v] availableBalance[%v], err1[%s]", event.To.Address, ordBalance, availableBalance, err1)
-			if err1 != nil {
-				return nil, nil, err
-			}
-		}
+	amount, err := e.convertAmount(event.Amount, event.Tick)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		action := devents.DBActionUpdate
-		if !toOK {
-			action = devents.DBActionCreate
-		}
-		if _, ok := balances[action]; !ok {
-			balances[action] = make([]*model.Balances, 0, 1)
+	addBalance := func(address string, exists bool, amount decimal.Decimal) error {
+		ok, balance := e.dCache.Balance.Get(defaultProtocol, event.Tick, address)
+		if !ok {
+			return fmt.Errorf("abnormal user balance. address[%s] tick[%s] amount[%s]", address, event.Tick, amount.String())
 		}
 
-		balances[action] = setBalances(balances[action], &model.Balances{
-			SID:       toBalance.SID,
-			Chain:     e.config.Chain.ChainName,
-			Protocol:  defaultProtocol,
-			Address:   event.To.Address,
-			Tick:      strings.ToLower(event.Tick),
-			Balance:   ordBalance,
-			Available: availableBalance,
-		}, action)
-
-	} else if event.Type == "transfer" {
-		amount, err := e.convertAmount(event.Amount, event.Tick)
-		if err != nil {
-			return nil, nil, err
-		}
-		tok, toBalance := e.dCache.Balance.Get(defaultProtocol, event.Tick, event.To.Address)
-		toOverall := decimal.Zero
-		toAvailable := decimal.Zero
-		if tok {
-			toOverall = toBalance.Overall
-			toAvailable = toBalance.Available
-		} else {
-			toBalance = e.dCache.Balance.Create(defaultProtocol, event.Tick, event.To.Address, &dcache.BalanceItem{
-				Available: amount,
-				Overall:   amount,
-			})
-		}
 		txns = append(txns, &model.BalanceTxn{
 			Chain:     e.config.Chain.ChainName,
 			Protocol:  defaultProtocol,
 			Event:     e.getEventByOperate(event.Type),
-			Address:   event.To.Address,
+			Address:   address,
 			Tick:      strings.ToLower(event.Tick),
 			Amount:    amount,
-			Balance:   toOverall,
-			Available: toAvailable,
+			Balance:   balance.Overall,
+			Available: balance.Available,
 			TxHash:    common.FromHex(txid),
 			CreatedAt: time.Unix(int64(block.Time), 0),
 		})
 
 		action := devents.DBActionUpdate
-		if !toOK {
+		if !exists {
 			action = devents.DBActionCreate
 		}
-		if _, ok := balances[action]; !ok {
-			balances[action] = make([]*model.Balances, 0, 1)
-		}
-
-		//ordBalance, ordAvailableBalance, err := e.GetAddressBalance(event.To.Address, event.Tick)
-		//if err != nil {
-		//	return nil, nil, err
-		//}
-		var ordBalance decimal.Decimal
-		var availableBalance decimal.Decimal
-		toKey := fmt.Sprintf("%s_%s", event.Tick, event.To.Address)
-		addrBalance, ok := addressBalances[toKey]
-		if ok {
-			ordBalance = addrBalance.OverallBalance
-			availableBalance = addrBalance.AvailableBalance
-		} else {
-			var err1 error
-			ordBalance, availableBalance, err1 = e.GetAddressBalance(event.To.Address, event.Tick)
-			if err1 != nil {
-				return nil, nil, err
-			}
-		}
 		balances[action] = setBalances(balances[action], &model.Balances{
-			SID:       toBalance.SID,
+			SID:       balance.SID,
 			Chain:     e.config.Chain.ChainName,
 			Protocol:  defaultProtocol,
-			Address:   event.To.Address,
+			Address:   address,
 			Tick:      strings.ToLower(event.Tick),
-			Balance:   ordBalance,
-			Available: availableBalance,
+			Balance:   balance.Overall,
+			Available: balance.Available,
 		}, action)
+		return nil
+	}
 
-		ok, senderBalance := e.dCache.Balance.Get(defaultProtocol, event.Tick, event.From.Address)
-		if !ok {
-			senderBalance = e.dCache.Balance.Create(defaultProtocol, event.Tick, event.From.Address, &dcache.BalanceItem{
-				Available: amount,
-				Overall:   amount,
-			})
-		}
-		//senderOverallBalance := senderBalance.Overall.Sub(amount)
-		//senderAvailableBalance := senderBalance.Available.Sub(amount)
-		txns = append(txns, &model.BalanceTxn{
-			Chain:     e.config.Chain.ChainName,
-			Protocol:  defaultProtocol,
-			Event:     e.getEventByOperate(event.Type),
-			Address:   event.To.Address,
-			Tick:      strings.ToLower(event.Tick),
-			Amount:    amount.Neg(),
-			Balance:   senderBalance.Overall,
-			Available: senderBalance.Available,
-			TxHash:    common.FromHex(txid),
-			CreatedAt: time.Unix(int64(block.Time), 0),
-		})
-
-		senderAction := devents.DBActionUpdate
-		if !fromOK {
-			senderAction = devents.DBActionCreate
-		}
-		if _, ok := balances[senderAction]; !ok {
-			balances[senderAction] = make([]*model.Balances, 0, 1)
-		}
-		//senderOrdBalance, senderAvailableBalance, err := e.GetAddressBalance(event.From.Address, event.Tick)
-		//if err != nil {
-		//	return nil, nil, err
-		//}
-		var senderOrdBalance decimal.Decimal
-		var senderAvailableBalance decimal.Decimal
-		fromKey := fmt.Sprintf("%s_%s", event.Tick, event.From.Address)
-		fromAddrBalance, ok := addressBalances[fromKey]
-		if ok {
-			senderOrdBalance = fromAddrBalance.OverallBalance
-			senderAvailableBalance = fromAddrBalance.AvailableBalance
-		} else {
-			var err1 error
-			senderOrdBalance, senderAvailableBalance, err1 = e.GetAddressBalance(event.To.Address, event.Tick)
-			if err1 != nil {
-				return nil, nil, err
-			}
-		}
-
-		balances[senderAction] = setBalances(balances[senderAction], &model.Balances{
-			SID:       senderBalance.SID,
-			Chain:     e.config.Chain.ChainName,
-			Protocol:  defaultProtocol,
-			Address:   event.To.Address,
-			Tick:      strings.ToLower(event.Tick),
-			Balance:   senderOrdBalance,
-			Available: senderAvailableBalance,
-		}, senderAction)
-
-	} else if event.Type == "inscribeTransfer" {
-		amount, err := e.convertAmount(event.Amount, event.Tick)
-		if err != nil {
+	switch event.Type {
+	case "mint", "inscribeTransfer":
+		if err = addBalance(event.To.Address, toOK, amount); err != nil {
 			return nil, nil, err
 		}
-		var overallBalance decimal.Decimal
-		var avaBalance decimal.Decimal
-		toOK, toBalance := e.dCache.Balance.Get(defaultProtocol, event.Tick, event.To.Address)
-		action := devents.DBActionUpdate
-		if !toOK {
-			action = devents.DBActionCreate
-			avaBalance = decimal.Zero
-			overallBalance = amount
-			return nil, nil, fmt.Errorf("abnormal user balance. address[%s] amount[%s]", event.To.Address, amount.String())
-		} else {
-			overallBalance = toBalance.Overall
-			avaBalance = toBalance.Available
+	case "transfer":
+		if err = addBalance(event.To.Address, toOK, amount); err != nil {
+			return nil, nil, err
 		}
-		//overallBalance := toBalance.Overall.Add(amount)
-		//availableBalance := toBalance.Available.Sub(amount)
-		txns = append(txns, &model.BalanceTxn{
-			Chain:     e.config.Chain.ChainName,
-			Protocol:  defaultProtocol,
-			Event:     e.getEventByOperate(event.Type),
-			Address:   event.To.Address,
-			Tick:      strings.ToLower(event.Tick),
-			Amount:    amount,
-			Balance:   overallBalance,
-			Available: avaBalance,
-			TxHash:    common.FromHex(txid),
-			CreatedAt: time.Unix(int64(block.Time), 0),
-		})
 
-		if _, ok := balances[action]; !ok {
-			balances[action] = make([]*model.Balances, 0, 1)
+		// inscription sent back to the owner, only the available balance is restored
+		if strings.EqualFold(event.From.Address, event.To.Address) {
+			break
 		}
-		//ordBalance, availableBalance, err := e.GetAddressBalance(event.To.Address, event.Tick)
-		//if err != nil {
-		//	return nil, nil, err
-		//}
-		var ordBalance decimal.Decimal
-		var availableBalance decimal.Decimal
-		toKey := fmt.Sprintf("%s_%s", event.Tick, event.To.Address)
-		addrBalance, ok := addressBalances[toKey]
-		if ok {
-			ordBalance = addrBalance.OverallBalance
-			availableBalance = addrBalance.AvailableBalance
-		} else {
-			var err1 error
-			ordBalance, availableBalance, err1 = e.GetAddressBalance(event.To.Address, event.Tick)
-			if err1 != nil {
-				return nil, nil, err
-			}
+		if err = addBalance(event.From.Address, fromOK, amount.Neg()); err != nil {
+			return nil, nil, err
 		}
-		balances[action] = setBalances(balances[action], &model.Balances{
-			SID:       toBalance.SID,
-			Chain:     e.config.Chain.ChainName,
-			Protocol:  defaultProtocol,
-			Address:   event.To.Address,
-			Tick:      strings.ToLower(event.Tick),
-			Balance:   ordBalance,
-			Available: availableBalance,
-		}, action)
 	}
-
 	return txns, balances, nil
 }
 
@@ -771,7 +564,7 @@ func (e *Explorer) buildInscription(txid string, event *xycommon.BlockEvent, blo
 		return nil, fmt.Errorf("inscription not found. txid[%s] tick[%s]", txid, event.Tick)
 	}
 	ret := make(map[devents.DBAction]*model.Inscriptions)
-	ins, err := e.GetInscription(event.InscriptionId)
+	ins, err := e.GetInscription(event)
 	if err != nil {
 		return nil, err
 	}
@@ -789,44 +582,53 @@ func (e *Explorer) buildInscription(txid string, event *xycommon.BlockEvent, blo
 		Decimals:          ins.Decimals,
 		InscriptionId:     ins.Id,
 		InscriptionNumber: ins.Number,
+		SelfMint:          ins.SelfMint,
 	}
 	ret[devents.DBActionCreate] = inscription
 
 	return ret, nil
 }
 
-func (e *Explorer) GetInscription(id string) (*xycommon.Inscription, error) {
-
-	bClient := e.node.(*btc.BClient)
-	ins, err := bClient.GetInscription(context.Background(), id)
-	if err != nil {
+// GetInscription decodes the deploy inscription content parsed from the witness
+func (e *Explorer) GetInscription(event *xycommon.BlockEvent) (*xycommon.Inscription, error) {
+	insContent := &xycommon.InscriptionContent{}
+	if err := json.Unmarshal([]byte(event.Content), insContent); err != nil {
 		return nil, err
 	}
-	if ins == nil {
-		return nil, errors.New("inscription empty, id=" + id)
+
+	selfMint := btcBrc20.IsSelfMint(insContent.Tick, insContent.SelfMint)
+	totalSupply, _ := decimal.NewFromString(insContent.Max)
+	if selfMint && totalSupply.IsZero() {
+		totalSupply = decimal.NewFromBigInt(new(big.Int).SetUint64(math.MaxUint64), 0)
 	}
-	content, err := hex.DecodeString(ins.Content)
-	if err != nil {
-		return nil, err
+
+	limit := totalSupply
+	if insContent.LimitPerMint != "" {
+		limit, _ = decimal.NewFromString(insContent.LimitPerMint)
 	}
-	insContent := &xycommon.InscriptionContent{}
-	err1 := json.Unmarshal(content, &insContent)
-	if err1 != nil {
-		return nil, err1
+	if selfMint && limit.IsZero() {
+		limit = totalSupply
 	}
 
-	limit, _ := decimal.NewFromString(insContent.LimitPerMint)
-	totalSupply, _ := decimal.NewFromString(insContent.Max)
+	tickDecimals := defaultTickDecimals
+	if insContent.Decimals != "" {
+		dec, err := decimal.NewFromString(insContent.Decimals)
+		if err != nil {
+			return nil, err
+		}
+		tickDecimals = int8(dec.IntPart())
+	}
 
 	return &xycommon.Inscription{
-		Id:           id,
+		Id:           event.InscriptionId,
 		Tick:         insContent.Tick,
 		LimitPerMint: limit,
 		TotalSupply:  totalSupply,
-		Decimals:     defaultTickDecimals,
-		Number:       ins.Number,
-		Owner:        ins.Owner.Address,
-		Content:      string(content),
+		Decimals:     tickDecimals,
+		SelfMint:     selfMint,
+		Number:       event.InscriptionNumber,
+		Owner:        event.To.Address,
+		Content:      event.Content,
 	}, nil
 }
 
@@ -836,15 +638,6 @@ func (e *Explorer) buildTx(txId string, event *xycommon.BlockEvent, idx int, tx
 	if err != nil {
 		return nil, err
 	}
-	ins, err := e.GetInscription(event.InscriptionId)
-	if err != nil {
-		return nil, err
-	}
-
-	var content string
-	if ins != nil {
-		content = ins.Content
-	}
 	return &model.Transaction{
 		Chain:           e.config.Chain.ChainName,
 		Protocol:        "brc-20",
@@ -860,7 +653,9 @@ func (e *Explorer) buildTx(txId string, event *xycommon.BlockEvent, idx int, tx
 		Gas:             0,
 		GasPrice:        0,
 		CreatedAt:       time.Unix(int64(block.Time), 0),
-		Content:         content,
+		Content:         event.Content,
+
+		InscriptionNumber: event.InscriptionNumber,
 	}, nil
 }
 
@@ -0,0 +1,77 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package explorer
+
+import (
+	"context"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/model"
+	"testing"
+)
+
+func TestCrossCheckBalances(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ordHeight := int64(0)
+	queried := make([]string, 0)
+	e := &Explorer{
+		ctx:           ctx,
+		balanceChecks: make(chan *balanceCheck, 1),
+		ordHeight: func() (int64, error) {
+			return ordHeight, nil
+		},
+		ordBalance: func(address, tick string) (decimal.Decimal, decimal.Decimal, error) {
+			queried = append(queried, address)
+			return decimal.NewFromInt(5), decimal.NewFromInt(5), nil
+		},
+	}
+
+	blockTxs := []*devents.DBModelEvent{
+		{Balances: map[devents.DBAction][]*model.Balances{
+			devents.DBActionCreate: {{Address: "bc1a", Tick: "ordi", Balance: decimal.NewFromInt(1)}},
+		}},
+		{Balances: map[devents.DBAction][]*model.Balances{
+			devents.DBActionUpdate: {{Address: "bc1a", Tick: "ordi", Balance: decimal.NewFromInt(5), Available: decimal.NewFromInt(5)}},
+		}},
+	}
+
+	// one pending check at most, later blocks are skipped while the worker is busy
+	e.enqueueBalanceCheck(100, blockTxs)
+	e.enqueueBalanceCheck(101, blockTxs)
+	assert.Len(t, e.balanceChecks, 1)
+	check := <-e.balanceChecks
+	assert.Equal(t, int64(100), check.blockNumber)
+
+	// the ord server is ahead, its latest balances can't be compared with a historical block
+	ordHeight = 120
+	e.crossCheckBalances(check)
+	assert.Empty(t, queried)
+
+	// same height, every address is queried once with its latest record of the block
+	ordHeight = 100
+	e.crossCheckBalances(check)
+	assert.Equal(t, []string{"bc1a"}, queried)
+}
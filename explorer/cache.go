@@ -25,13 +25,16 @@ package explorer
 import (
 	"github.com/shopspring/decimal"
 	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/xylog"
 )
 
-func (e *Explorer) updateDeployCache(tick string, limit, total decimal.Decimal, decimals int8) {
+func (e *Explorer) updateDeployCache(tick string, limit, total decimal.Decimal, decimals int8, selfMint bool, inscriptionId string) {
 	t := &dcache.Tick{
-		LimitPerMint: limit,
-		TotalSupply:  total,
-		Decimals:     decimals,
+		LimitPerMint:  limit,
+		TotalSupply:   total,
+		Decimals:      decimals,
+		SelfMint:      selfMint,
+		InscriptionId: inscriptionId,
 	}
 	e.dCache.Inscription.Create(defaultProtocol, tick, t)
 
@@ -138,5 +141,7 @@ func (e *Explorer) updateInscribeTransferCache(tick, address, txhash, inscriptio
 		Overall:   balance.Overall,
 	})
 	// add utxo record
-	e.dCache.UTXO.Add(defaultProtocol, tick, txhash, address, amount, inscriptionID)
+	if err := e.dCache.UTXO.Add(defaultProtocol, tick, inscriptionGenesisSatpoint(txhash), address, amount, inscriptionID); err != nil {
+		xylog.Logger.Errorf("add utxo error. inscription[%s] err[%v]", inscriptionID, err)
+	}
 }
@@ -23,7 +23,6 @@
 package explorer
 
 import (
-	"errors"
 	"fmt"
 	"github.com/alitto/pond"
 	"github.com/uxuycom/indexer/client/xycommon"
@@ -31,6 +30,7 @@ import (
 	"github.com/uxuycom/indexer/model"
 	"github.com/uxuycom/indexer/protocol"
 	"github.com/uxuycom/indexer/protocol/common"
+	"github.com/uxuycom/indexer/protocol/evm/ethscriptions"
 	"github.com/uxuycom/indexer/xyerrors"
 	"github.com/uxuycom/indexer/xylog"
 	"math/big"
@@ -40,7 +40,7 @@ import (
 	"time"
 )
 
-func (e *Explorer) validReceiptTxs(items []*xycommon.RpcTransaction) ([]*xycommon.RpcTransaction, *xyerrors.InsError) {
+func (e *Explorer) validReceiptTxs(block *xycommon.RpcBlock, items []*xycommon.RpcTransaction) ([]*xycommon.RpcTransaction, *xyerrors.InsError) {
 	startTs := time.Now()
 	defer func() {
 		xylog.Logger.Infof("handle txs, fetch receipt data cost[%v], items[%d]", time.Since(startTs), len(items))
@@ -51,12 +51,29 @@ func (e *Explorer) validReceiptTxs(items []*xycommon.RpcTransaction) ([]*xycommo
 		txHashList[item.Hash] = struct{}{}
 	}
 
+	receiptsMap := &sync.Map{}
+
+	// all receipts of the block in one call, falls back to the receipts per tx
+	if e.config.Chain.BlockReceipts && len(txHashList) > 0 {
+		receipts, err := e.node.BlockReceipts(e.ctx, block.Number)
+		if err != nil {
+			xylog.Logger.Warnf("get block[%d] receipts err:%v, fetch receipts per tx", block.Number.Uint64(), err)
+		}
+		for _, r := range receipts {
+			if _, ok := txHashList[r.TxHash.String()]; ok {
+				receiptsMap.Store(r.TxHash.String(), r)
+			}
+		}
+	}
+
 	workers := int(e.config.Scan.TxBatchWorkers)
 	pool := pond.New(workers, 0, pond.MinWorkers(workers))
 
-	receiptsMap := &sync.Map{}
 	for txHash := range txHashList {
 		hash := txHash
+		if _, ok := receiptsMap.Load(hash); ok {
+			continue
+		}
 		pool.Submit(func() {
 			r, err := e.node.TransactionReceipt(e.ctx, hash)
 			if err != nil {
@@ -102,32 +119,51 @@ func (e *Explorer) validReceiptTxs(items []*xycommon.RpcTransaction) ([]*xycommo
 	return results, nil
 }
 
-func (e *Explorer) tryFilterTxs(txs []*xycommon.RpcTransaction) []*xycommon.RpcTransaction {
+// tryFilterTxs filter invalid txs, the protocol metadata of the valid txs is kept by tx hash & parsed only once
+func (e *Explorer) tryFilterTxs(txs []*xycommon.RpcTransaction) ([]*xycommon.RpcTransaction, map[string]*txMeta) {
 	validTxs := make([]*xycommon.RpcTransaction, 0, len(txs))
+	metas := make(map[string]*txMeta, len(txs))
 	for _, tx := range txs {
-		pt, md := protocol.GetProtocol(e.config, tx)
-		if pt == nil {
-			continue
+		meta := e.filterTx(tx)
+		if meta != nil {
+			metas[txKey(tx)] = meta
 		}
 
-		// Add protocol whitelist
-		if !e.protocolEnabled(md.Protocol) {
-			continue
+		// wrapper erc-20 events are kept whatever the inscription data of the tx
+		if meta != nil || protocol.HasWraps(tx) {
+			validTxs = append(validTxs, tx)
 		}
+	}
+	return validTxs, metas
+}
 
-		// Add protocol whitelist
-		if !e.tickEnabled(md.Tick) {
-			continue
-		}
+// txKey smart account inner calls share the hash of their tx
+func txKey(tx *xycommon.RpcTransaction) string {
+	return fmt.Sprintf("%s:%d", tx.Hash, tx.SubIndex)
+}
 
-		// Add mint completed filter
-		if e.filterMintCompleted(md) {
-			xylog.Logger.Infof("tx hit mint completed strategy & ignore. tx[%s]", tx.Hash)
-			continue
-		}
-		validTxs = append(validTxs, tx)
+func (e *Explorer) filterTx(tx *xycommon.RpcTransaction) *txMeta {
+	pt, md := protocol.GetProtocol(e.config, tx)
+	if pt == nil {
+		return nil
+	}
+
+	// Add protocol whitelist
+	if !e.protocolEnabled(md.Protocol) {
+		return nil
 	}
-	return validTxs
+
+	// Add protocol whitelist
+	if !e.tickEnabled(md.Tick) {
+		return nil
+	}
+
+	// Add mint completed filter
+	if e.filterMintCompleted(md) {
+		xylog.Logger.Infof("tx hit mint completed strategy & ignore. tx[%s]", tx.Hash)
+		return nil
+	}
+	return &txMeta{pt: pt, md: md}
 }
 
 func (e *Explorer) filterMintCompleted(md *devents.MetaData) bool {
@@ -155,52 +191,32 @@ func (e *Explorer) filterMintCompleted(md *devents.MetaData) bool {
 	return false
 }
 
-func (e *Explorer) handleTxs(block *xycommon.RpcBlock, txs []*xycommon.RpcTransaction) *xyerrors.InsError {
+func (e *Explorer) handleTxs(block *xycommon.RpcBlock, txs []*xycommon.RpcTransaction, metas map[string]*txMeta) *xyerrors.InsError {
 	startTs := time.Now()
 	defer func() {
 		xylog.Logger.Infof("handle txs, parse & async sink cost[%v], txs[%d]", time.Since(startTs), len(txs))
 	}()
 
-	blockTxResults := make([]*devents.DBModelEvent, 0, len(txs))
-	for _, tx := range txs {
-		pt, md := protocol.GetProtocol(e.config, tx)
-		if pt == nil {
-			continue
-		}
-
-		// Add protocol whitelist
-		if !e.protocolEnabled(md.Protocol) {
-			continue
-		}
-
-		// Add protocol whitelist
-		if !e.tickEnabled(md.Tick) {
-			continue
-		}
-
-		txResults, err := pt.Parse(block, tx, md)
-		if err != nil && errors.Is(err, xyerrors.ErrInternal) {
-			return err
-		}
-		if err != nil {
-			xylog.Logger.Infof("tx data parsed failed. md[%v], tx[%s], err[%v]", md, tx.Hash, err)
-			continue
-		}
-		xylog.Logger.Infof("tx data parsed success. md[%v], tx[%s]", md, tx.Hash)
+	blockTxResults, err := e.parseTxs(block, txs, metas)
+	if err != nil {
+		return err
+	}
+	e.reconcileWrappers(block)
+	e.writeDBAsync(block, blockTxResults)
+	return nil
+}
 
-		if len(txResults) < 1 {
-			xylog.Logger.Warnf("tx data parsed result nil. md[%v], tx[%s]", md, tx.Hash)
+// reconcileWrappers alert when the erc-20 supply of a wrapper diverges from its locked balance
+func (e *Explorer) reconcileWrappers(block *xycommon.RpcBlock) {
+	for _, item := range e.dCache.Wrapper.Reconcile() {
+		if item.Diverged() {
+			xylog.Logger.Warnf("wrapper[%s] diverged at block[%d], tick[%s-%s], supply[%s], locked[%s]",
+				item.Name, block.Number, item.Protocol, item.Tick, item.Supply, item.Locked)
 			continue
 		}
-
-		// update cache
-		for _, txResult := range txResults {
-			e.txResultHandler.UpdateCache(txResult)
-			blockTxResults = append(blockTxResults, e.txResultHandler.BuildModel(txResult))
-		}
+		xylog.Logger.Infof("wrapper[%s] reconciled at block[%d], tick[%s-%s], supply[%s]",
+			item.Name, block.Number, item.Protocol, item.Tick, item.Supply)
 	}
-	e.writeDBAsync(block, blockTxResults)
-	return nil
 }
 
 func (e *Explorer) extractTxsFromBlock(block *xycommon.RpcBlock) []*xycommon.RpcTransaction {
@@ -210,6 +226,21 @@ func (e *Explorer) extractTxsFromBlock(block *xycommon.RpcBlock) []*xycommon.Rpc
 
 	txs := make([]*xycommon.RpcTransaction, 0, len(block.Transactions))
 	for _, tx := range block.Transactions {
+		// smart account inscriptions, the inner calls are indexed in place of the tx in sub index order
+		if inners := protocol.UnwrapSmartAccount(e.config, tx); len(inners) > 0 {
+			for _, inner := range inners {
+				if e.fastChecking(inner) {
+					txs = append(txs, inner)
+				}
+			}
+			continue
+		}
+
+		// blob inscriptions, the decoded blobs are indexed as the calldata
+		if inner := protocol.UnwrapBlobs(e.config, tx); inner != nil {
+			tx = inner
+		}
+
 		// fast check & filter invalid txs
 		if !e.fastChecking(tx) {
 			continue
@@ -274,17 +305,17 @@ func (e *Explorer) handleBlock(block *xycommon.RpcBlock) {
 			txs := e.extractTxsFromBlock(block)
 
 			// try filter invalid txs
-			txs = e.tryFilterTxs(txs)
+			txs, metas := e.tryFilterTxs(txs)
 
 			// Add receipt data & filter invalid status
-			txs, err := e.validReceiptTxs(txs)
+			txs, err := e.validReceiptTxs(block, txs)
 			if err != nil {
 				xylog.Logger.Errorf("fetch receipt data internal err:%v & retry later[%d]", err, retry)
 				retry++
 				<-time.After(time.Millisecond * 100)
 				continue
 			}
-			err = e.handleTxs(block, txs)
+			err = e.handleTxs(block, txs, metas)
 		}
 		if err != nil {
 			xylog.Logger.Errorf("parse internal err:%v & retry later[%d]", err, retry)
@@ -335,6 +366,16 @@ func (e *Explorer) fastChecking(tx *xycommon.RpcTransaction) bool {
 	if strings.HasPrefix(trxContent, common.DataPrefix) {
 		return true
 	}
+
+	// ESIP-7 gzip compressed calldata
+	if strings.HasPrefix(trxContent, common.GzipPrefix) {
+		return true
+	}
+
+	// ethscription ids transfer checking
+	if ethscriptions.IsTransferInput(trxContent) {
+		return true
+	}
 	return false
 }
 
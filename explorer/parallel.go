@@ -0,0 +1,220 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package explorer
+
+import (
+	"errors"
+	"fmt"
+	"github.com/alitto/pond"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol"
+	"github.com/uxuycom/indexer/protocol/types"
+	"github.com/uxuycom/indexer/xyerrors"
+	"github.com/uxuycom/indexer/xylog"
+	"runtime"
+	"strings"
+)
+
+// txMeta protocol & metadata parsed once by tryFilterTxs
+type txMeta struct {
+	pt types.IProtocol
+	md *devents.MetaData
+}
+
+// txTask a tx of the block, tasks of a shard share the protocol tick
+type txTask struct {
+	tx      *xycommon.RpcTransaction
+	meta    *txMeta
+	results []*devents.TxResult
+	models  []*devents.DBModelEvent
+	err     *xyerrors.InsError
+}
+
+// shardOperates single tick operates, txs of different ticks never touch the same cache records
+var shardOperates = map[string]struct{}{
+	devents.OperateMint:     {},
+	devents.OperateTransfer: {},
+	devents.OperateList:     {},
+	devents.OperateFreeze:   {},
+	devents.OperateUnfreeze: {},
+}
+
+// shardKey the (protocol, tick) shard of the task, empty for serialization points:
+// deploys, exchange & bridge events, ethscriptions and wrapper events
+func (t *txTask) shardKey() string {
+	if t.meta == nil || t.meta.md.Tick == "" || t.meta.md.Protocol == types.EthscriptionsProtocol {
+		return ""
+	}
+
+	if _, ok := shardOperates[t.meta.md.Operate]; !ok {
+		return ""
+	}
+	return fmt.Sprintf("%s_%s", t.meta.md.Protocol, strings.ToLower(t.meta.md.Tick))
+}
+
+// parseTxs parse the block txs, consecutive single tick txs are sharded by (protocol, tick) & parsed concurrently,
+// the results are merged back in tx order, serialization points are parsed alone in between
+func (e *Explorer) parseTxs(block *xycommon.RpcBlock, txs []*xycommon.RpcTransaction, metas map[string]*txMeta) ([]*devents.DBModelEvent, *xyerrors.InsError) {
+	models := make([]*devents.DBModelEvent, 0, len(txs))
+	pending := make([]*txTask, 0, len(txs))
+	flush := func() *xyerrors.InsError {
+		if len(pending) == 0 {
+			return nil
+		}
+
+		e.parseShards(block, pending)
+		for _, task := range pending {
+			if task.err != nil {
+				return task.err
+			}
+
+			if len(task.results) < 1 {
+				continue
+			}
+
+			// number valid inscription txs in block & tx index order
+			number := e.dCache.InscriptionNumber.Next()
+			for idx, txResult := range task.results {
+				e.txResultHandler.FinalizeModel(txResult, task.models[idx], number)
+			}
+			models = append(models, task.models...)
+		}
+		pending = pending[:0]
+		return nil
+	}
+
+	for _, tx := range txs {
+		// wrapper erc-20 events, tracked apart from the inscription data of the tx
+		wraps := make([]*devents.TxResult, 0)
+		for _, txResult := range protocol.ParseWraps(e.config, block, tx) {
+			if !e.protocolEnabled(txResult.MD.Protocol) || !e.tickEnabled(txResult.MD.Tick) {
+				continue
+			}
+			wraps = append(wraps, txResult)
+		}
+
+		task := &txTask{tx: tx, meta: metas[txKey(tx)]}
+		if len(wraps) == 0 && task.shardKey() != "" {
+			pending = append(pending, task)
+			continue
+		}
+
+		// serialization point, the shards before go first
+		if err := flush(); err != nil {
+			return nil, err
+		}
+
+		for _, txResult := range wraps {
+			e.txResultHandler.UpdateCache(txResult)
+			models = append(models, e.txResultHandler.BuildModel(txResult))
+		}
+
+		if task.meta == nil {
+			continue
+		}
+
+		e.parseTask(block, task, true)
+		if task.err != nil {
+			return nil, task.err
+		}
+		models = append(models, task.models...)
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return models, nil
+}
+
+// parseShards parse the shards concurrently, txs of a shard in tx order.
+// New balance sids are deferred until the results are merged in tx order.
+func (e *Explorer) parseShards(block *xycommon.RpcBlock, tasks []*txTask) {
+	shards := make(map[string][]*txTask)
+	keys := make([]string, 0)
+	for _, task := range tasks {
+		key := task.shardKey()
+		if _, ok := shards[key]; !ok {
+			keys = append(keys, key)
+		}
+		shards[key] = append(shards[key], task)
+	}
+
+	e.dCache.Balance.DeferSid(true)
+	defer e.dCache.Balance.DeferSid(false)
+
+	workers := int(e.config.Scan.TxParseWorkers)
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	pool := pond.New(workers, 0, pond.MinWorkers(workers))
+	for _, key := range keys {
+		shard := shards[key]
+		pool.Submit(func() {
+			for _, task := range shard {
+				// numbered when merged
+				e.parseTask(block, task, false)
+				if task.err != nil {
+					return
+				}
+			}
+		})
+	}
+	pool.StopAndWait()
+
+	xylog.Logger.Infof("parse shards finished, txs[%d], shards[%d]", len(tasks), len(keys))
+}
+
+// parseTask parse the tx & update the cache, only internal errors are kept
+func (e *Explorer) parseTask(block *xycommon.RpcBlock, task *txTask, numbered bool) {
+	md := task.meta.md
+	txResults, err := task.meta.pt.Parse(block, task.tx, md)
+	if err != nil && errors.Is(err, xyerrors.ErrInternal) {
+		task.err = err
+		return
+	}
+	if err != nil {
+		xylog.Logger.Infof("tx data parsed failed. md[%v], tx[%s], err[%v]", md, task.tx.Hash, err)
+		return
+	}
+	xylog.Logger.Infof("tx data parsed success. md[%v], tx[%s]", md, task.tx.Hash)
+
+	if len(txResults) < 1 {
+		xylog.Logger.Warnf("tx data parsed result nil. md[%v], tx[%s]", md, task.tx.Hash)
+		return
+	}
+
+	// number valid inscription txs in block & tx index order
+	number := int64(0)
+	if numbered {
+		number = e.dCache.InscriptionNumber.Next()
+	}
+
+	// update cache
+	for _, txResult := range txResults {
+		txResult.Number = number
+		e.txResultHandler.UpdateCache(txResult)
+		task.results = append(task.results, txResult)
+		task.models = append(task.models, e.txResultHandler.BuildModel(txResult))
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package explorer
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol"
+	"github.com/uxuycom/indexer/xylog"
+	"math/big"
+	"testing"
+)
+
+func init() {
+	xylog.InitLog(logrus.ErrorLevel, "")
+}
+
+func parallelTestBlocks() []*xycommon.RpcBlock {
+	newTx := func(number uint64, idx int, from, to, data string) *xycommon.RpcTransaction {
+		return &xycommon.RpcTransaction{
+			BlockNumber: new(big.Int).SetUint64(number),
+			TxIndex:     big.NewInt(int64(idx)),
+			Hash:        fmt.Sprintf("0x%032x%032x", number, idx),
+			From:        from,
+			To:          to,
+			Input:       "0x" + hex.EncodeToString([]byte(data)),
+			Gas:         big.NewInt(0),
+			GasPrice:    big.NewInt(0),
+		}
+	}
+	addr := func(i int) string {
+		return fmt.Sprintf("0x%040x", i+1)
+	}
+
+	ticks := []string{"aaaa", "bbbb", "cccc"}
+	blocks := make([]*xycommon.RpcBlock, 0, 2)
+	for number := uint64(1); number <= 2; number++ {
+		block := &xycommon.RpcBlock{Number: new(big.Int).SetUint64(number), Time: 1700000000 + number}
+		add := func(from, to, data string) {
+			block.Transactions = append(block.Transactions, newTx(number, len(block.Transactions), from, to, data))
+		}
+
+		if number == 1 {
+			for _, tick := range ticks {
+				add(addr(0), addr(0), fmt.Sprintf(`data:,{"p":"asc-20","op":"deploy","tick":"%s","max":"1000000","lim":"1000"}`, tick))
+			}
+			blocks = append(blocks, block)
+			continue
+		}
+
+		// interleaved mints & transfers of the ticks, a deploy in between is a serialization point
+		for i := 0; i < 30; i++ {
+			tick := ticks[i%len(ticks)]
+			add(addr(i), addr(i), fmt.Sprintf(`data:,{"p":"asc-20","op":"mint","tick":"%s","amt":"1000"}`, tick))
+			if i == 15 {
+				add(addr(0), addr(0), `data:,{"p":"asc-20","op":"deploy","tick":"dddd","max":"1000000","lim":"1000"}`)
+			}
+			if i > 15 {
+				add(addr(i), addr(i), `data:,{"p":"asc-20","op":"mint","tick":"dddd","amt":"10"}`)
+			}
+			if i >= 3 {
+				add(addr(i-3), addr(100+i), fmt.Sprintf(`data:,{"p":"asc-20","op":"transfer","tick":"%s","amt":"400"}`, tick))
+			}
+			// rejected, exceeds the balance
+			add(addr(i), addr(200+i), fmt.Sprintf(`data:,{"p":"asc-20","op":"transfer","tick":"%s","amt":"5000"}`, tick))
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+func newParallelTestExplorer(t *testing.T, workers uint64) *Explorer {
+	cfg := &config.Config{
+		Scan:  config.ScanConfig{TxParseWorkers: workers},
+		Chain: config.ChainConfig{ChainName: "avalanche", ChainGroup: "evm"},
+	}
+	cache := dcache.NewMemoryManager(cfg.Chain.ChainName)
+	assert.NoError(t, protocol.InitProtocols(cfg, cache))
+	return &Explorer{
+		config:          cfg,
+		dCache:          cache,
+		txResultHandler: devents.NewTxResultHandler(cache),
+	}
+}
+
+// sequentialParseTxs parse the txs one by one, the reference of the sharded parsing
+func sequentialParseTxs(e *Explorer, block *xycommon.RpcBlock) []*devents.DBModelEvent {
+	models := make([]*devents.DBModelEvent, 0)
+	for _, tx := range block.Transactions {
+		pt, md := protocol.GetProtocol(e.config, tx)
+		if pt == nil {
+			continue
+		}
+
+		txResults, err := pt.Parse(block, tx, md)
+		if err != nil || len(txResults) < 1 {
+			continue
+		}
+
+		number := e.dCache.InscriptionNumber.Next()
+		for _, txResult := range txResults {
+			txResult.Number = number
+			e.txResultHandler.UpdateCache(txResult)
+			models = append(models, e.txResultHandler.BuildModel(txResult))
+		}
+	}
+	return models
+}
+
+func modelSummary(models []*devents.DBModelEvent) []string {
+	items := make([]string, 0, len(models)*3)
+	for _, dm := range models {
+		items = append(items, fmt.Sprintf("tx %x %s %s %d", dm.Tx.TxHash, dm.Tx.Op, dm.Tx.Tick, dm.Tx.InscriptionNumber))
+		for _, action := range []devents.DBAction{devents.DBActionCreate, devents.DBActionUpdate} {
+			for _, b := range dm.Balances[action] {
+				items = append(items, fmt.Sprintf("balance %s %d %s %s %s %s", action, b.SID, b.Tick, b.Address, b.Balance, b.Available))
+			}
+		}
+		for _, b := range dm.BalanceTxs {
+			items = append(items, fmt.Sprintf("balance tx %s %s %s %s", b.Tick, b.Address, b.Amount, b.Balance))
+		}
+		for _, s := range dm.InscriptionStats {
+			items = append(items, fmt.Sprintf("stats %d %s %s %d %d", s.SID, s.Tick, s.Minted, s.Holders, s.TxCnt))
+		}
+	}
+	return items
+}
+
+func TestParseTxsDeterministic(t *testing.T) {
+	blocks := parallelTestBlocks()
+
+	seq := newParallelTestExplorer(t, 1)
+	expected := make([]string, 0)
+	for _, block := range blocks {
+		expected = append(expected, modelSummary(sequentialParseTxs(seq, block))...)
+	}
+
+	par := newParallelTestExplorer(t, 8)
+	actual := make([]string, 0)
+	for _, block := range blocks {
+		txs, metas := par.tryFilterTxs(block.Transactions)
+		models, err := par.parseTxs(block, txs, metas)
+		assert.Nil(t, err)
+		actual = append(actual, modelSummary(models)...)
+	}
+
+	assert.NotEmpty(t, expected)
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, seq.dCache.InscriptionNumber.Next(), par.dCache.InscriptionNumber.Next())
+}
+
+func TestInscriptionNumberResume(t *testing.T) {
+	blocks := parallelTestBlocks()
+
+	ref := newParallelTestExplorer(t, 8)
+	expected := make([]string, 0)
+	for _, block := range blocks {
+		txs, metas := ref.tryFilterTxs(block.Transactions)
+		models, err := ref.parseTxs(block, txs, metas)
+		assert.Nil(t, err)
+		expected = append(expected, modelSummary(models)...)
+	}
+
+	// restart after the first block, the sequence is reloaded from the max persisted txs number
+	e := newParallelTestExplorer(t, 8)
+	e.dCache.InscriptionNumber.Resume(-1, false)
+	actual := make([]string, 0)
+	last, found := int64(-1), false
+	for i, block := range blocks {
+		if i > 0 {
+			e.dCache.InscriptionNumber = dcache.NewInscriptionNumber()
+			e.dCache.InscriptionNumber.Resume(last, found)
+		}
+
+		txs, metas := e.tryFilterTxs(block.Transactions)
+		models, err := e.parseTxs(block, txs, metas)
+		assert.Nil(t, err)
+		for _, dm := range models {
+			if dm.Tx != nil && dm.Tx.InscriptionNumber > last {
+				last, found = dm.Tx.InscriptionNumber, true
+			}
+		}
+		actual = append(actual, modelSummary(models)...)
+	}
+
+	assert.NotEmpty(t, expected)
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, ref.dCache.InscriptionNumber.Next(), e.dCache.InscriptionNumber.Next())
+}
@@ -24,326 +24,212 @@ package explorer
 
 import (
 	"context"
-	"fmt"
 	"github.com/btcsuite/btcd/btcjson"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
 	"github.com/uxuycom/indexer/client/btc"
 	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/dcache"
 	"github.com/uxuycom/indexer/devents"
 	"github.com/uxuycom/indexer/model"
-	"github.com/uxuycom/indexer/xyerrors"
-	"github.com/uxuycom/indexer/xylog"
-	"golang.org/x/sync/errgroup"
-	"sync"
+	btcRunes "github.com/uxuycom/indexer/protocol/btc/runes"
+	"github.com/uxuycom/indexer/protocol/types"
+	"sort"
 	"time"
 )
 
-func (e *Explorer) SaveRunesToDb(ctx context.Context) error {
+// handleRunesTx applies the runestone & rune inputs of the tx, returns nil if the tx doesn't touch runes
+func (e *Explorer) handleRunesTx(block *xycommon.RpcBlock, idx int, tx btcjson.TxRawResult) (*devents.DBModelEvent, error) {
+	if e.runes == nil || !e.protocolEnabled(types.RunesProtocol) {
+		return nil, nil
+	}
 
-	client := e.node.(*btc.BClient)
-	runes, err := client.GetRunes(ctx)
+	r, err := e.runes.Index(block.Number.Uint64(), int64(block.Time), uint32(idx), tx)
+	if err != nil || r == nil {
+		return nil, err
+	}
+	return e.buildRunesModel(block, idx, tx, r), nil
+}
+
+// runesOutputFetcher resolves etching commitment outputs from the node
+func (e *Explorer) runesOutputFetcher(txid string, vout uint32) (string, uint64, error) {
+	bClient := e.node.(*btc.BClient)
+	tx, err := bClient.GetRawTransactionVerbose(context.Background(), txid)
 	if err != nil {
-		xylog.Logger.Errorf("OrdinalsClient GetRunes func error,err=%v", err)
-		return err
+		return "", 0, err
 	}
-	dbRunes := make([]model.Runes, 0)
-	for _, r := range runes {
 
-		ru := model.Runes{
-			BlockHeight:  r.BlockHeight,
-			Index:        r.Index,
-			Burned:       r.Burned,
-			Divisibility: r.Divisibility,
-			Etching:      r.Etching,
-			Mints:        r.Mints,
-			Number:       r.Number,
-			Rune:         r.Rune,
-			Spacers:      r.Spacers,
-			Supply:       r.Supply,
-			Symbol:       r.Symbol,
-			Timestamp:    r.Timestamp,
-		}
-		if r.Mint != nil {
-			ru.Deadline = r.Mint.Deadline
-			ru.End = r.Mint.End
-			ru.Limit = r.Mint.Limit
+	scriptType := ""
+	for _, item := range tx.Vout {
+		if item.N == vout {
+			scriptType = item.ScriptPubKey.Type
 		}
-		dbRunes = append(dbRunes, ru)
+	}
 
+	// unconfirmed
+	if tx.BlockHash == "" {
+		return scriptType, 0, nil
 	}
-	err = e.db.SaveRunes(dbRunes)
+
+	header, err := bClient.GetBlockVerbose(context.Background(), tx.BlockHash)
 	if err != nil {
-		xylog.Logger.Errorf("SaveRunes error =%v", err)
+		return "", 0, err
 	}
-	return nil
+	return scriptType, uint64(header.Height), nil
 }
 
-// handleRunesTxs  dispose btc txs and save to db
-func (e *Explorer) handleRunesTxs(block *xycommon.RpcBlock) *xyerrors.InsError {
-
-	startTime := time.Now()
-	defer func() {
-		xylog.Logger.Infof("handle handleRunesTxs use time [%v] block[%v]", time.Since(startTime), block.Number)
-	}()
-	xylog.Logger.Infof("handleRunesTxs begin RpcTxs[%v] len[%v] block[%v]", block.RpcTxs, len(block.RpcTxs), block.Number)
+func (e *Explorer) buildRunesModel(block *xycommon.RpcBlock, idx int, tx btcjson.TxRawResult, r *btcRunes.TxResult) *devents.DBModelEvent {
+	blockTime := time.Unix(int64(block.Time), 0)
+	height := block.Number.Uint64()
+	dm := &devents.DBModelEvent{
+		Runes:        map[devents.DBAction][]*model.Runes{},
+		RuneBalances: map[devents.DBAction][]*model.RuneBalances{},
+	}
 
-	var blockTxs []*devents.DBModelEvent
-	txIdx, btcTxs := e.convertTxIdx(block.RpcTxs)
+	newEvent := func(id, event string, vout int64, address string, amount decimal.Decimal) *model.RuneEvents {
+		return &model.RuneEvents{
+			Chain:       e.config.Chain.ChainName,
+			RuneId:      id,
+			Event:       event,
+			TxHash:      tx.Txid,
+			Vout:        vout,
+			Address:     address,
+			Amount:      amount,
+			BlockHeight: height,
+			CreatedAt:   blockTime,
+		}
+	}
 
-	addressBalances, err := e.getRunesAddressBalanceFromBlock(block.Txs, txIdx, block.Number.Int64())
-	if err != nil {
-		return xyerrors.ErrInternal
+	op := model.RuneEventTransfer
+	tick := ""
+	if r.Etched != nil {
+		op = model.RuneEventEtch
+		tick = r.Etched.Id
+		dm.Runes[devents.DBActionCreate] = []*model.Runes{e.buildRune(r.Etched)}
+		dm.RuneEvents = append(dm.RuneEvents, newEvent(r.Etched.Id, model.RuneEventEtch, -1, "", r.Etched.Premine))
 	}
-	xylog.Logger.Infof("getAddressBalanceFromBlock use time[%v] block[%v]", time.Since(startTime), block.Number)
 
-	startRangTxTime := time.Now()
-	for _, tx := range block.OrdTx {
+	if r.Minted != nil {
+		if tick == "" {
+			op = model.RuneEventMint
+			tick = r.Minted.String()
+		}
+		dm.RuneEvents = append(dm.RuneEvents, newEvent(r.Minted.String(), model.RuneEventMint, -1, "", r.MintAmt))
+	}
 
-		idx, ok := txIdx[tx.TxId]
-		btcTx, _ := btcTxs[tx.TxId]
-		if !ok {
-			xylog.Logger.Errorf("the transaction does not exist on the block for the original node. txid[%s]", tx.TxId)
-			return xyerrors.ErrInternal
+	for _, id := range r.Updated() {
+		if r.Etched != nil && id == r.Etched.Id {
+			continue
 		}
-		for _, event := range tx.Events {
-			if !event.Valid {
-				continue
-			}
-			if event.From == "" || event.To == "" {
-				xylog.Logger.Infof("the from or to address is empty and the transaction is skipped. txid[%s] blockHash[%s]", tx.TxId, block.Hash)
-				continue
-			}
-			xylog.Logger.Infof("binding transaction data. txid[%s] block[%d]", tx.TxId, block.Number)
-			dm, err := e.buildRunesModel(tx.TxId, event, idx, btcTx, block, addressBalances)
-			if err != nil {
-				xylog.Logger.Errorf("handleTxs error. err[%s]", err)
-				return xyerrors.ErrInternal
-			}
-			blockTxs = append(blockTxs, dm)
-			xylog.Logger.Infof("binding transaction data end. txid[%s] block[%d]", tx.TxId, block.Number)
+		if ok, entry := e.dCache.Runes.Get(id); ok {
+			dm.Runes[devents.DBActionUpdate] = append(dm.Runes[devents.DBActionUpdate], e.buildRune(entry))
 		}
 	}
 
-	xylog.Logger.Infof("handleTxs  end. block[%d] use time[%v]", block.Number, time.Since(startRangTxTime))
-	e.writeDBAsync(block, blockTxs)
-	return nil
-}
-
-func (e *Explorer) buildRunesModel(txid string, event *xycommon.OrdBlockEvent, idx int, btcTx btcjson.TxRawResult, block *xycommon.RpcBlock, addressBalances map[string]*xycommon.AddressBalance) (*devents.DBModelEvent, error) {
-
-	//fromOK, _ := e.dCache.Balance.Get(defaultProtocol, event.Tick, event.From)
-	//toOK, _ := e.dCache.Balance.Get(defaultProtocol, event.Tick, event.To)
-	//amount, _ := e.convertAmount(event.Amount, event.Tick)
-	//xylog.Logger.Infof("buildModel txid[%s]", txid)
-	//
-	//startTime := time.Now()
-	//if event.Type == "deploy" {
-	//
-	//	xylog.Logger.Infof("buildModel - deploy- GetInscription txid[%s]", txid)
-	//	ins, err := e.GetInscription(event.InscriptionId)
-	//	xylog.Logger.Infof("buildModel GetInscription end txid[%s]", txid)
-	//	if err != nil {
-	//		return nil, err
-	//	}
-	//	e.updateDeployCache(event.Tick, ins.LimitPerMint, ins.TotalSupply, defaultTickDecimals)
-	//	xylog.Logger.Infof("buildModel- deploy- updateDeployCache end txid[%s]", txid)
-	//} else if event.Type == "mint" {
-	//
-	//	xylog.Logger.Infof("buildModel- mint- updateMintCache end txid[%s]", txid)
-	//	e.updateMintCache(event.Tick, amount, event.To.Address)
-	//	xylog.Logger.Infof("buildModel- mint- updateMintCache end txid[%s]", txid)
-	//
-	//} else if event.Type == "transfer" {
-	//	xylog.Logger.Infof("buildModel- transfer- updateTransferCache end txid[%s]", txid)
-	//	e.updateTransferCache(event.Tick, event.From.Address, event.To.Address, amount)
-	//	xylog.Logger.Infof("buildModel- transfer- updateTransferCache end txid[%s]", txid)
-	//
-	//} else if event.Type == "inscribeTransfer" {
-	//	xylog.Logger.Infof("buildModel- inscribeTransfer- updateInscribeTransferCache end txid[%s]", txid)
-	//	e.updateInscribeTransferCache(event.Tick, event.To.Address, txid, event.InscriptionId, amount)
-	//	xylog.Logger.Infof("buildModel- inscribeTransfer- updateInscribeTransferCache end txid[%s]", txid)
-	//
-	//}
-	//xylog.Logger.Infof("buildModel- buildTx- begin txid[%s] updateCacheUseTime[%v] block[%v]", txid, time.Since(startTime), block.Number)
-	//buildTxStartTime := time.Now()
-	//tx, err := e.buildTx(txid, event, idx, btcTx, block)
-	//if err != nil {
-	//	xylog.Logger.Infof("buildModel- buildTx- err txid[%s], err[%v]", txid, err)
-	//	return nil, err
-	//}
-	//
-	//buildInscriptionStartTime := time.Now()
-	//xylog.Logger.Infof("buildModel- buildInscription- begin txid[%s] buildTxUseTime[%v]", txid, time.Since(buildTxStartTime))
-	//inscription, err := e.buildInscription(txid, event, block)
-	//if err != nil {
-	//	xylog.Logger.Infof("buildModel- buildInscription- err txid[%s],err[%v]", txid, err)
-	//	return nil, err
-	//}
-	//
-	//buildInscriptionStatsStartTime := time.Now()
-	//xylog.Logger.Infof("buildModel- buildInscriptionStats- begin txid[%s] buildInscriptionStartTime[%v] block[%v]", txid, time.Since(buildInscriptionStartTime), block.Number)
-	//inscriptionStats, err := e.buildInscriptionStats(event, block)
-	//if err != nil {
-	//	xylog.Logger.Infof("buildModel- buildInscriptionStats- err txid[%s] err[%v]", txid, err)
-	//	return nil, err
-	//}
-	//
-	//xylog.Logger.Infof("buildModel- buildBalance- begin txid[%s] buildInscriptionStatsStartTime[%v] block[%v]", txid, time.Since(buildInscriptionStatsStartTime), block.Number)
-	//txns, balances, err := e.buildBalance(txid, event, block, fromOK, toOK, addressBalances)
-	//if err != nil {
-	//	xylog.Logger.Infof("buildModel- buildBalance- err txid[%s], err[%v]", txid, err)
-	//	return nil, err
-	//}
-	//
-	//xylog.Logger.Infof("buildModel- buildAddressTx- begin txid[%s]", txid)
-	//addressTxs, err := e.buildAddressTx(txid, event, block)
-	//if err != nil {
-	//	xylog.Logger.Infof("buildModel- buildAddressTx- err txid[%s],err[%v]", txid, err)
-	//	return nil, err
-	//}
-	//
-	//xylog.Logger.Infof("buildModel- buildUTXO- begin txid[%s]", txid)
-	//utxos, err := e.buildUTXO(event, block)
-	//if err != nil {
-	//	xylog.Logger.Infof("buildModel- buildUTXO- err txid[%s] err[%v]", txid, err)
-	//	return nil, err
-	//}
-	//
-	//xylog.Logger.Infof("buildModel- end txid[%s], err[%v] useTime[%v] block[%v]", txid, err, time.Since(startTime), block.Number)
-	//return &devents.DBModelEvent{
-	//	Tx:               tx,
-	//	Inscriptions:     inscription,
-	//	InscriptionStats: inscriptionStats,
-	//	BalanceTxs:       txns,
-	//	Balances:         balances,
-	//	AddressTxs:       addressTxs,
-	//	UTXOs:            utxos,
-	//}, nil
-	return nil, nil
-}
-
-func (e *Explorer) getRunesAddressBalanceFromBlock(txs []*xycommon.Tx, txIdx map[string]int, blockNumber int64) (map[string]*xycommon.AddressBalance, error) {
-
-	addrBalancesMap := e.getAddressFromTx(txs, txIdx)
-	balanceMap := &sync.Map{}
-	g, _ := errgroup.WithContext(context.Background())
-
-	addrChan := make(chan int, 20)
-	for _, v := range addrBalancesMap {
-		addr := v
-		key := fmt.Sprintf("%s_%s", addr.Tick, addr.Address)
-		addrChan <- 1
-		g.Go(func() error {
-			defer func() {
-				<-addrChan
-			}()
-			ordBalance, availableBalance, err := e.GetAddressBalance(addr.Address, addr.Tick)
-			xylog.Logger.Infof("address[%s] tick[%s] ordBalance[%v]  availableBalance[%v] err[%v]", addr.Address, addr.Tick, ordBalance, availableBalance, err)
-			if err != nil {
-				xylog.Logger.Errorf("address[%s] tick[%s] ordBalance[%v]  availableBalance[%v] err[%v]", addr.Address, addr.Tick, ordBalance, availableBalance, err)
-				return err
-			}
-			newAddrBalance := &xycommon.AddressBalance{
-				Address:          addr.Address,
-				OverallBalance:   ordBalance,
-				AvailableBalance: availableBalance,
-				Tick:             addr.Tick,
-			}
-
-			balanceMap.Store(key, newAddrBalance)
-
-			return nil
+	// spent rune outputs
+	from := ""
+	for _, spent := range r.Spent {
+		if from == "" {
+			from = spent.Output.Address
+		}
+		dm.RuneBalances[devents.DBActionUpdate] = append(dm.RuneBalances[devents.DBActionUpdate], &model.RuneBalances{
+			Chain:    e.config.Chain.ChainName,
+			Outpoint: btcRunes.Outpoint(spent.TxHash, spent.Vout),
+			Address:  spent.Output.Address,
+			Status:   model.UTXOStatusSpent,
 		})
 	}
-	if err := g.Wait(); err != nil {
-		return nil, fmt.Errorf("concurrent block scanning failed. err=%s", err)
-	}
 
-	xylog.Logger.Infof("getAddressBalanceFromBlock len:[%v]", len(addrBalancesMap))
-	newBalanceMap := make(map[string]*xycommon.AddressBalance)
-
-	for _, v := range addrBalancesMap {
-		key := fmt.Sprintf("%s_%s", v.Tick, v.Address)
-		xylog.Logger.Infof("getAddressBalanceFromBlock range addressBalances key:[%v]", key)
-		if v, ok := balanceMap.Load(key); ok {
-			xylog.Logger.Infof("getAddressBalanceFromBlock range addressBalances key:[%v] load", key)
-			if vv, ok := v.(*xycommon.AddressBalance); ok {
-				xylog.Logger.Infof("getAddressBalanceFromBlock range addressBalances key:[%v] load address[%s] tick[%s] balance[%v] aval[%v]", key, vv.Address, vv.Tick, vv.OverallBalance, vv.AvailableBalance)
-				newBalanceMap[key] = vv
+	// new rune outputs
+	to := ""
+	for _, output := range r.Outputs {
+		if to == "" {
+			to = output.Address
+		}
+		for _, id := range sortedRuneIds(output.Balances) {
+			if tick == "" {
+				tick = id
 			}
+			amount := output.Balances[id]
+			dm.RuneBalances[devents.DBActionCreate] = append(dm.RuneBalances[devents.DBActionCreate], &model.RuneBalances{
+				Chain:       e.config.Chain.ChainName,
+				RuneId:      id,
+				Outpoint:    btcRunes.Outpoint(tx.Txid, output.Vout),
+				Address:     output.Address,
+				Amount:      amount,
+				Status:      model.UTXOStatusUnspent,
+				BlockHeight: height,
+				CreatedAt:   blockTime,
+			})
+			dm.RuneEvents = append(dm.RuneEvents, newEvent(id, model.RuneEventTransfer, int64(output.Vout), output.Address, amount))
+		}
+	}
+
+	for _, id := range sortedRuneIds(r.Burned) {
+		if tick == "" {
+			op = model.RuneEventBurn
+			tick = id
 		}
+		dm.RuneEvents = append(dm.RuneEvents, newEvent(id, model.RuneEventBurn, -1, "", r.Burned[id]))
 	}
 
-	return newBalanceMap, nil
+	dm.Tx = &model.Transaction{
+		Chain:           e.config.Chain.ChainName,
+		Protocol:        types.RunesProtocol,
+		BlockHeight:     height,
+		PositionInBlock: uint64(idx),
+		BlockTime:       blockTime,
+		TxHash:          common.FromHex(tx.Txid),
+		From:            from,
+		To:              to,
+		Op:              op,
+		Tick:            tick,
+		CreatedAt:       blockTime,
+
+		InscriptionNumber: -1, // runestones are not inscriptions
+	}
+	return dm
 }
 
-func (e *Explorer) getRunesAddressFromTx(txs []btcjson.TxRawResult, txIdx map[string]int) map[string]*xycommon.AddressBalance {
+func (e *Explorer) buildRune(entry *dcache.RuneEntry) *model.Runes {
+	item := &model.Runes{
+		Chain:        e.config.Chain.ChainName,
+		RuneId:       entry.Id,
+		Rune:         entry.Rune,
+		SpacedRune:   btcRunes.SpacedRune(entry.Rune, entry.Spacers),
+		Number:       int64(entry.Number),
+		Divisibility: int(entry.Divisibility),
+		Spacers:      int64(entry.Spacers),
+		Symbol:       entry.Symbol,
+		Etching:      entry.Etching,
+		Premine:      entry.Premine,
+		Supply:       entry.Premine,
+		Burned:       entry.Burned,
+		Mints:        int64(entry.Mints),
+		Turbo:        entry.Turbo,
+		BlockHeight:  int64(entry.Block),
+		Index:        int64(entry.Tx),
+		Timestamp:    entry.Timestamp,
+		CreatedAt:    time.Unix(entry.Timestamp, 0),
+	}
 
-	addrBalances := make(map[string]*xycommon.AddressBalance)
-	//for _, tx := range txs {
-	//	if _, ok := txIdx[tx.Txid]; !ok {
-	//		continue
-	//	}
-	//
-	//	for _, event := range tx.Vin {
-	//		if !event.Valid {
-	//			xylog.Logger.Infof(" !event.Valid getAddressBalanceFromBlock----- from[%s] to[%s]", event.From.Address, event.To.Address)
-	//			continue
-	//		}
-	//
-	//		if event.From.Address == "" || event.To.Address == "" {
-	//			xylog.Logger.Infof("the from or to address is empty and the transaction is skipped. txid[%s]", tx.Txid)
-	//			continue
-	//		}
-	//		// deploy balance not change don't need dispose
-	//		if event.Type == "deploy" {
-	//			continue
-	//		}
-	//		fromKey := fmt.Sprintf("%s_%s", event.Tick, event.From.Address)
-	//		toKey := fmt.Sprintf("%s_%s", event.Tick, event.To.Address)
-	//		addrBalances[fromKey] = &xycommon.AddressBalance{
-	//			Address: event.From.Address,
-	//			Tick:    event.Tick,
-	//		}
-	//		addrBalances[toKey] = &xycommon.AddressBalance{
-	//			Address: event.To.Address,
-	//			Tick:    event.Tick,
-	//		}
-	//	}
-	//}
-	return addrBalances
+	if entry.Terms != nil {
+		item.Cap = entry.Terms.Cap
+		item.Limit = entry.Terms.Amount
+		item.HeightStart = entry.Terms.HeightStart
+		item.HeightEnd = entry.Terms.HeightEnd
+		item.OffsetStart = entry.Terms.OffsetStart
+		item.OffsetEnd = entry.Terms.OffsetEnd
+		item.Supply = entry.Premine.Add(entry.Terms.Amount.Mul(decimal.NewFromInt(item.Mints)))
+	}
+	return item
 }
 
-//
-//func (e *Explorer) buildRunesTx(txId string, idx int, tx btcjson.TxRawResult, block *xycommon.RpcBlock) (*model.Transaction, error) {
-//
-//	amount, err := e.convertAmount(event.Amount, event.Tick)
-//	if err != nil {
-//		return nil, err
-//	}
-//	ins, err := e.GetInscription(event.InscriptionId)
-//	if err != nil {
-//		return nil, err
-//	}
-//
-//	var content string
-//	if ins != nil {
-//		content = ins.Content
-//	}
-//	return &model.Transaction{
-//		Chain:           e.config.Chain.ChainName,
-//		Protocol:        "brc-20",
-//		BlockHeight:     block.Number.Uint64(),
-//		PositionInBlock: uint64(idx),
-//		BlockTime:       time.Unix(int64(block.Time), 0),
-//		TxHash:          common.FromHex(txId),
-//		From:            event.From.Address,
-//		To:              event.To.Address,
-//		Op:              event.Type,
-//		Tick:            strings.ToLower(event.Tick),
-//		Amount:          amount,
-//		Gas:             0,
-//		GasPrice:        0,
-//		CreatedAt:       time.Unix(int64(block.Time), 0),
-//		Content:         content,
-//	}, nil
-//}
+func sortedRuneIds(balances map[string]decimal.Decimal) []string {
+	ids := make([]string, 0, len(balances))
+	for id := range balances {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -26,18 +26,25 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/btcsuite/btcd/btcjson"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/client/beacon"
 	"github.com/uxuycom/indexer/client/xycommon"
 	"github.com/uxuycom/indexer/config"
 	"github.com/uxuycom/indexer/dcache"
 	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/model"
+	"github.com/uxuycom/indexer/protocol"
+	btcRunes "github.com/uxuycom/indexer/protocol/btc/runes"
 	"github.com/uxuycom/indexer/storage"
 	"github.com/uxuycom/indexer/xylog"
 	"golang.org/x/sync/errgroup"
 	"math/big"
 	"os"
 	"runtime/debug"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"syscall"
@@ -55,6 +62,12 @@ type Explorer struct {
 	txResultHandler *devents.TxResultHandler
 	dCache          *dcache.Manager
 	dEvent          *devents.DEvent
+	runes           *btcRunes.Updater
+	btcPrevTxs      func(txids []string) (map[string]*btcjson.TxRawResult, error)
+	balanceChecks   chan *balanceCheck
+	ordHeight       func() (int64, error)
+	ordBalance      func(address, tick string) (decimal.Decimal, decimal.Decimal, error)
+	beacon          *beacon.Client
 	latestBlockNum  atomic.Uint64
 	currentBlockNum atomic.Uint64
 }
@@ -77,6 +90,20 @@ func NewExplorer(rpcClient xycommon.IRPCClient, dbc *storage.DBClient, cfg *conf
 
 		dEvent: dEvent,
 	}
+
+	if cfg.Chain.ChainGroup == model.BtcChainGroup {
+		exp.runes = btcRunes.NewUpdater(dCache, cfg.Chain.Testnet, exp.runesOutputFetcher)
+		exp.btcPrevTxs = exp.btcPrevTxsFetcher
+		if cfg.Chain.BalanceCrossCheck {
+			exp.balanceChecks = make(chan *balanceCheck, 1)
+			exp.ordHeight = exp.ordHeightFetcher
+			exp.ordBalance = exp.GetAddressBalance
+		}
+	} else if cfg.Chain.BeaconRpc != "" {
+		exp.beacon = beacon.NewClient(cfg.Chain.BeaconRpc)
+	} else if protocol.BlobsConfigured() {
+		xylog.Logger.Warnf("blobs rule configured without chain.beacon_rpc, blob inscriptions are not indexed")
+	}
 	return exp
 }
 
@@ -106,6 +133,11 @@ func (e *Explorer) Scan() {
 	// update latest block number
 	go e.updateBlockLatestNumberTiming()
 
+	// ord balance cross checks, one at a time
+	if e.balanceChecks != nil {
+		go e.crossCheckBalancesTiming()
+	}
+
 	// set start block number
 	e.currentBlockNum.Store(startBlock)
 
@@ -192,34 +224,48 @@ func (e *Explorer) syncLatestBlockNumber() error {
 }
 
 func (e *Explorer) scanLogs(startBlock, endBlock uint64, result chan map[string][]xycommon.RpcLog) {
-	if e.config.Filters == nil || len(e.config.Filters.EventTopics) <= 0 {
+	// filter Logs, configured topics, marketplace, bridge, wrapper & smart account events
+	topics := [][]common.Hash{append(protocol.MarketplaceTopics(), protocol.BridgeTopics()...)}
+	topics[0] = append(topics[0], protocol.WrapperTopics()...)
+	topics[0] = append(topics[0], protocol.SmartAccountTopics()...)
+	if e.config.Filters != nil {
+		for _, ts := range e.config.Filters.EventTopics {
+			topics[0] = append(topics[0], common.HexToHash(ts))
+		}
+	}
+
+	if len(topics[0]) <= 0 {
 		result <- nil
 		return
 	}
 
-	// filter Logs
-	topics := [][]common.Hash{{}}
-	topics[0] = make([]common.Hash, 0, len(e.config.Filters.EventTopics))
-	for _, ts := range e.config.Filters.EventTopics {
-		topics[0] = append(topics[0], common.HexToHash(ts))
-	}
+	// split the blocks into the max eth_getLogs range of the chain
+	logs := make([]xycommon.RpcLog, 0)
+	for from := startBlock; from <= endBlock; {
+		to := endBlock
+		if maxRange := e.config.Chain.MaxLogsRange; maxRange > 0 && to-from+1 > maxRange {
+			to = from + maxRange - 1
+		}
 
-	retry := 0
-DoFilter:
-	query := ethereum.FilterQuery{
-		Topics:    topics,
-		FromBlock: new(big.Int).SetUint64(startBlock),
-		ToBlock:   new(big.Int).SetUint64(endBlock),
-	}
-	logs, err := e.node.FilterLogs(e.ctx, query)
-	if err != nil {
-		xylog.Logger.Errorf("rpc FilterLogs call err:%v, retry[%d]", err, retry)
-		retry++
-		if retry > 10 {
-			result <- nil
-			return
+		retry := 0
+	DoFilter:
+		query := ethereum.FilterQuery{
+			Topics:    topics,
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(to),
 		}
-		goto DoFilter
+		items, err := e.node.FilterLogs(e.ctx, query)
+		if err != nil {
+			xylog.Logger.Errorf("rpc FilterLogs call err:%v, retry[%d]", err, retry)
+			retry++
+			if retry > 10 {
+				result <- nil
+				return
+			}
+			goto DoFilter
+		}
+		logs = append(logs, items...)
+		from = to + 1
 	}
 
 	groupLogs := make(map[string][]xycommon.RpcLog, 200)
@@ -255,6 +301,10 @@ func (e *Explorer) batchScan(startBlock, endBlock uint64) error {
 				xylog.Logger.Errorf("scan call rpc BlockByNumber[%d], err=%s", blockNum, err)
 				return err
 			}
+			if err = e.attachBlobs(ctx, block); err != nil {
+				xylog.Logger.Errorf("scan blobs of block[%d], err=%s", blockNum, err)
+				return err
+			}
 			blockMap.Store(blockNum, block)
 			return nil
 		})
@@ -284,6 +334,44 @@ func (e *Explorer) batchScan(startBlock, endBlock uint64) error {
 	return nil
 }
 
+// attachBlobs fetches the blobs of the block type-3 txs from the beacon node once the blobs rule is active,
+// txs whose calldata is an inscription are skipped as calldata takes precedence
+func (e *Explorer) attachBlobs(ctx context.Context, block *xycommon.RpcBlock) error {
+	if e.beacon == nil || block == nil || block.Number == nil || !protocol.BlobsActive(block.Number.Uint64()) {
+		return nil
+	}
+
+	txs := make([]*xycommon.RpcTransaction, 0)
+	hashes := make([]string, 0)
+	for _, tx := range block.Transactions {
+		if len(tx.BlobVersionedHashes) == 0 {
+			continue
+		}
+		if md, _ := protocol.ParseMetaData(e.config.Chain.ChainName, tx); md != nil {
+			continue
+		}
+		txs = append(txs, tx)
+		hashes = append(hashes, tx.BlobVersionedHashes...)
+	}
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	// beacon nodes prune sidecars after the retention window, the block is retried until an archive node serves them
+	blobs, err := e.beacon.Blobs(ctx, block.Time, hashes)
+	if err != nil {
+		return fmt.Errorf("%v, blocks beyond the blob retention window need an archive chain.beacon_rpc", err)
+	}
+
+	for _, tx := range txs {
+		tx.Blobs = make([][]byte, 0, len(tx.BlobVersionedHashes))
+		for _, h := range tx.BlobVersionedHashes {
+			tx.Blobs = append(tx.Blobs, blobs[strings.ToLower(h)])
+		}
+	}
+	return nil
+}
+
 func (e *Explorer) Stop() {
 	e.cancel()
 }
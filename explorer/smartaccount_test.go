@@ -0,0 +1,131 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package explorer
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol"
+	"github.com/uxuycom/indexer/protocol/smartaccount"
+	"math/big"
+	"testing"
+)
+
+type testUserOperation struct {
+	Sender               common.Address
+	Nonce                *big.Int
+	InitCode             []byte
+	CallData             []byte
+	CallGasLimit         *big.Int
+	VerificationGasLimit *big.Int
+	PreVerificationGas   *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	PaymasterAndData     []byte
+	Signature            []byte
+}
+
+func TestSmartAccountSubIndex(t *testing.T) {
+	cfg := &config.Config{
+		Chain: config.ChainConfig{ChainName: "eth", ChainGroup: "evm", Rules: []*config.RuleActivation{
+			{Protocol: "asc-20", Rule: "smart_account", Value: "true"},
+		}},
+	}
+	cache := dcache.NewMemoryManager(cfg.Chain.ChainName)
+	assert.NoError(t, protocol.InitProtocols(cfg, cache))
+	e := &Explorer{
+		config:          cfg,
+		dCache:          cache,
+		txResultHandler: devents.NewTxResultHandler(cache),
+	}
+
+	pack := func(name string, args ...interface{}) []byte {
+		data, err := smartaccount.ParsedABI.Pack(name, args...)
+		assert.NoError(t, err)
+		return data
+	}
+	userOp := func(sender common.Address, nonce int64, callData []byte) (testUserOperation, xycommon.RpcLog) {
+		event := smartaccount.ParsedABI.Events["UserOperationEvent"]
+		data, err := event.Inputs.NonIndexed().Pack(big.NewInt(nonce), true, big.NewInt(1), big.NewInt(1))
+		assert.NoError(t, err)
+		zero := big.NewInt(0)
+		return testUserOperation{
+			Sender: sender, Nonce: big.NewInt(nonce), CallData: callData, CallGasLimit: zero, VerificationGasLimit: zero,
+			PreVerificationGas: zero, MaxFeePerGas: zero, MaxPriorityFeePerGas: zero,
+		}, xycommon.RpcLog{
+			Address: common.HexToAddress(smartaccount.EntryPointV06),
+			Topics:  []common.Hash{event.ID, {}, common.BytesToHash(sender.Bytes()), {}},
+			Data:    data,
+		}
+	}
+
+	var (
+		account  = common.HexToAddress("0x00000000000000000000000000000000000000a1")
+		account2 = common.HexToAddress("0x00000000000000000000000000000000000000a2")
+		mint     = []byte(`data:,{"p":"asc-20","op":"mint","tick":"avav","amt":"1000"}`)
+	)
+	op1, log1 := userOp(account, 1, pack("execute", account, big.NewInt(0), mint))
+	op2, log2 := userOp(account2, 2, pack("executeBatch", []common.Address{account2, account2}, [][]byte{mint, mint}))
+
+	block := &xycommon.RpcBlock{Number: big.NewInt(100), Time: 1700000000, Transactions: []*xycommon.RpcTransaction{
+		{
+			BlockNumber: big.NewInt(100), TxIndex: big.NewInt(0), Hash: fmt.Sprintf("0x%064x", 1),
+			From: account.String(), To: account.String(), Gas: big.NewInt(0), GasPrice: big.NewInt(0),
+			Input: "0x" + hex.EncodeToString([]byte(`data:,{"p":"asc-20","op":"deploy","tick":"avav","max":"1000000","lim":"1000"}`)),
+		},
+		{
+			BlockNumber: big.NewInt(100), TxIndex: big.NewInt(1), Hash: fmt.Sprintf("0x%064x", 2),
+			From: account.String(), To: smartaccount.EntryPointV06, Gas: big.NewInt(0), GasPrice: big.NewInt(0),
+			Input:  hexutil.Encode(pack("handleOps", []testUserOperation{op1, op2}, account)),
+			Events: []xycommon.RpcLog{log1, log2},
+		},
+	}}
+
+	// every inner call is a tx of its own, numbered in sub index order
+	txs, metas := e.tryFilterTxs(e.extractTxsFromBlock(block))
+	models, err := e.parseTxs(block, txs, metas)
+	assert.Nil(t, err)
+	if !assert.Len(t, models, 4) {
+		return
+	}
+	for idx, dm := range models {
+		assert.Equal(t, int64(idx), dm.Tx.InscriptionNumber)
+	}
+	assert.Equal(t, []int{0, 1, 2}, []int{models[1].Tx.SubIndex, models[2].Tx.SubIndex, models[3].Tx.SubIndex})
+	assert.Equal(t, []string{account.String(), account2.String(), account2.String()},
+		[]string{models[1].Tx.From, models[2].Tx.From, models[3].Tx.From})
+
+	_, balance := cache.Balance.Get("asc-20", "avav", account2.String())
+	assert.Equal(t, "2000", balance.Overall.String())
+
+	// one txs row per inner call
+	dmf := devents.BuildDBUpdateModel([]*devents.Event{{Chain: cfg.Chain.ChainName, Items: models}})
+	assert.Len(t, dmf.Txs, 4)
+}
@@ -110,6 +110,19 @@ type InscriptionInfo struct {
 	Holders      uint64 `json:"holders"`
 	TxCnt        uint64 `json:"tx_cnt"`
 	Progress     string `json:"progress"`
+
+	InscriptionNumber int64  `json:"inscription_number"` // deploy inscription number
+	LastSN            uint64 `json:"last_sn"`            // last mint sequence number
+	Burned            string `json:"burned"`
+	Bridged           string `json:"bridged"`            // bridged in from other chains
+	CirculatingSupply string `json:"circulating_supply"` // minted + bridged - burned
+
+	// deploy params, 0 means unbounded
+	StartBlock         uint64 `json:"start_block"`
+	EndBlock           uint64 `json:"end_block"`
+	MaxMintsPerAddress uint64 `json:"max_mints_per_address"`
+	MaxMintsPerBlock   uint64 `json:"max_mints_per_block"`
+	DeployerOnly       bool   `json:"deployer_only"`
 }
 
 type ChainInfo struct {
@@ -206,6 +219,8 @@ type BalanceInfo struct {
 	Tick         string `json:"tick"`
 	Address      string `json:"address"`
 	Balance      string `json:"balance"`
+	Available    string `json:"available"`
+	Locked       string `json:"locked"` // balance - available, frozen or inscribed as transferable
 	DeployHash   string `json:"deploy_hash"`
 	TransferType int8   `json:"transfer_type"`
 }
@@ -227,6 +242,7 @@ type BalanceBrief struct {
 	Utxos        []*UTXOBrief `json:"utxos,omitempty"`
 	DeployHash   string       `json:"deploy_hash"`
 	Available    string       `json:"available"`
+	Locked       string       `json:"locked"` // balance - available, frozen or inscribed as transferable
 }
 
 type UTXOBrief struct {
@@ -329,6 +345,9 @@ type TransactionResponse struct {
 	Status          int8            `json:"status"`            // tx status
 	CreatedAt       time.Time       `json:"created_at" `
 	UpdatedAt       time.Time       `json:"updated_at"`
+
+	InscriptionNumber int64  `json:"inscription_number"` // chain-wide inscription number
+	MintSN            uint64 `json:"mint_sn"`            // per-tick mint sequence number
 }
 
 type GetTxByHashResponse struct {
@@ -338,6 +357,18 @@ type GetTxByHashResponse struct {
 	Address          *model.AddressTxs    `json:"address,omitempty"`
 	InscriptionsData *InscriptionsData    `json:"data,omitempty"`
 }
+type IndsGetTransactionByNumberCmd struct {
+	Chain  string
+	Number int64 // chain-wide inscription number
+}
+
+type IndsGetMintBySNCmd struct {
+	Chain    string
+	Protocol string
+	Tick     string
+	SN       uint64 // per-tick mint sequence number
+}
+
 type GetAllChainCmd struct {
 	Chains []string
 }
@@ -361,6 +392,337 @@ type InscriptionsData struct {
 	Amount   decimal.Decimal `json:"amt"`
 }
 
+type IndsGetRunesCmd struct {
+	Limit    int    `json:"limit"`
+	Offset   int    `json:"offset"`
+	Chain    string `json:"chain"`
+	Keyword  string `json:"keyword"`
+	SortMode int    `json:"sort_mode"`
+}
+
+type IndsGetRuneCmd struct {
+	Chain string
+	Rune  string // rune id (block:tx), rune name or spaced rune name
+}
+
+type IndsGetRuneHoldersCmd struct {
+	Limit    int
+	Offset   int
+	Chain    string
+	Rune     string
+	SortMode int
+}
+
+type IndsGetRuneBalancesByAddressCmd struct {
+	Chain   string
+	Address string
+}
+
+type IndsGetRuneUtxosByAddressCmd struct {
+	Limit   int
+	Offset  int
+	Chain   string
+	Address string
+	Rune    string
+}
+
+type RuneTerms struct {
+	Amount      string  `json:"amount"`
+	Cap         string  `json:"cap"`
+	HeightStart *uint64 `json:"height_start"`
+	HeightEnd   *uint64 `json:"height_end"`
+	OffsetStart *uint64 `json:"offset_start"`
+	OffsetEnd   *uint64 `json:"offset_end"`
+}
+
+type RuneInfo struct {
+	Chain        string     `json:"chain"`
+	RuneId       string     `json:"rune_id"`
+	Rune         string     `json:"rune"`
+	SpacedRune   string     `json:"spaced_rune"`
+	Number       int64      `json:"number"`
+	Symbol       string     `json:"symbol"`
+	Divisibility int        `json:"divisibility"`
+	Etching      string     `json:"etching"`
+	Premine      string     `json:"premine"`
+	Supply       string     `json:"supply"`
+	MaxSupply    string     `json:"max_supply"`
+	Burned       string     `json:"burned"`
+	Mints        int64      `json:"mints"`
+	Progress     string     `json:"progress"` // mints / cap
+	Terms        *RuneTerms `json:"terms,omitempty"`
+	Turbo        bool       `json:"turbo"`
+	BlockHeight  int64      `json:"block_height"`
+	Timestamp    int64      `json:"timestamp"`
+}
+
+type RuneHolder struct {
+	RuneId     string `json:"rune_id"`
+	SpacedRune string `json:"spaced_rune"`
+	Symbol     string `json:"symbol"`
+	Address    string `json:"address"`
+	Balance    string `json:"balance"`
+	Utxos      int64  `json:"utxos"`
+}
+
+type RuneUtxo struct {
+	RuneId      string `json:"rune_id"`
+	SpacedRune  string `json:"spaced_rune"`
+	Outpoint    string `json:"outpoint"`
+	Address     string `json:"address"`
+	Balance     string `json:"balance"`
+	BlockHeight uint64 `json:"block_height"`
+}
+
+type FindRunesResponse struct {
+	Runes  interface{} `json:"runes"`
+	Total  int64       `json:"total"`
+	Limit  int         `json:"limit"`
+	Offset int         `json:"offset"`
+}
+
+type FindRuneHoldersResponse struct {
+	Rune    *RuneInfo   `json:"rune"`
+	Holders interface{} `json:"holders"`
+	Total   int64       `json:"total"`
+	Limit   int         `json:"limit"`
+	Offset  int         `json:"offset"`
+}
+
+type FindRuneBalancesResponse struct {
+	Address  string      `json:"address"`
+	Balances interface{} `json:"balances"`
+}
+
+type FindRuneUtxosResponse struct {
+	Utxos  interface{} `json:"utxos"`
+	Total  int64       `json:"total"`
+	Limit  int         `json:"limit"`
+	Offset int         `json:"offset"`
+}
+
+type IndsGetListingsCmd struct {
+	Limit    int
+	Offset   int
+	Chain    string
+	Protocol string
+	Tick     string
+	Seller   string
+	Status   string // active, cancelled or filled, defaults to active
+}
+
+type IndsGetListingFillsCmd struct {
+	Limit    int
+	Offset   int
+	Chain    string
+	Protocol string
+	Tick     string
+	Address  string // seller or buyer
+}
+
+type ListingInfo struct {
+	Chain          string `json:"chain"`
+	Protocol       string `json:"protocol"`
+	Tick           string `json:"tick"`
+	ListId         string `json:"list_id"`
+	Marketplace    string `json:"marketplace"`
+	Seller         string `json:"seller"`
+	Buyer          string `json:"buyer"`
+	Amount         string `json:"amount"`
+	Price          string `json:"price"`
+	UnitPrice      string `json:"unit_price"`
+	Status         string `json:"status"`
+	ListTxHash     string `json:"list_tx_hash"`
+	CloseTxHash    string `json:"close_tx_hash"`
+	ListBlock      uint64 `json:"list_block"`
+	CloseBlock     uint64 `json:"close_block"`
+	ListTimestamp  int64  `json:"list_timestamp"`
+	CloseTimestamp int64  `json:"close_timestamp"`
+}
+
+type FindListingsResponse struct {
+	Listings interface{} `json:"listings"`
+	Total    int64       `json:"total"`
+	Limit    int         `json:"limit"`
+	Offset   int         `json:"offset"`
+}
+
+type IndsGetBridgeTransfersCmd struct {
+	Limit    int
+	Offset   int
+	Chain    string // source or destination chain
+	Protocol string
+	Tick     string
+	Address  string // sender or recipient
+	Status   string // pending or settled, all by default
+}
+
+type IndsGetBridgeTransferCmd struct {
+	Bridge string
+	Id     string
+}
+
+type IndsGetBridgeLockedCmd struct {
+	Chain    string
+	Protocol string
+	Tick     string
+}
+
+type BridgeTransferInfo struct {
+	Bridge          string `json:"bridge"`
+	Id              string `json:"id"`
+	Protocol        string `json:"protocol"`
+	Tick            string `json:"tick"`
+	Amount          string `json:"amount"`
+	Sender          string `json:"sender"`
+	Recipient       string `json:"recipient"`
+	SourceChain     string `json:"source_chain"`
+	SourceTxHash    string `json:"source_tx_hash"`
+	SourceBlock     uint64 `json:"source_block"`
+	SourceTimestamp int64  `json:"source_timestamp"`
+	DestChain       string `json:"dest_chain"`
+	DestTxHash      string `json:"dest_tx_hash"`
+	DestBlock       uint64 `json:"dest_block"`
+	DestTimestamp   int64  `json:"dest_timestamp"`
+	SettleType      string `json:"settle_type"` // release or mint
+	Status          string `json:"status"`
+}
+
+type FindBridgeTransfersResponse struct {
+	Transfers interface{} `json:"transfers"`
+	Total     int64       `json:"total"`
+	Limit     int         `json:"limit"`
+	Offset    int         `json:"offset"`
+}
+
+// BridgeLockedInfo tokens of the tick locked in the bridge contracts of the chain
+type BridgeLockedInfo struct {
+	Chain     string              `json:"chain"`
+	Protocol  string              `json:"protocol"`
+	Tick      string              `json:"tick"`
+	Locked    string              `json:"locked"`
+	Contracts []*BridgeLockedItem `json:"contracts"`
+}
+
+type BridgeLockedItem struct {
+	Contract string `json:"contract"`
+	Amount   string `json:"amount"`
+}
+
+type IndsGetWrappedSupplyCmd struct {
+	Chain    string
+	Protocol string
+	Tick     string
+}
+
+// WrappedSupplyInfo tick supply wrapped into erc-20 contracts, diverged if any wrapper supply differs from its locked balance
+type WrappedSupplyInfo struct {
+	Chain    string         `json:"chain"`
+	Protocol string         `json:"protocol"`
+	Tick     string         `json:"tick"`
+	Supply   string         `json:"supply"`
+	Locked   string         `json:"locked"`
+	Diverged bool           `json:"diverged"`
+	Wrappers []*WrapperInfo `json:"wrappers"`
+}
+
+type WrapperInfo struct {
+	Name        string `json:"name"`
+	Address     string `json:"address"`
+	Contract    string `json:"contract"`
+	Supply      string `json:"supply"`
+	Locked      string `json:"locked"`
+	Diverged    bool   `json:"diverged"`
+	BlockHeight uint64 `json:"block_height"`
+}
+
+type IndsGetTickCandlesCmd struct {
+	Chain    string
+	Protocol string
+	Tick     string
+	Period   string // 1m, 1h or 1d
+	Start    int64  // unix timestamp, inclusive
+	End      int64  // unix timestamp, exclusive
+	Limit    int
+}
+
+type IndsGetTickMarketStatsCmd struct {
+	Chain    string
+	Protocol string
+	Tick     string
+}
+
+type TickCandle struct {
+	OpenTime int64  `json:"open_time"`
+	Open     string `json:"open"`
+	High     string `json:"high"`
+	Low      string `json:"low"`
+	Close    string `json:"close"`
+	Volume   string `json:"volume"`
+	Turnover string `json:"turnover"`
+	Trades   uint64 `json:"trades"`
+}
+
+type FindTickCandlesResponse struct {
+	Chain    string      `json:"chain"`
+	Protocol string      `json:"protocol"`
+	Tick     string      `json:"tick"`
+	Period   string      `json:"period"`
+	Candles  interface{} `json:"candles"`
+}
+
+// TickMarketStats prices are in wei per token, 24h values cover the 24 hours before the latest indexed block
+type TickMarketStats struct {
+	Chain          string `json:"chain"`
+	Protocol       string `json:"protocol"`
+	Tick           string `json:"tick"`
+	LastPrice      string `json:"last_price"`
+	PriceChange24h string `json:"price_change_24h"` // percent
+	FloorPrice24h  string `json:"floor_price_24h"`  // lowest fill price
+	HighPrice24h   string `json:"high_price_24h"`
+	Volume24h      string `json:"volume_24h"`
+	Turnover24h    string `json:"turnover_24h"`
+	Trades24h      uint64 `json:"trades_24h"`
+	Minted         string `json:"minted"`
+	MarketCap      string `json:"market_cap"` // last price * minted
+}
+
+type IndsGetInscriptionContentCmd struct {
+	Chain string
+	Id    string // creation tx hash
+}
+
+type IndsGetInscriptionsByAddressCmd struct {
+	Limit   int
+	Offset  int
+	Chain   string
+	Address string // current owner
+}
+
+type InscriptionContentInfo struct {
+	Chain         string `json:"chain"`
+	Protocol      string `json:"protocol"` // token protocol, empty for ethscriptions
+	Id            string `json:"id"`
+	Number        uint64 `json:"number"`
+	Creator       string `json:"creator"`
+	Owner         string `json:"owner"`
+	PreviousOwner string `json:"previous_owner"`
+	ContentType   string `json:"content_type"`
+	ContentSize   int    `json:"content_size"`
+	ContentSha256 string `json:"content_sha256"`
+	Content       []byte `json:"content,omitempty"` // base64 encoded, only returned by id
+	BlockHeight   uint64 `json:"block_height"`
+	CreatedAt     int64  `json:"created_at"`
+}
+
+type FindInscriptionsByAddressResponse struct {
+	Address      string      `json:"address"`
+	Inscriptions interface{} `json:"inscriptions"`
+	Total        int64       `json:"total"`
+	Limit        int         `json:"limit"`
+	Offset       int         `json:"offset"`
+}
+
 func init() {
 	// No special flags for commands in this file.
 	flags := UsageFlag(0)
@@ -399,5 +761,22 @@ func init() {
 	MustRegisterCmd("inds_addChainStatFromTxsByDay", (*AddChainStatFromTxsByDayCmd)(nil), flags)
 	MustRegisterCmd("inds_allChainStat", (*ChainStatCmd)(nil), flags)
 	MustRegisterCmd("inds_allSearch", (*IndsSearchCmd)(nil), flags)
+	MustRegisterCmd("inds_getRunes", (*IndsGetRunesCmd)(nil), flags)
+	MustRegisterCmd("inds_getRune", (*IndsGetRuneCmd)(nil), flags)
+	MustRegisterCmd("inds_getRuneHolders", (*IndsGetRuneHoldersCmd)(nil), flags)
+	MustRegisterCmd("inds_getRuneBalancesByAddress", (*IndsGetRuneBalancesByAddressCmd)(nil), flags)
+	MustRegisterCmd("inds_getRuneUtxosByAddress", (*IndsGetRuneUtxosByAddressCmd)(nil), flags)
+	MustRegisterCmd("inds_getListings", (*IndsGetListingsCmd)(nil), flags)
+	MustRegisterCmd("inds_getListingFills", (*IndsGetListingFillsCmd)(nil), flags)
+	MustRegisterCmd("inds_getTickCandles", (*IndsGetTickCandlesCmd)(nil), flags)
+	MustRegisterCmd("inds_getTickMarketStats", (*IndsGetTickMarketStatsCmd)(nil), flags)
+	MustRegisterCmd("inds_getInscriptionContent", (*IndsGetInscriptionContentCmd)(nil), flags)
+	MustRegisterCmd("inds_getInscriptionsByAddress", (*IndsGetInscriptionsByAddressCmd)(nil), flags)
+	MustRegisterCmd("inds_getTransactionByNumber", (*IndsGetTransactionByNumberCmd)(nil), flags)
+	MustRegisterCmd("inds_getMintBySN", (*IndsGetMintBySNCmd)(nil), flags)
+	MustRegisterCmd("inds_getBridgeTransfers", (*IndsGetBridgeTransfersCmd)(nil), flags)
+	MustRegisterCmd("inds_getBridgeTransfer", (*IndsGetBridgeTransferCmd)(nil), flags)
+	MustRegisterCmd("inds_getBridgeLocked", (*IndsGetBridgeLockedCmd)(nil), flags)
+	MustRegisterCmd("inds_getWrappedSupply", (*IndsGetWrappedSupplyCmd)(nil), flags)
 
 }
@@ -43,6 +43,23 @@ var rpcHandlersBeforeInitV2 = map[string]commandHandler{
 	"inds_addChainStatFromTxsByDay":  indsAddChainStatFromTxsByDay,
 	"inds_allChainStat":              indsAllChainStat,
 	"inds_allSearch":                 indsAllSearch,
+	"inds_getRunes":                  indsGetRunes,
+	"inds_getRune":                   indsGetRune,
+	"inds_getRuneHolders":            indsGetRuneHolders,
+	"inds_getRuneBalancesByAddress":  indsGetRuneBalancesByAddress,
+	"inds_getRuneUtxosByAddress":     indsGetRuneUtxosByAddress,
+	"inds_getListings":               indsGetListings,
+	"inds_getListingFills":           indsGetListingFills,
+	"inds_getTickCandles":            indsGetTickCandles,
+	"inds_getTickMarketStats":        indsGetTickMarketStats,
+	"inds_getInscriptionContent":     indsGetInscriptionContent,
+	"inds_getInscriptionsByAddress":  indsGetInscriptionsByAddress,
+	"inds_getTransactionByNumber":    indsGetTransactionByNumber,
+	"inds_getMintBySN":               indsGetMintBySN,
+	"inds_getBridgeTransfers":        indsGetBridgeTransfers,
+	"inds_getBridgeTransfer":         indsGetBridgeTransfer,
+	"inds_getBridgeLocked":           indsGetBridgeLocked,
+	"inds_getWrappedSupply":          indsGetWrappedSupply,
 }
 
 func indsGetAllChains(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
@@ -258,3 +275,173 @@ func indsAllSearch(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (in
 	return svr.AllSearch(req.Keyword, req.Chain)
 
 }
+
+func indsGetRunes(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	req, ok := cmd.(*IndsGetRunesCmd)
+	if !ok {
+		return ErrRPCInvalidParams, errors.New("invalid params")
+	}
+	xylog.Logger.Infof("get runes cmd params:%v", req)
+	svr := NewService(s)
+	return svr.GetRunes(req.Limit, req.Offset, req.Chain, req.Keyword, req.SortMode)
+}
+
+func indsGetRune(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	req, ok := cmd.(*IndsGetRuneCmd)
+	if !ok {
+		return ErrRPCInvalidParams, errors.New("invalid params")
+	}
+	xylog.Logger.Infof("get rune cmd params:%v", req)
+	svr := NewService(s)
+	return svr.GetRune(req.Chain, req.Rune)
+}
+
+func indsGetRuneHolders(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	req, ok := cmd.(*IndsGetRuneHoldersCmd)
+	if !ok {
+		return ErrRPCInvalidParams, errors.New("invalid params")
+	}
+	xylog.Logger.Infof("get rune holders cmd params:%v", req)
+	svr := NewService(s)
+	return svr.GetRuneHolders(req.Limit, req.Offset, req.Chain, req.Rune, req.SortMode)
+}
+
+func indsGetRuneBalancesByAddress(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	req, ok := cmd.(*IndsGetRuneBalancesByAddressCmd)
+	if !ok {
+		return ErrRPCInvalidParams, errors.New("invalid params")
+	}
+	xylog.Logger.Infof("get rune balances cmd params:%v", req)
+	svr := NewService(s)
+	return svr.GetRuneBalancesByAddress(req.Chain, req.Address)
+}
+
+func indsGetRuneUtxosByAddress(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	req, ok := cmd.(*IndsGetRuneUtxosByAddressCmd)
+	if !ok {
+		return ErrRPCInvalidParams, errors.New("invalid params")
+	}
+	xylog.Logger.Infof("get rune utxos cmd params:%v", req)
+	svr := NewService(s)
+	return svr.GetRuneUtxosByAddress(req.Limit, req.Offset, req.Chain, req.Address, req.Rune)
+}
+
+func indsGetListings(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	req, ok := cmd.(*IndsGetListingsCmd)
+	if !ok {
+		return ErrRPCInvalidParams, errors.New("invalid params")
+	}
+	xylog.Logger.Infof("get listings cmd params:%v", req)
+	svr := NewService(s)
+	return svr.GetListings(req.Limit, req.Offset, req.Chain, req.Protocol, req.Tick, req.Seller, req.Status)
+}
+
+func indsGetListingFills(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	req, ok := cmd.(*IndsGetListingFillsCmd)
+	if !ok {
+		return ErrRPCInvalidParams, errors.New("invalid params")
+	}
+	xylog.Logger.Infof("get listing fills cmd params:%v", req)
+	svr := NewService(s)
+	return svr.GetListingFills(req.Limit, req.Offset, req.Chain, req.Protocol, req.Tick, req.Address)
+}
+
+func indsGetTickCandles(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	req, ok := cmd.(*IndsGetTickCandlesCmd)
+	if !ok {
+		return ErrRPCInvalidParams, errors.New("invalid params")
+	}
+	xylog.Logger.Infof("get tick candles cmd params:%v", req)
+	svr := NewService(s)
+	return svr.GetTickCandles(req.Chain, req.Protocol, req.Tick, req.Period, req.Start, req.End, req.Limit)
+}
+
+func indsGetTickMarketStats(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	req, ok := cmd.(*IndsGetTickMarketStatsCmd)
+	if !ok {
+		return ErrRPCInvalidParams, errors.New("invalid params")
+	}
+	xylog.Logger.Infof("get tick market stats cmd params:%v", req)
+	svr := NewService(s)
+	return svr.GetTickMarketStats(req.Chain, req.Protocol, req.Tick)
+}
+
+func indsGetInscriptionContent(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	req, ok := cmd.(*IndsGetInscriptionContentCmd)
+	if !ok {
+		return ErrRPCInvalidParams, errors.New("invalid params")
+	}
+	xylog.Logger.Infof("get inscription content cmd params:%v", req)
+	svr := NewService(s)
+	return svr.GetInscriptionContent(req.Chain, req.Id)
+}
+
+func indsGetInscriptionsByAddress(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	req, ok := cmd.(*IndsGetInscriptionsByAddressCmd)
+	if !ok {
+		return ErrRPCInvalidParams, errors.New("invalid params")
+	}
+	xylog.Logger.Infof("get inscriptions by address cmd params:%v", req)
+	svr := NewService(s)
+	return svr.GetInscriptionsByAddress(req.Limit, req.Offset, req.Chain, req.Address)
+}
+
+func indsGetTransactionByNumber(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	req, ok := cmd.(*IndsGetTransactionByNumberCmd)
+	if !ok {
+		return ErrRPCInvalidParams, errors.New("invalid params")
+	}
+	xylog.Logger.Infof("get transaction by number cmd params:%v", req)
+	svr := NewService(s)
+	return svr.GetTxByNumber(req.Chain, req.Number)
+}
+
+func indsGetMintBySN(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	req, ok := cmd.(*IndsGetMintBySNCmd)
+	if !ok {
+		return ErrRPCInvalidParams, errors.New("invalid params")
+	}
+	xylog.Logger.Infof("get mint by sn cmd params:%v", req)
+	svr := NewService(s)
+	return svr.GetMintBySN(req.Chain, req.Protocol, req.Tick, req.SN)
+}
+
+func indsGetBridgeTransfers(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	req, ok := cmd.(*IndsGetBridgeTransfersCmd)
+	if !ok {
+		return ErrRPCInvalidParams, errors.New("invalid params")
+	}
+	xylog.Logger.Infof("get bridge transfers cmd params:%v", req)
+	svr := NewService(s)
+	return svr.GetBridgeTransfers(req.Limit, req.Offset, req.Chain, req.Protocol, req.Tick, req.Address, req.Status)
+}
+
+func indsGetBridgeTransfer(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	req, ok := cmd.(*IndsGetBridgeTransferCmd)
+	if !ok {
+		return ErrRPCInvalidParams, errors.New("invalid params")
+	}
+	xylog.Logger.Infof("get bridge transfer cmd params:%v", req)
+	svr := NewService(s)
+	return svr.GetBridgeTransfer(req.Bridge, req.Id)
+}
+
+func indsGetBridgeLocked(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	req, ok := cmd.(*IndsGetBridgeLockedCmd)
+	if !ok {
+		return ErrRPCInvalidParams, errors.New("invalid params")
+	}
+	xylog.Logger.Infof("get bridge locked cmd params:%v", req)
+	svr := NewService(s)
+	return svr.GetBridgeLocked(req.Chain, req.Protocol, req.Tick)
+}
+
+func indsGetWrappedSupply(s *RpcServer, cmd interface{}, closeChan <-chan struct{}) (interface{}, error) {
+	req, ok := cmd.(*IndsGetWrappedSupplyCmd)
+	if !ok {
+		return ErrRPCInvalidParams, errors.New("invalid params")
+	}
+	xylog.Logger.Infof("get wrapped supply cmd params:%v", req)
+	svr := NewService(s)
+	return svr.GetWrappedSupply(req.Chain, req.Protocol, req.Tick)
+}
@@ -57,6 +57,8 @@ func (s *Service) GetAddressBalances(limit, offset int, address, chain, protocol
 			Tick:         b.Tick,
 			Address:      b.Address,
 			Balance:      b.Balance.String(),
+			Available:    b.Available.String(),
+			Locked:       b.Balance.Sub(b.Available).String(),
 			DeployHash:   b.DeployHash,
 			TransferType: b.TransferType,
 		}
@@ -173,6 +175,18 @@ func (s *Service) GetInscription(chain, protocol, tick, deployHash string) (inte
 		DeployTime:   uint32(inscription.DeployTime.Unix()),
 		CreatedAt:    uint32(inscription.CreatedAt.Unix()),
 		UpdatedAt:    uint32(inscription.UpdatedAt.Unix()),
+
+		InscriptionNumber: inscription.InscriptionNumber,
+		LastSN:            inscription.LastSN,
+		Burned:            inscription.Burned.String(),
+		Bridged:           inscription.Bridged.String(),
+		CirculatingSupply: inscription.Minted.Add(inscription.Bridged).Sub(inscription.Burned).String(),
+
+		StartBlock:         inscription.StartBlock,
+		EndBlock:           inscription.EndBlock,
+		MaxMintsPerAddress: inscription.MaxMintsPerAddress,
+		MaxMintsPerBlock:   inscription.MaxMintsPerBlock,
+		DeployerOnly:       inscription.DeployerOnly,
 	}
 
 	s.rpcServer.cacheStore.Set(cacheKey, resp)
@@ -462,6 +476,8 @@ func (s *Service) GetInscriptionByTick(protocol string, tick string, chain strin
 		CreatedAt:    uint32(data.CreatedAt.Unix()),
 		UpdatedAt:    uint32(data.UpdatedAt.Unix()),
 		Decimals:     data.Decimals,
+
+		InscriptionNumber: data.InscriptionNumber,
 	}
 	s.rpcServer.cacheStore.Set(cacheKey, resp)
 	return resp, nil
@@ -559,10 +575,60 @@ func (s *Service) GetTxByHash(txHash string, chain string) (interface{}, error)
 	if tx == nil {
 		return nil, errors.New("Transaction Record not found")
 	}
+	resp := s.buildTxResponse(tx)
+	s.rpcServer.cacheStore.Set(cacheKey, resp)
+	return resp, nil
+}
+
+// GetTxByNumber find the inscription tx by its chain-wide number
+func (s *Service) GetTxByNumber(chain string, number int64) (interface{}, error) {
+	cacheKey := fmt.Sprintf("tx_number_%s_%d", chain, number)
+	if ins, ok := s.rpcServer.cacheStore.Get(cacheKey); ok {
+		if resp, ok := ins.(*GetTxByHashResponse); ok {
+			return resp, nil
+		}
+	}
+
+	tx, err := s.rpcServer.dbc.FindTransactionByNumber(chain, number)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+	if tx == nil {
+		return ErrRPCRecordNotFound, err
+	}
+	resp := s.buildTxResponse(tx)
+	s.rpcServer.cacheStore.Set(cacheKey, resp)
+	return resp, nil
+}
+
+// GetMintBySN find the mint tx of a tick by its mint sequence number
+func (s *Service) GetMintBySN(chain, protocol, tick string, sn uint64) (interface{}, error) {
+	protocol = strings.ToLower(protocol)
+	tick = strings.ToLower(tick)
+	cacheKey := fmt.Sprintf("tx_mint_sn_%s_%s_%s_%d", chain, protocol, tick, sn)
+	if ins, ok := s.rpcServer.cacheStore.Get(cacheKey); ok {
+		if resp, ok := ins.(*GetTxByHashResponse); ok {
+			return resp, nil
+		}
+	}
+
+	tx, err := s.rpcServer.dbc.FindMintBySN(chain, protocol, tick, sn)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+	if tx == nil {
+		return ErrRPCRecordNotFound, err
+	}
+	resp := s.buildTxResponse(tx)
+	s.rpcServer.cacheStore.Set(cacheKey, resp)
+	return resp, nil
+}
+
+func (s *Service) buildTxResponse(tx *model.Transaction) *GetTxByHashResponse {
 	resp := &GetTxByHashResponse{}
-	inscription, err := s.rpcServer.dbc.FindInscriptionByTick(tx.Chain, tx.Protocol, tx.Tick)
+	inscription, _ := s.rpcServer.dbc.FindInscriptionByTick(tx.Chain, tx.Protocol, tx.Tick)
 	// get amount from address tx tab
-	addressTx, err := s.rpcServer.dbc.FindAddressTxByHash(chain, common.HexToHash(txHash))
+	addressTx, _ := s.rpcServer.dbc.FindAddressTxByHash(tx.Chain, common.BytesToHash(tx.TxHash))
 	resp.IsInscription = true
 
 	resp.Inscriptions = inscription
@@ -587,6 +653,9 @@ func (s *Service) GetTxByHash(txHash string, chain string) (interface{}, error)
 			Status:          tx.Status,
 			CreatedAt:       tx.CreatedAt,
 			UpdatedAt:       tx.UpdatedAt,
+
+			InscriptionNumber: tx.InscriptionNumber,
+			MintSN:            tx.MintSN,
 		}
 		resp.Transaction = trs
 	}
@@ -597,8 +666,7 @@ func (s *Service) GetTxByHash(txHash string, chain string) (interface{}, error)
 		Amount:   tx.Amount,
 	}
 	resp.InscriptionsData = inscriptionsData
-	s.rpcServer.cacheStore.Set(cacheKey, resp)
-	return resp, nil
+	return resp
 }
 
 func (s *Service) GetLastBlockNumber(chains []string) (interface{}, error) {
@@ -714,6 +782,7 @@ func (s *Service) GetAddressBalance(protocol string, chain string, tick string,
 	}
 	resp.Balance = balance.Balance.String()
 	resp.Available = balance.Available.String()
+	resp.Locked = balance.Balance.Sub(balance.Available).String()
 
 	switch inscription.TransferType {
 	case model.TransferTypeHash:
@@ -1075,3 +1144,763 @@ func doRequest(body, path string, nodes map[string]string) (map[string]interface
 	})
 	return result, nil
 }
+
+func (s *Service) GetRunes(limit, offset int, chain, keyword string, sortMode int) (interface{}, error) {
+	cacheKey := fmt.Sprintf("all_runes_%d_%d_%s_%s_%d", limit, offset, chain, keyword, sortMode)
+	if runes, ok := s.rpcServer.cacheStore.Get(cacheKey); ok {
+		if allRunes, ok := runes.(*FindRunesResponse); ok {
+			return allRunes, nil
+		}
+	}
+
+	runes, total, err := s.rpcServer.dbc.GetRunes(limit, offset, chain, strings.TrimSpace(keyword), sortMode)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+
+	list := make([]*RuneInfo, 0, len(runes))
+	for _, r := range runes {
+		list = append(list, buildRuneInfo(r))
+	}
+
+	resp := &FindRunesResponse{
+		Runes:  list,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}
+	s.rpcServer.cacheStore.Set(cacheKey, resp)
+	return resp, nil
+}
+
+func (s *Service) GetRune(chain, key string) (interface{}, error) {
+	cacheKey := fmt.Sprintf("rune_%s_%s", chain, key)
+	if r, ok := s.rpcServer.cacheStore.Get(cacheKey); ok {
+		if info, ok := r.(*RuneInfo); ok {
+			return info, nil
+		}
+	}
+
+	r, err := s.rpcServer.dbc.FindRune(chain, strings.TrimSpace(key))
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+	if r == nil {
+		return nil, errors.New("Record not found")
+	}
+
+	info := buildRuneInfo(r)
+	s.rpcServer.cacheStore.Set(cacheKey, info)
+	return info, nil
+}
+
+func (s *Service) GetRuneHolders(limit, offset int, chain, key string, sortMode int) (interface{}, error) {
+	cacheKey := fmt.Sprintf("rune_holders_%d_%d_%s_%s_%d", limit, offset, chain, key, sortMode)
+	if holders, ok := s.rpcServer.cacheStore.Get(cacheKey); ok {
+		if resp, ok := holders.(*FindRuneHoldersResponse); ok {
+			return resp, nil
+		}
+	}
+
+	r, err := s.rpcServer.dbc.FindRune(chain, strings.TrimSpace(key))
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+	if r == nil {
+		return nil, errors.New("Record not found")
+	}
+
+	holders, total, err := s.rpcServer.dbc.GetRuneHolders(limit, offset, chain, r.RuneId, sortMode)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+
+	list := make([]*RuneHolder, 0, len(holders))
+	for _, holder := range holders {
+		list = append(list, &RuneHolder{
+			RuneId:     r.RuneId,
+			SpacedRune: r.SpacedRune,
+			Symbol:     r.Symbol,
+			Address:    holder.Address,
+			Balance:    runeAmount(holder.Amount, r.Divisibility),
+			Utxos:      holder.Utxos,
+		})
+	}
+
+	resp := &FindRuneHoldersResponse{
+		Rune:    buildRuneInfo(r),
+		Holders: list,
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+	}
+	s.rpcServer.cacheStore.Set(cacheKey, resp)
+	return resp, nil
+}
+
+func (s *Service) GetRuneBalancesByAddress(chain, address string) (interface{}, error) {
+	cacheKey := fmt.Sprintf("rune_balances_%s_%s", chain, address)
+	if balances, ok := s.rpcServer.cacheStore.Get(cacheKey); ok {
+		if resp, ok := balances.(*FindRuneBalancesResponse); ok {
+			return resp, nil
+		}
+	}
+
+	balances, err := s.rpcServer.dbc.GetRuneBalancesByAddress(chain, address)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+
+	ids := make([]string, 0, len(balances))
+	for _, balance := range balances {
+		ids = append(ids, balance.RuneId)
+	}
+	runes, err := s.findRunesByIds(chain, ids)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+
+	list := make([]*RuneHolder, 0, len(balances))
+	for _, balance := range balances {
+		r, ok := runes[balance.RuneId]
+		if !ok {
+			continue
+		}
+		list = append(list, &RuneHolder{
+			RuneId:     r.RuneId,
+			SpacedRune: r.SpacedRune,
+			Symbol:     r.Symbol,
+			Address:    balance.Address,
+			Balance:    runeAmount(balance.Amount, r.Divisibility),
+			Utxos:      balance.Utxos,
+		})
+	}
+
+	resp := &FindRuneBalancesResponse{
+		Address:  address,
+		Balances: list,
+	}
+	s.rpcServer.cacheStore.Set(cacheKey, resp)
+	return resp, nil
+}
+
+func (s *Service) GetRuneUtxosByAddress(limit, offset int, chain, address, key string) (interface{}, error) {
+	cacheKey := fmt.Sprintf("rune_utxos_%d_%d_%s_%s_%s", limit, offset, chain, address, key)
+	if utxos, ok := s.rpcServer.cacheStore.Get(cacheKey); ok {
+		if resp, ok := utxos.(*FindRuneUtxosResponse); ok {
+			return resp, nil
+		}
+	}
+
+	runeId := ""
+	if key = strings.TrimSpace(key); key != "" {
+		r, err := s.rpcServer.dbc.FindRune(chain, key)
+		if err != nil {
+			return ErrRPCInternal, err
+		}
+		if r == nil {
+			return nil, errors.New("Record not found")
+		}
+		runeId = r.RuneId
+	}
+
+	utxos, total, err := s.rpcServer.dbc.GetRuneUtxosByAddress(limit, offset, chain, address, runeId)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+
+	ids := make([]string, 0, len(utxos))
+	for _, utxo := range utxos {
+		ids = append(ids, utxo.RuneId)
+	}
+	runes, err := s.findRunesByIds(chain, ids)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+
+	list := make([]*RuneUtxo, 0, len(utxos))
+	for _, utxo := range utxos {
+		r, ok := runes[utxo.RuneId]
+		if !ok {
+			continue
+		}
+		list = append(list, &RuneUtxo{
+			RuneId:      r.RuneId,
+			SpacedRune:  r.SpacedRune,
+			Outpoint:    utxo.Outpoint,
+			Address:     utxo.Address,
+			Balance:     runeAmount(utxo.Amount, r.Divisibility),
+			BlockHeight: utxo.BlockHeight,
+		})
+	}
+
+	resp := &FindRuneUtxosResponse{
+		Utxos:  list,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}
+	s.rpcServer.cacheStore.Set(cacheKey, resp)
+	return resp, nil
+}
+
+func (s *Service) findRunesByIds(chain string, runeIds []string) (map[string]*model.Runes, error) {
+	ids := make([]string, 0, len(runeIds))
+	seen := make(map[string]struct{}, len(runeIds))
+	for _, id := range runeIds {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+
+	items, err := s.rpcServer.dbc.FindRunesByIds(chain, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	runes := make(map[string]*model.Runes, len(items))
+	for _, item := range items {
+		runes[item.RuneId] = item
+	}
+	return runes, nil
+}
+
+// runeAmount format raw rune amount with rune divisibility
+func runeAmount(amount decimal.Decimal, divisibility int) string {
+	return amount.Shift(int32(-divisibility)).String()
+}
+
+func buildRuneInfo(r *model.Runes) *RuneInfo {
+	info := &RuneInfo{
+		Chain:        r.Chain,
+		RuneId:       r.RuneId,
+		Rune:         r.Rune,
+		SpacedRune:   r.SpacedRune,
+		Number:       r.Number,
+		Symbol:       r.Symbol,
+		Divisibility: r.Divisibility,
+		Etching:      r.Etching,
+		Premine:      runeAmount(r.Premine, r.Divisibility),
+		Supply:       runeAmount(r.Supply, r.Divisibility),
+		MaxSupply:    runeAmount(r.Premine.Add(r.Cap.Mul(r.Limit)), r.Divisibility),
+		Burned:       runeAmount(r.Burned, r.Divisibility),
+		Mints:        r.Mints,
+		Progress:     "0",
+		Turbo:        r.Turbo,
+		BlockHeight:  r.BlockHeight,
+		Timestamp:    r.Timestamp,
+	}
+
+	// runes etched without mint terms are premine only
+	if r.Cap.IsPositive() || r.Limit.IsPositive() || r.HeightStart != nil || r.HeightEnd != nil ||
+		r.OffsetStart != nil || r.OffsetEnd != nil {
+		info.Terms = &RuneTerms{
+			Amount:      runeAmount(r.Limit, r.Divisibility),
+			Cap:         r.Cap.String(),
+			HeightStart: r.HeightStart,
+			HeightEnd:   r.HeightEnd,
+			OffsetStart: r.OffsetStart,
+			OffsetEnd:   r.OffsetEnd,
+		}
+	}
+
+	if r.Cap.IsPositive() {
+		info.Progress = decimal.NewFromInt(r.Mints).Div(r.Cap).Truncate(4).String()
+	}
+	return info
+}
+
+var listingStatusNames = map[int8]string{
+	model.ListingStatusActive:    "active",
+	model.ListingStatusCancelled: "cancelled",
+	model.ListingStatusFilled:    "filled",
+}
+
+func parseListingStatus(status string) (int8, bool) {
+	status = strings.ToLower(strings.TrimSpace(status))
+	if status == "" {
+		return model.ListingStatusActive, true
+	}
+	for k, v := range listingStatusNames {
+		if v == status {
+			return k, true
+		}
+	}
+	return 0, false
+}
+
+func buildListingInfo(item *model.Listings) *ListingInfo {
+	return &ListingInfo{
+		Chain:          item.Chain,
+		Protocol:       item.Protocol,
+		Tick:           item.Tick,
+		ListId:         item.ListId,
+		Marketplace:    item.Marketplace,
+		Seller:         item.Seller,
+		Buyer:          item.Buyer,
+		Amount:         item.Amount.String(),
+		Price:          item.Price.String(),
+		UnitPrice:      item.UnitPrice.String(),
+		Status:         listingStatusNames[item.Status],
+		ListTxHash:     item.ListTxHash,
+		CloseTxHash:    item.CloseTxHash,
+		ListBlock:      item.ListBlock,
+		CloseBlock:     item.CloseBlock,
+		ListTimestamp:  item.ListTimestamp,
+		CloseTimestamp: item.CloseTimestamp,
+	}
+}
+
+func (s *Service) GetListings(limit, offset int, chain, protocol, tick, seller, status string) (interface{}, error) {
+	st, ok := parseListingStatus(status)
+	if !ok {
+		return ErrRPCInvalidParams, fmt.Errorf("invalid listing status[%s]", status)
+	}
+
+	protocol = strings.ToLower(protocol)
+	tick = strings.ToLower(tick)
+	seller = strings.ToLower(seller)
+	cacheKey := fmt.Sprintf("listings_%d_%d_%s_%s_%s_%s_%d", limit, offset, chain, protocol, tick, seller, st)
+	if listings, ok := s.rpcServer.cacheStore.Get(cacheKey); ok {
+		if resp, ok := listings.(*FindListingsResponse); ok {
+			return resp, nil
+		}
+	}
+
+	items, total, err := s.rpcServer.dbc.GetListings(limit, offset, chain, protocol, tick, seller, st)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+
+	list := make([]*ListingInfo, 0, len(items))
+	for _, item := range items {
+		list = append(list, buildListingInfo(item))
+	}
+
+	resp := &FindListingsResponse{
+		Listings: list,
+		Total:    total,
+		Limit:    limit,
+		Offset:   offset,
+	}
+	s.rpcServer.cacheStore.Set(cacheKey, resp)
+	return resp, nil
+}
+
+func (s *Service) GetListingFills(limit, offset int, chain, protocol, tick, address string) (interface{}, error) {
+	protocol = strings.ToLower(protocol)
+	tick = strings.ToLower(tick)
+	address = strings.ToLower(address)
+	cacheKey := fmt.Sprintf("listing_fills_%d_%d_%s_%s_%s_%s", limit, offset, chain, protocol, tick, address)
+	if fills, ok := s.rpcServer.cacheStore.Get(cacheKey); ok {
+		if resp, ok := fills.(*FindListingsResponse); ok {
+			return resp, nil
+		}
+	}
+
+	items, total, err := s.rpcServer.dbc.GetListingFills(limit, offset, chain, protocol, tick, address)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+
+	list := make([]*ListingInfo, 0, len(items))
+	for _, item := range items {
+		list = append(list, buildListingInfo(item))
+	}
+
+	resp := &FindListingsResponse{
+		Listings: list,
+		Total:    total,
+		Limit:    limit,
+		Offset:   offset,
+	}
+	s.rpcServer.cacheStore.Set(cacheKey, resp)
+	return resp, nil
+}
+
+var bridgeTransferStatusNames = map[int8]string{
+	model.BridgeTransferStatusPending: "pending",
+	model.BridgeTransferStatusSettled: "settled",
+}
+
+// parseBridgeTransferStatus empty status matches all transfers
+func parseBridgeTransferStatus(status string) (int8, bool) {
+	status = strings.ToLower(strings.TrimSpace(status))
+	if status == "" {
+		return 0, true
+	}
+	for k, v := range bridgeTransferStatusNames {
+		if v == status {
+			return k, true
+		}
+	}
+	return 0, false
+}
+
+func buildBridgeTransferInfo(item *model.BridgeTransfers) *BridgeTransferInfo {
+	return &BridgeTransferInfo{
+		Bridge:          item.Bridge,
+		Id:              item.BridgeId,
+		Protocol:        item.Protocol,
+		Tick:            item.Tick,
+		Amount:          item.Amount.String(),
+		Sender:          item.Sender,
+		Recipient:       item.Recipient,
+		SourceChain:     item.SourceChain,
+		SourceTxHash:    item.SourceTxHash,
+		SourceBlock:     item.SourceBlock,
+		SourceTimestamp: item.SourceTimestamp,
+		DestChain:       item.DestChain,
+		DestTxHash:      item.DestTxHash,
+		DestBlock:       item.DestBlock,
+		DestTimestamp:   item.DestTimestamp,
+		SettleType:      item.SettleType,
+		Status:          bridgeTransferStatusNames[item.Status],
+	}
+}
+
+func (s *Service) GetBridgeTransfers(limit, offset int, chain, protocol, tick, address, status string) (interface{}, error) {
+	st, ok := parseBridgeTransferStatus(status)
+	if !ok {
+		return ErrRPCInvalidParams, fmt.Errorf("invalid bridge transfer status[%s]", status)
+	}
+
+	protocol = strings.ToLower(protocol)
+	tick = strings.ToLower(tick)
+	address = strings.ToLower(address)
+	cacheKey := fmt.Sprintf("bridge_transfers_%d_%d_%s_%s_%s_%s_%d", limit, offset, chain, protocol, tick, address, st)
+	if transfers, ok := s.rpcServer.cacheStore.Get(cacheKey); ok {
+		if resp, ok := transfers.(*FindBridgeTransfersResponse); ok {
+			return resp, nil
+		}
+	}
+
+	items, total, err := s.rpcServer.dbc.GetBridgeTransfers(limit, offset, chain, protocol, tick, address, st)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+
+	list := make([]*BridgeTransferInfo, 0, len(items))
+	for _, item := range items {
+		list = append(list, buildBridgeTransferInfo(item))
+	}
+
+	resp := &FindBridgeTransfersResponse{
+		Transfers: list,
+		Total:     total,
+		Limit:     limit,
+		Offset:    offset,
+	}
+	s.rpcServer.cacheStore.Set(cacheKey, resp)
+	return resp, nil
+}
+
+func (s *Service) GetBridgeTransfer(bridge, id string) (interface{}, error) {
+	cacheKey := fmt.Sprintf("bridge_transfer_%s_%s", bridge, id)
+	if transfer, ok := s.rpcServer.cacheStore.Get(cacheKey); ok {
+		if resp, ok := transfer.(*BridgeTransferInfo); ok {
+			return resp, nil
+		}
+	}
+
+	item, err := s.rpcServer.dbc.FindBridgeTransfer(bridge, id)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+	if item == nil {
+		return ErrRPCRecordNotFound, err
+	}
+
+	resp := buildBridgeTransferInfo(item)
+	s.rpcServer.cacheStore.Set(cacheKey, resp)
+	return resp, nil
+}
+
+func (s *Service) GetBridgeLocked(chain, protocol, tick string) (interface{}, error) {
+	protocol = strings.ToLower(protocol)
+	tick = strings.ToLower(tick)
+	cacheKey := fmt.Sprintf("bridge_locked_%s_%s_%s", chain, protocol, tick)
+	if locked, ok := s.rpcServer.cacheStore.Get(cacheKey); ok {
+		if resp, ok := locked.(*BridgeLockedInfo); ok {
+			return resp, nil
+		}
+	}
+
+	balances, err := s.rpcServer.dbc.GetBridgeLockedBalances(chain, protocol, tick)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+
+	total := decimal.Zero
+	contracts := make([]*BridgeLockedItem, 0, len(balances))
+	for _, item := range balances {
+		total = total.Add(item.Balance)
+		contracts = append(contracts, &BridgeLockedItem{
+			Contract: item.Address,
+			Amount:   item.Balance.String(),
+		})
+	}
+
+	resp := &BridgeLockedInfo{
+		Chain:     chain,
+		Protocol:  protocol,
+		Tick:      tick,
+		Locked:    total.String(),
+		Contracts: contracts,
+	}
+	s.rpcServer.cacheStore.Set(cacheKey, resp)
+	return resp, nil
+}
+
+func (s *Service) GetWrappedSupply(chain, protocol, tick string) (interface{}, error) {
+	protocol = strings.ToLower(protocol)
+	tick = strings.ToLower(tick)
+	cacheKey := fmt.Sprintf("wrapped_supply_%s_%s_%s", chain, protocol, tick)
+	if wrapped, ok := s.rpcServer.cacheStore.Get(cacheKey); ok {
+		if resp, ok := wrapped.(*WrappedSupplyInfo); ok {
+			return resp, nil
+		}
+	}
+
+	items, err := s.rpcServer.dbc.GetWrappers(chain, protocol, tick)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+
+	resp := &WrappedSupplyInfo{
+		Chain:    chain,
+		Protocol: protocol,
+		Tick:     tick,
+		Wrappers: make([]*WrapperInfo, 0, len(items)),
+	}
+	supply, locked := decimal.Zero, decimal.Zero
+	for _, item := range items {
+		diverged := !item.Supply.Equal(item.Locked)
+		resp.Diverged = resp.Diverged || diverged
+		supply = supply.Add(item.Supply)
+		locked = locked.Add(item.Locked)
+		resp.Wrappers = append(resp.Wrappers, &WrapperInfo{
+			Name:        item.Name,
+			Address:     item.Address,
+			Contract:    item.Contract,
+			Supply:      item.Supply.String(),
+			Locked:      item.Locked.String(),
+			Diverged:    diverged,
+			BlockHeight: item.BlockHeight,
+		})
+	}
+	resp.Supply = supply.String()
+	resp.Locked = locked.String()
+	s.rpcServer.cacheStore.Set(cacheKey, resp)
+	return resp, nil
+}
+
+const maxTickCandlesLimit = 1000
+
+func (s *Service) GetTickCandles(chain, protocol, tick, period string, start, end int64, limit int) (interface{}, error) {
+	period = strings.ToLower(strings.TrimSpace(period))
+	if period == "" {
+		period = model.CandlePeriod1h
+	}
+	if _, ok := model.CandlePeriods[period]; !ok {
+		return ErrRPCInvalidParams, fmt.Errorf("invalid candle period[%s]", period)
+	}
+	if limit <= 0 || limit > maxTickCandlesLimit {
+		limit = maxTickCandlesLimit
+	}
+
+	protocol = strings.ToLower(protocol)
+	tick = strings.ToLower(tick)
+	cacheKey := fmt.Sprintf("tick_candles_%s_%s_%s_%s_%d_%d_%d", chain, protocol, tick, period, start, end, limit)
+	if candles, ok := s.rpcServer.cacheStore.Get(cacheKey); ok {
+		if resp, ok := candles.(*FindTickCandlesResponse); ok {
+			return resp, nil
+		}
+	}
+
+	items, err := s.rpcServer.dbc.GetTickCandles(chain, protocol, tick, period, start, end, limit)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+
+	list := make([]*TickCandle, 0, len(items))
+	for _, item := range items {
+		list = append(list, &TickCandle{
+			OpenTime: item.OpenTime,
+			Open:     item.Open.String(),
+			High:     item.High.String(),
+			Low:      item.Low.String(),
+			Close:    item.Close.String(),
+			Volume:   item.Volume.String(),
+			Turnover: item.Turnover.String(),
+			Trades:   item.Trades,
+		})
+	}
+
+	resp := &FindTickCandlesResponse{
+		Chain:    chain,
+		Protocol: protocol,
+		Tick:     tick,
+		Period:   period,
+		Candles:  list,
+	}
+	s.rpcServer.cacheStore.Set(cacheKey, resp)
+	return resp, nil
+}
+
+func (s *Service) GetTickMarketStats(chain, protocol, tick string) (interface{}, error) {
+	protocol = strings.ToLower(protocol)
+	tick = strings.ToLower(tick)
+	cacheKey := fmt.Sprintf("tick_market_stats_%s_%s_%s", chain, protocol, tick)
+	if stats, ok := s.rpcServer.cacheStore.Get(cacheKey); ok {
+		if resp, ok := stats.(*TickMarketStats); ok {
+			return resp, nil
+		}
+	}
+
+	inscription, err := s.rpcServer.dbc.FindInscriptionByTick(chain, protocol, tick)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+	if inscription == nil {
+		return nil, errors.New("Record not found")
+	}
+
+	stats, err := s.rpcServer.dbc.FindInscriptionsStatsByTick(chain, protocol, tick)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+
+	// 24h window over the minute candles, anchored to the latest indexed block so a lagging indexer reports full windows
+	endTime := time.Now()
+	if block, _ := s.rpcServer.dbc.FindLastBlock(chain); block != nil {
+		endTime = block.BlockTime
+	}
+	since := endTime.Unix() - model.CandlePeriods[model.CandlePeriod1d]
+	summary, err := s.rpcServer.dbc.GetTickMarketSummary(chain, protocol, tick, model.CandlePeriod1m, since)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+
+	first, err := s.rpcServer.dbc.FindTickCandle(chain, protocol, tick, model.CandlePeriod1m, since, false)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+
+	last, err := s.rpcServer.dbc.FindTickCandle(chain, protocol, tick, model.CandlePeriod1m, 0, true)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+
+	resp := buildTickMarketStats(inscription, stats.Minted, summary, first, last)
+	s.rpcServer.cacheStore.Set(cacheKey, resp)
+	return resp, nil
+}
+
+func buildTickMarketStats(inscription *model.Inscriptions, minted decimal.Decimal, summary *model.TickMarketSummary,
+	first, last *model.TickCandles) *TickMarketStats {
+	stats := &TickMarketStats{
+		Chain:          inscription.Chain,
+		Protocol:       inscription.Protocol,
+		Tick:           inscription.Tick,
+		LastPrice:      "0",
+		PriceChange24h: "0",
+		FloorPrice24h:  summary.Low.String(),
+		HighPrice24h:   summary.High.String(),
+		Volume24h:      summary.Volume.String(),
+		Turnover24h:    summary.Turnover.String(),
+		Trades24h:      summary.Trades,
+		Minted:         minted.String(),
+		MarketCap:      "0",
+	}
+	if last == nil {
+		return stats
+	}
+
+	stats.LastPrice = last.Close.String()
+	stats.MarketCap = last.Close.Mul(minted).Truncate(0).String()
+	if first != nil && first.Open.GreaterThan(decimal.Zero) {
+		stats.PriceChange24h = last.Close.Sub(first.Open).Div(first.Open).Mul(decimal.NewFromInt(100)).StringFixed(2)
+	}
+	return stats
+}
+
+func (s *Service) GetInscriptionContent(chain, id string) (interface{}, error) {
+	id = strings.ToLower(id)
+	cacheKey := fmt.Sprintf("inscription_content_%s_%s", chain, id)
+	if content, ok := s.rpcServer.cacheStore.Get(cacheKey); ok {
+		if resp, ok := content.(*InscriptionContentInfo); ok {
+			return resp, nil
+		}
+	}
+
+	item, err := s.rpcServer.dbc.FindEthscription(chain, id)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+	if item == nil {
+		return ErrRPCRecordNotFound, err
+	}
+
+	resp := buildInscriptionContentInfo(item)
+	if item.ContentSha256 != "" {
+		content, err := s.rpcServer.dbc.FindInscriptionContent(item.ContentSha256)
+		if err != nil {
+			return ErrRPCInternal, err
+		}
+		if content != nil {
+			resp.Content = content.Content
+		}
+	}
+	s.rpcServer.cacheStore.Set(cacheKey, resp)
+	return resp, nil
+}
+
+func (s *Service) GetInscriptionsByAddress(limit, offset int, chain, address string) (interface{}, error) {
+	address = strings.ToLower(address)
+	cacheKey := fmt.Sprintf("address_inscriptions_%d_%d_%s_%s", limit, offset, chain, address)
+	if inscriptions, ok := s.rpcServer.cacheStore.Get(cacheKey); ok {
+		if resp, ok := inscriptions.(*FindInscriptionsByAddressResponse); ok {
+			return resp, nil
+		}
+	}
+
+	items, total, err := s.rpcServer.dbc.GetEthscriptionsByOwner(limit, offset, chain, address)
+	if err != nil {
+		return ErrRPCInternal, err
+	}
+
+	list := make([]*InscriptionContentInfo, 0, len(items))
+	for _, item := range items {
+		list = append(list, buildInscriptionContentInfo(item))
+	}
+
+	resp := &FindInscriptionsByAddressResponse{
+		Address:      address,
+		Inscriptions: list,
+		Total:        total,
+		Limit:        limit,
+		Offset:       offset,
+	}
+	s.rpcServer.cacheStore.Set(cacheKey, resp)
+	return resp, nil
+}
+
+func buildInscriptionContentInfo(item *model.Ethscriptions) *InscriptionContentInfo {
+	return &InscriptionContentInfo{
+		Chain:         item.Chain,
+		Protocol:      item.Protocol,
+		Id:            item.EthscriptionId,
+		Number:        item.Number,
+		Creator:       item.Creator,
+		Owner:         item.Owner,
+		PreviousOwner: item.PreviousOwner,
+		ContentType:   item.ContentType,
+		ContentSize:   item.ContentSize,
+		ContentSha256: item.ContentSha256,
+		BlockHeight:   item.BlockHeight,
+		CreatedAt:     item.CreatedAt.Unix(),
+	}
+}
@@ -25,6 +25,8 @@ package jsonrpc
 import (
 	"encoding/json"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/model"
 	"testing"
 )
 
@@ -45,3 +47,53 @@ func Test_json(t *testing.T) {
 	t.Logf("data=%v", string(data))
 
 }
+
+func Test_buildRuneInfo(t *testing.T) {
+	start := uint64(840000)
+	info := buildRuneInfo(&model.Runes{
+		RuneId:       "840000:3",
+		Rune:         "UNCOMMONGOODS",
+		SpacedRune:   "UNCOMMON•GOODS",
+		Divisibility: 2,
+		Premine:      decimal.NewFromInt(100),
+		Supply:       decimal.NewFromInt(1100),
+		Mints:        10,
+		Cap:          decimal.NewFromInt(40),
+		Limit:        decimal.NewFromInt(100),
+		HeightStart:  &start,
+	})
+
+	if info.Supply != "11" || info.MaxSupply != "41" || info.Premine != "1" {
+		t.Fatalf("unexpected supply: %s / %s / %s", info.Supply, info.MaxSupply, info.Premine)
+	}
+	if info.Progress != "0.25" {
+		t.Fatalf("unexpected progress: %s", info.Progress)
+	}
+	if info.Terms == nil || info.Terms.Amount != "1" || info.Terms.Cap != "40" || *info.Terms.HeightStart != start {
+		t.Fatalf("unexpected terms: %+v", info.Terms)
+	}
+
+	premineOnly := buildRuneInfo(&model.Runes{RuneId: "840000:1", Premine: decimal.NewFromInt(5)})
+	if premineOnly.Terms != nil || premineOnly.Progress != "0" {
+		t.Fatalf("unexpected premine only rune: %+v", premineOnly)
+	}
+}
+
+func Test_parseListingStatus(t *testing.T) {
+	cases := map[string]int8{
+		"":          model.ListingStatusActive,
+		"active":    model.ListingStatusActive,
+		"Cancelled": model.ListingStatusCancelled,
+		" filled ":  model.ListingStatusFilled,
+	}
+	for status, expected := range cases {
+		st, ok := parseListingStatus(status)
+		if !ok || st != expected {
+			t.Fatalf("status[%s] parsed[%d-%v], expected[%d]", status, st, ok, expected)
+		}
+	}
+
+	if _, ok := parseListingStatus("sold"); ok {
+		t.Fatalf("unknown status should be rejected")
+	}
+}
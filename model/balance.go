@@ -40,7 +40,7 @@ type Balances struct {
 	Protocol  string          `json:"protocol" gorm:"column:protocol"`
 	Address   string          `json:"address" gorm:"column:address"`
 	Tick      string          `json:"tick" gorm:"column:tick"`
-	Available decimal.Decimal `json:"available" gorm:"column:available;type:decimal(38,18)"` // available balance = overall balance - transferable balance
+	Available decimal.Decimal `json:"available" gorm:"column:available;type:decimal(38,18)"` // available balance = overall balance - transferable (btc) / frozen (evm) balance
 	Balance   decimal.Decimal `json:"balance" gorm:"column:balance;type:decimal(38,18)"`     // overall balance
 	CreatedAt time.Time       `json:"created_at" gorm:"column:created_at"`
 	UpdatedAt time.Time       `json:"updated_at" gorm:"column:updated_at"`
@@ -62,7 +62,8 @@ type UTXO struct {
 	Amount            decimal.Decimal `json:"amount" gorm:"column:amount;type:decimal(38,18)"` // amount
 	RootHash          string          `json:"root_hash" gorm:"column:root_hash"`
 	TxHash            string          `json:"tx_hash" gorm:"column:tx_hash"`
-	Status            int8            `json:"status" gorm:"column:status"` // tx status
+	Satpoint          string          `json:"satpoint" gorm:"column:satpoint"` // <txid>:<vout>:<offset> of the inscribed sat
+	Status            int8            `json:"status" gorm:"column:status"`     // tx status
 	CreatedAt         time.Time       `json:"created_at" gorm:"column:created_at"`
 	UpdatedAt         time.Time       `json:"updated_at" gorm:"column:updated_at"`
 }
@@ -77,6 +78,7 @@ type BalanceInscription struct {
 	Tick         string          `json:"tick"`
 	Address      string          `json:"address"`
 	Balance      decimal.Decimal `json:"balance"`
+	Available    decimal.Decimal `json:"available"`
 	DeployHash   string          `json:"deploy_hash"`
 	TransferType int8            `json:"transfer_type"`
 }
@@ -0,0 +1,70 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package model
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+const (
+	BridgeTransferStatusPending = 1
+	BridgeTransferStatusSettled = 2
+)
+
+// bridge settlement kinds of the destination chain
+const (
+	BridgeSettleRelease = "release"
+	BridgeSettleMint    = "mint"
+)
+
+// BridgeTransfers cross-chain bridge transfers keyed by (bridge, bridge_id), the source chain indexer records the lock
+// and the destination chain indexer the release / mint in the same database, the transfer is settled once both are known
+type BridgeTransfers struct {
+	ID              uint64          `gorm:"primaryKey" json:"id"`
+	Bridge          string          `gorm:"column:bridge" json:"bridge"`
+	BridgeId        string          `gorm:"column:bridge_id" json:"bridge_id"`
+	Protocol        string          `gorm:"column:protocol" json:"protocol"`
+	Tick            string          `gorm:"column:tick" json:"tick"`
+	Amount          decimal.Decimal `gorm:"column:amount;type:decimal(38,18)" json:"amount"`
+	Sender          string          `gorm:"column:sender" json:"sender"`
+	Recipient       string          `gorm:"column:recipient" json:"recipient"`
+	SourceChain     string          `gorm:"column:source_chain" json:"source_chain"`
+	SourceContract  string          `gorm:"column:source_contract" json:"source_contract"`
+	SourceTxHash    string          `gorm:"column:source_tx_hash" json:"source_tx_hash"`
+	SourceBlock     uint64          `gorm:"column:source_block" json:"source_block"`
+	SourceTimestamp int64           `gorm:"column:source_timestamp" json:"source_timestamp"`
+	DestChain       string          `gorm:"column:dest_chain" json:"dest_chain"`
+	DestContract    string          `gorm:"column:dest_contract" json:"dest_contract"`
+	DestTxHash      string          `gorm:"column:dest_tx_hash" json:"dest_tx_hash"`
+	DestBlock       uint64          `gorm:"column:dest_block" json:"dest_block"`
+	DestTimestamp   int64           `gorm:"column:dest_timestamp" json:"dest_timestamp"`
+	SettleType      string          `gorm:"column:settle_type" json:"settle_type"` // release / mint
+	Status          int8            `gorm:"column:status" json:"status"`
+	CreatedAt       time.Time       `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt       time.Time       `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (BridgeTransfers) TableName() string {
+	return "bridge_transfers"
+}
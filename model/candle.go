@@ -0,0 +1,73 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package model
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+const (
+	CandlePeriod1m = "1m"
+	CandlePeriod1h = "1h"
+	CandlePeriod1d = "1d"
+)
+
+// CandlePeriods candle periods & their length in seconds
+var CandlePeriods = map[string]int64{
+	CandlePeriod1m: 60,
+	CandlePeriod1h: 3600,
+	CandlePeriod1d: 86400,
+}
+
+// TickCandles OHLCV candles of filled marketplace orders, prices are in wei per token
+type TickCandles struct {
+	ID        uint64          `gorm:"primaryKey" json:"id"`
+	Chain     string          `gorm:"column:chain" json:"chain"`
+	Protocol  string          `gorm:"column:protocol" json:"protocol"`
+	Tick      string          `gorm:"column:tick" json:"tick"`
+	Period    string          `gorm:"column:period" json:"period"`
+	OpenTime  int64           `gorm:"column:open_time" json:"open_time"`
+	Open      decimal.Decimal `gorm:"column:open;type:decimal(65,18)" json:"open"`
+	High      decimal.Decimal `gorm:"column:high;type:decimal(65,18)" json:"high"`
+	Low       decimal.Decimal `gorm:"column:low;type:decimal(65,18)" json:"low"`
+	Close     decimal.Decimal `gorm:"column:close;type:decimal(65,18)" json:"close"`
+	Volume    decimal.Decimal `gorm:"column:volume;type:decimal(38,18)" json:"volume"`    // traded token amount
+	Turnover  decimal.Decimal `gorm:"column:turnover;type:decimal(65,0)" json:"turnover"` // traded value in wei
+	Trades    uint64          `gorm:"column:trades" json:"trades"`
+	CreatedAt time.Time       `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt time.Time       `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (TickCandles) TableName() string {
+	return "tick_candles"
+}
+
+// TickMarketSummary aggregated candles of a time window
+type TickMarketSummary struct {
+	Low      decimal.Decimal `gorm:"column:low"`
+	High     decimal.Decimal `gorm:"column:high"`
+	Volume   decimal.Decimal `gorm:"column:volume"`
+	Turnover decimal.Decimal `gorm:"column:turnover"`
+	Trades   uint64          `gorm:"column:trades"`
+}
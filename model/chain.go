@@ -32,8 +32,11 @@ const (
 )
 
 const (
-	ChainBTC  string = "btc"
-	ChainAVAX string = "avalanche"
+	ChainBTC     string = "btc"
+	ChainAVAX    string = "avalanche"
+	ChainETH     string = "eth"
+	ChainBSC     string = "bsc"
+	ChainPolygon string = "polygon"
 )
 
 type ChainInfo struct {
@@ -0,0 +1,62 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package model
+
+import (
+	"time"
+)
+
+type Ethscriptions struct {
+	ID             uint64    `gorm:"primaryKey" json:"id"`
+	Chain          string    `json:"chain" gorm:"column:chain"`
+	Protocol       string    `json:"protocol" gorm:"column:protocol"`               // token protocol of a token inscription, empty for ethscriptions
+	EthscriptionId string    `json:"ethscription_id" gorm:"column:ethscription_id"` // creation tx hash
+	Creator        string    `json:"creator" gorm:"column:creator"`
+	Owner          string    `json:"owner" gorm:"column:owner"`
+	PreviousOwner  string    `json:"previous_owner" gorm:"column:previous_owner"`
+	Number         uint64    `json:"number" gorm:"column:number"` // chain-wide inscription number of the creation tx
+	ContentType    string    `json:"content_type" gorm:"column:content_type"`
+	ContentSize    int       `json:"content_size" gorm:"column:content_size"`
+	ContentSha256  string    `json:"content_sha256" gorm:"column:content_sha256"` // inscription_contents key
+	UriSha256      string    `json:"uri_sha256" gorm:"column:uri_sha256"`         // sha256 of the creation data uri
+	BlockHeight    uint64    `json:"block_height" gorm:"column:block_height"`
+	CreatedAt      time.Time `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt      time.Time `json:"updated_at" gorm:"column:updated_at"`
+}
+
+func (Ethscriptions) TableName() string {
+	return "ethscriptions"
+}
+
+// InscriptionContents deduplicated content store, the same bytes are stored once
+type InscriptionContents struct {
+	ID        uint64    `gorm:"primaryKey" json:"id"`
+	Sha256    string    `json:"sha256" gorm:"column:sha256"`
+	Size      int       `json:"size" gorm:"column:size"`
+	Content   []byte    `json:"content" gorm:"column:content"`
+	CreatedAt time.Time `json:"created_at" gorm:"column:created_at"`
+}
+
+func (InscriptionContents) TableName() string {
+	return "inscription_contents"
+}
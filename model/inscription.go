@@ -51,6 +51,13 @@ type Inscriptions struct {
 	CreatedAt         time.Time       `json:"created_at" gorm:"column:created_at"`
 	UpdatedAt         time.Time       `json:"updated_at" gorm:"column:updated_at"`
 	Decimals          int8            `json:"decimals" gorm:"column:decimals"`
+	SelfMint          bool            `json:"self_mint" gorm:"column:self_mint"`
+	// deploy params, 0 means unbounded
+	StartBlock         uint64 `json:"start_block" gorm:"column:start_block"`
+	EndBlock           uint64 `json:"end_block" gorm:"column:end_block"`
+	MaxMintsPerAddress uint64 `json:"max_mints_per_address" gorm:"column:max_mints_per_address"`
+	MaxMintsPerBlock   uint64 `json:"max_mints_per_block" gorm:"column:max_mints_per_block"`
+	DeployerOnly       bool   `json:"deployer_only" gorm:"column:deployer_only"`
 }
 
 func (Inscriptions) TableName() string {
@@ -71,6 +78,8 @@ type InscriptionsStats struct {
 	MintFirstBlock    uint64          `gorm:"column:mint_first_block" json:"mint_first_block"`
 	MintLastBlock     uint64          `gorm:"column:mint_last_block" json:"mint_last_block"`
 	LastSN            uint64          `gorm:"column:last_sn" json:"last_sn"`
+	Burned            decimal.Decimal `gorm:"column:burned;type:decimal(38,18)" json:"burned"`   // received by burn addresses
+	Bridged           decimal.Decimal `gorm:"column:bridged;type:decimal(38,18)" json:"bridged"` // minted from tokens locked on another chain
 	Holders           uint64          `gorm:"column:holders" json:"holders"`
 	TxCnt             uint64          `gorm:"column:tx_cnt" json:"tx_cnt"`
 	CreatedAt         time.Time       `gorm:"column:created_at" json:"created_at"`
@@ -109,6 +118,17 @@ type InscriptionOverView struct {
 	Minted       decimal.Decimal `gorm:"column:minted;type:decimal(38,18)" json:"minted"`
 	TxCnt        uint64          `gorm:"column:tx_cnt" json:"tx_cnt"`
 	Progress     decimal.Decimal `gorm:"column:progress;type:decimal(36,18)" json:"progress"` // mint进度
+
+	InscriptionNumber int64           `json:"inscription_number" gorm:"column:inscription_number"`
+	LastSN            uint64          `json:"last_sn" gorm:"column:last_sn"`
+	Burned            decimal.Decimal `gorm:"column:burned;type:decimal(38,18)" json:"burned"`
+	Bridged           decimal.Decimal `gorm:"column:bridged;type:decimal(38,18)" json:"bridged"`
+
+	StartBlock         uint64 `json:"start_block" gorm:"column:start_block"`
+	EndBlock           uint64 `json:"end_block" gorm:"column:end_block"`
+	MaxMintsPerAddress uint64 `json:"max_mints_per_address" gorm:"column:max_mints_per_address"`
+	MaxMintsPerBlock   uint64 `json:"max_mints_per_block" gorm:"column:max_mints_per_block"`
+	DeployerOnly       bool   `json:"deployer_only" gorm:"column:deployer_only"`
 }
 
 type InscriptionBrief struct {
@@ -0,0 +1,63 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package model
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+const (
+	ListingStatusActive    = 1
+	ListingStatusCancelled = 2
+	ListingStatusFilled    = 3
+)
+
+// Listings marketplace order book, a listing is created by the list inscription
+// and closed by the marketplace contract when the order is cancelled or filled
+type Listings struct {
+	ID             uint64          `gorm:"primaryKey" json:"id"`
+	Chain          string          `gorm:"column:chain" json:"chain"`
+	Protocol       string          `gorm:"column:protocol" json:"protocol"`
+	Tick           string          `gorm:"column:tick" json:"tick"`
+	ListId         string          `gorm:"column:list_id" json:"list_id"` // list tx hash
+	Marketplace    string          `gorm:"column:marketplace" json:"marketplace"`
+	Seller         string          `gorm:"column:seller" json:"seller"`
+	Buyer          string          `gorm:"column:buyer" json:"buyer"`
+	Amount         decimal.Decimal `gorm:"column:amount;type:decimal(38,18)" json:"amount"`
+	Price          decimal.Decimal `gorm:"column:price;type:decimal(65,0)" json:"price"`            // total price in wei, known when the order is closed
+	UnitPrice      decimal.Decimal `gorm:"column:unit_price;type:decimal(65,18)" json:"unit_price"` // price / amount
+	Status         int8            `gorm:"column:status" json:"status"`
+	ListTxHash     string          `gorm:"column:list_tx_hash" json:"list_tx_hash"`
+	CloseTxHash    string          `gorm:"column:close_tx_hash" json:"close_tx_hash"`
+	ListBlock      uint64          `gorm:"column:list_block" json:"list_block"`
+	CloseBlock     uint64          `gorm:"column:close_block" json:"close_block"`
+	ListTimestamp  int64           `gorm:"column:list_timestamp" json:"list_timestamp"`
+	CloseTimestamp int64           `gorm:"column:close_timestamp" json:"close_timestamp"`
+	CreatedAt      time.Time       `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt      time.Time       `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (Listings) TableName() string {
+	return "listings"
+}
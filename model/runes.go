@@ -24,27 +24,89 @@ package model
 
 import (
 	"github.com/shopspring/decimal"
+	"time"
+)
+
+const (
+	RuneEventEtch     = "etch"
+	RuneEventMint     = "mint"
+	RuneEventTransfer = "transfer"
+	RuneEventBurn     = "burn"
 )
 
 type Runes struct {
 	Id           int64           `gorm:"primaryKey" json:"id"` // ID
-	Burned       decimal.Decimal `gorm:"burned" json:"burned"`
-	Divisibility int             `gorm:"divisibility" json:"divisibility"`
-	Etching      string          `gorm:"etching" json:"etching"`
-	Mints        int64           `gorm:"mints" json:"mints"`
-	Number       int64           `gorm:"number" json:"number"`
-	Rune         string          `gorm:"rune" json:"rune"`
-	Spacers      int64           `gorm:"spacers" json:"spacers"`
-	Supply       decimal.Decimal `gorm:"supply" json:"supply"`
-	Symbol       string          `gorm:"symbol" json:"symbol"`
-	Deadline     int64           `gorm:"deadline" json:"deadline"` // mint deadline
-	End          int64           `gorm:"end" json:"end"`           // mint end
-	Limit        decimal.Decimal `gorm:"limit" json:"limit"`       // mint limit
-	BlockHeight  int64           `gorm:"block_height" json:"block_height"`
-	Index        int64           `gorm:"index" json:"index"`
-	Timestamp    int64           `gorm:"burned" json:"timestamp"`
+	Chain        string          `gorm:"column:chain" json:"chain"`
+	RuneId       string          `gorm:"column:rune_id" json:"rune_id"` // block:tx
+	Rune         string          `gorm:"column:rune" json:"rune"`
+	SpacedRune   string          `gorm:"column:spaced_rune" json:"spaced_rune"`
+	Number       int64           `gorm:"column:number" json:"number"`
+	Divisibility int             `gorm:"column:divisibility" json:"divisibility"`
+	Spacers      int64           `gorm:"column:spacers" json:"spacers"`
+	Symbol       string          `gorm:"column:symbol" json:"symbol"`
+	Etching      string          `gorm:"column:etching" json:"etching"` // etching tx hash
+	Premine      decimal.Decimal `gorm:"column:premine;type:decimal(40,0)" json:"premine"`
+	Supply       decimal.Decimal `gorm:"column:supply;type:decimal(40,0)" json:"supply"` // premine + mints * limit
+	Burned       decimal.Decimal `gorm:"column:burned;type:decimal(40,0)" json:"burned"`
+	Mints        int64           `gorm:"column:mints" json:"mints"`
+	Cap          decimal.Decimal `gorm:"column:cap;type:decimal(40,0)" json:"cap"`     // mint cap
+	Limit        decimal.Decimal `gorm:"column:limit;type:decimal(40,0)" json:"limit"` // mint amount
+	HeightStart  *uint64         `gorm:"column:height_start" json:"height_start"`
+	HeightEnd    *uint64         `gorm:"column:height_end" json:"height_end"`
+	OffsetStart  *uint64         `gorm:"column:offset_start" json:"offset_start"`
+	OffsetEnd    *uint64         `gorm:"column:offset_end" json:"offset_end"`
+	Turbo        bool            `gorm:"column:turbo" json:"turbo"`
+	BlockHeight  int64           `gorm:"column:block_height" json:"block_height"`
+	Index        int64           `gorm:"column:index" json:"index"` // tx index in block
+	Timestamp    int64           `gorm:"column:timestamp" json:"timestamp"`
+	CreatedAt    time.Time       `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt    time.Time       `gorm:"column:updated_at" json:"updated_at"`
 }
 
 func (Runes) TableName() string {
 	return "runes"
 }
+
+// RuneBalances rune balances held by utxos
+type RuneBalances struct {
+	ID          uint64          `gorm:"primaryKey" json:"id"`
+	Chain       string          `gorm:"column:chain" json:"chain"`
+	RuneId      string          `gorm:"column:rune_id" json:"rune_id"`
+	Outpoint    string          `gorm:"column:outpoint" json:"outpoint"` // txid:vout
+	Address     string          `gorm:"column:address" json:"address"`
+	Amount      decimal.Decimal `gorm:"column:amount;type:decimal(40,0)" json:"amount"`
+	Status      int8            `gorm:"column:status" json:"status"` // utxo status
+	BlockHeight uint64          `gorm:"column:block_height" json:"block_height"`
+	CreatedAt   time.Time       `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt   time.Time       `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (RuneBalances) TableName() string {
+	return "rune_balances"
+}
+
+// RuneEvents rune etch/mint/transfer/burn history
+type RuneEvents struct {
+	ID          uint64          `gorm:"primaryKey" json:"id"`
+	Chain       string          `gorm:"column:chain" json:"chain"`
+	RuneId      string          `gorm:"column:rune_id" json:"rune_id"`
+	Event       string          `gorm:"column:event" json:"event"`
+	TxHash      string          `gorm:"column:tx_hash" json:"tx_hash"`
+	Vout        int64           `gorm:"column:vout" json:"vout"` // receiving output, -1 if none
+	Address     string          `gorm:"column:address" json:"address"`
+	Amount      decimal.Decimal `gorm:"column:amount;type:decimal(40,0)" json:"amount"`
+	BlockHeight uint64          `gorm:"column:block_height" json:"block_height"`
+	CreatedAt   time.Time       `gorm:"column:created_at" json:"created_at"`
+}
+
+func (RuneEvents) TableName() string {
+	return "rune_events"
+}
+
+// RuneHolding aggregated unspent rune balance of an address
+type RuneHolding struct {
+	RuneId  string          `json:"rune_id"`
+	Address string          `json:"address"`
+	Amount  decimal.Decimal `json:"amount"`
+	Utxos   int64           `json:"utxos"`
+}
@@ -43,6 +43,12 @@ const (
 	TransactionEventDelist           TxEvent = 5
 	TransactionEventExchange         TxEvent = 6
 	TransactionEventInscribeTransfer TxEvent = 7
+	TransactionEventCreate           TxEvent = 8
+	TransactionEventBridgeLock       TxEvent = 9
+	TransactionEventBridgeRelease    TxEvent = 10
+	TransactionEventBridgeMint       TxEvent = 11
+	TransactionEventFreeze           TxEvent = 12
+	TransactionEventUnfreeze         TxEvent = 13
 )
 
 type TransactionRaw struct {
@@ -125,6 +131,10 @@ type Transaction struct {
 	CreatedAt       time.Time       `json:"created_at" gorm:"column:created_at"`
 	UpdatedAt       time.Time       `json:"updated_at" gorm:"column:updated_at"`
 	Content         string          `json:"content" gorm:"column:content"` // content
+
+	InscriptionNumber int64  `json:"inscription_number" gorm:"column:inscription_number"` // chain-wide sequence in block & tx index order, starts at 0
+	MintSN            uint64 `json:"mint_sn" gorm:"column:mint_sn"`                       // per-tick mint sequence, starts at 1
+	SubIndex          int    `json:"sub_index" gorm:"column:sub_index"`                   // smart account inner call position within the tx
 }
 
 func (Transaction) TableName() string {
@@ -0,0 +1,48 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package model
+
+import (
+	"github.com/shopspring/decimal"
+	"time"
+)
+
+// Wrappers inscription tokens locked in a wrapper address against the supply of its erc-20 contract
+type Wrappers struct {
+	ID          uint64          `gorm:"primaryKey" json:"id"`
+	Chain       string          `gorm:"column:chain" json:"chain"`
+	Name        string          `gorm:"column:name" json:"name"`
+	Protocol    string          `gorm:"column:protocol" json:"protocol"`
+	Tick        string          `gorm:"column:tick" json:"tick"`
+	Address     string          `gorm:"column:address" json:"address"`
+	Contract    string          `gorm:"column:contract" json:"contract"`
+	Supply      decimal.Decimal `gorm:"column:supply;type:decimal(38,18)" json:"supply"`
+	Locked      decimal.Decimal `gorm:"column:locked;type:decimal(38,18)" json:"locked"`
+	BlockHeight uint64          `gorm:"column:block_height" json:"block_height"`
+	CreatedAt   time.Time       `gorm:"column:created_at" json:"created_at"`
+	UpdatedAt   time.Time       `gorm:"column:updated_at" json:"updated_at"`
+}
+
+func (Wrappers) TableName() string {
+	return "wrappers"
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/shopspring/decimal"
 	"github.com/uxuycom/indexer/client/xycommon"
 	"github.com/uxuycom/indexer/devents"
+	protocommon "github.com/uxuycom/indexer/protocol/common"
 	"github.com/uxuycom/indexer/utils"
 	"github.com/uxuycom/indexer/xyerrors"
 	"github.com/uxuycom/indexer/xylog"
@@ -51,6 +52,11 @@ type Exchange struct {
 	From    string
 	To      string
 	Amount  decimal.Decimal
+
+	// order book fields, only set for marketplace orders
+	ListId string
+	Seller string
+	Price  decimal.Decimal
 }
 
 // ASC20Order is an auto generated low-level Go binding around an user-defined struct.
@@ -65,21 +71,24 @@ type ASC20Order struct {
 }
 
 func (p *Protocol) Exchange(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, omd *devents.MetaData) (items []*devents.TxResult, err *xyerrors.InsError) {
+	// configured marketplaces & bridges, the orders below are verified against their balance changes
+	deltas := make(protocommon.TxDeltas)
+	items = p.common.Exchange(block, tx, omd, deltas)
+
 	// extract valid orders
 	exchanges := p.extractValidOrders(tx)
 	if len(exchanges) <= 0 {
-		return nil, nil
+		return items, nil
 	}
-
-	items = make([]*devents.TxResult, 0, len(exchanges))
 	for _, exchange := range exchanges {
 		md := omd.Copy()
 		md.Operate = exchange.Operate
 		md.Tick = strings.ToLower(strings.TrimSpace(exchange.Tick))
-		if err1 := p.verifyExchange(md, exchange); err1 != nil {
+		if err1 := p.verifyExchange(md, exchange, deltas.Get(md.Protocol, md.Tick, exchange.From)); err1 != nil {
 			xylog.Logger.Infof("exchange verified failed, err:%v, data:%v", err1, exchange)
 			continue
 		}
+		deltas.Move(md.Protocol, md.Tick, exchange.From, exchange.To, exchange.Amount)
 
 		item := &devents.TxResult{
 			MD:    md,
@@ -95,6 +104,16 @@ func (p *Protocol) Exchange(block *xycommon.RpcBlock, tx *xycommon.RpcTransactio
 				},
 			},
 		}
+		if exchange.ListId != "" {
+			item.Listing = &devents.Listing{
+				ListId:      exchange.ListId,
+				Marketplace: exchange.From,
+				Seller:      exchange.Seller,
+				Buyer:       exchange.To,
+				Amount:      exchange.Amount,
+				Price:       exchange.Price,
+			}
+		}
 		items = append(items, item)
 	}
 	return
@@ -103,6 +122,7 @@ func (p *Protocol) Exchange(block *xycommon.RpcBlock, tx *xycommon.RpcTransactio
 func (p *Protocol) parseOrderByExchange(e xycommon.RpcLog, orders map[string]*ASC20Order) (*Exchange, *xyerrors.InsError) {
 	order, ok := orders[e.Data.String()]
 	if !ok {
+		xylog.Logger.Infof("tx[%s] - exchange event list id[%s] matches no input order", e.TxHash, e.Data)
 		return nil, nil
 	}
 
@@ -110,12 +130,20 @@ func (p *Protocol) parseOrderByExchange(e xycommon.RpcLog, orders map[string]*AS
 		return nil, xyerrors.NewInsError(-17, fmt.Sprintf("order amount value empty, ticker[%v]", order.Ticker))
 	}
 
+	price := decimal.Zero
+	if order.Price != nil {
+		price = decimal.NewFromBigInt(order.Price, 0)
+	}
+
 	return &Exchange{
 		Operate: order.Operate,
 		Tick:    order.Ticker,
 		From:    e.Address.String(),
 		To:      common.BytesToAddress(e.Topics[2].Bytes()).String(),
 		Amount:  decimal.NewFromBigInt(order.Amount, 0),
+		ListId:  common.BytesToHash(order.ListId[:]).String(),
+		Seller:  order.Seller.String(),
+		Price:   price,
 	}, nil
 }
 
@@ -270,7 +298,8 @@ func (p *Protocol) extractValidOrdersByExchange(tx *xycommon.RpcTransaction) []*
 	return items
 }
 
-func (p *Protocol) verifyExchange(md *devents.MetaData, e *Exchange) *xyerrors.InsError {
+// verifyExchange checks the sender balance, delta is the sender balance change of the previous events of the tx
+func (p *Protocol) verifyExchange(md *devents.MetaData, e *Exchange, delta decimal.Decimal) *xyerrors.InsError {
 	var (
 		protocol = md.Protocol
 		tick     = md.Tick
@@ -280,15 +309,17 @@ func (p *Protocol) verifyExchange(md *devents.MetaData, e *Exchange) *xyerrors.I
 		return xyerrors.NewInsError(-15, fmt.Sprintf("inscription not exist, protocol[%s]-tick[%s]", protocol, tick))
 	}
 
-	// sender balance checking
-	ok, balance := p.cache.Balance.Get(protocol, tick, e.From)
-	if !ok {
+	// sender balance checking, the sender may only hold what earlier events of the tx sent
+	available := delta
+	if ok, balance := p.cache.Balance.Get(protocol, tick, e.From); ok {
+		available = available.Add(balance.Available)
+	} else if delta.LessThanOrEqual(decimal.Zero) {
 		return xyerrors.NewInsError(-16, fmt.Sprintf("sender balance record not exist, tick[%s-%s], address[%s]", protocol, tick, e.From))
 	}
 
-	// balance available checking
-	if balance.Overall.LessThan(e.Amount) {
-		return xyerrors.NewInsError(-17, fmt.Sprintf("sender total balance[%v] < transfer amount[%v]", balance.Overall, e.Amount))
+	// balance available checking, locked balances can't be spent
+	if available.LessThan(e.Amount) {
+		return xyerrors.NewInsError(-17, fmt.Sprintf("sender available balance[%v] < transfer amount[%v]", available, e.Amount))
 	}
 	return nil
 }
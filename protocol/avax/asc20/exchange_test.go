@@ -85,7 +85,7 @@ func TestExtractInputOrders_cancelOrdersVerify(t *testing.T) {
 	}
 
 	cache := dcache.NewManager(nil, "avax")
-	protocol := NewProtocol(cache)
+	protocol := NewProtocol(cache, nil, nil, nil)
 
 	results := protocol.extractInputOrders("", "0x7b2c304d00000000000000000000000000000000000000000000000000000000000000200000000000000000000000000000000000000000000000000000000000000003000000000000000000000000000000000000000000000000000000000000006000000000000000000000000000000000000000000000000000000000000002a000000000000000000000000000000000000000000000000000000000000004e00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000050cf0e5438354c45bcaf1689916a6ae39a2198059045bb79275c718d4fce7a5d00000000000000000000000000000000000000000000000000000000000001e0000000000000000000000000000000000000000000000000000000037e11d600000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000022000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000046176617800000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000dcf1bc942bb158a669e6ce4bf8714c06aaaf19abbd96c08f5e759f9ca696fda800000000000000000000000000000000000000000000000000000000000001e000000000000000000000000000000000000000000000000000000003b9aca00000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000002200000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000004617661760000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000084b6f0bd44aba8c87e416c91e0874a6b1d4a4b9eb23a7aec6a93860e3e19ded500000000000000000000000000000000000000000000000000000000000001e00000000000000000000000000000000000000000000000000000000430e234000000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000220000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000478787979000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000")
 
@@ -130,7 +130,7 @@ func TestExtractInputOrders_batchMatchOrdersVerify(t *testing.T) {
 	}
 
 	cache := dcache.NewManager(nil, "avax")
-	protocol := NewProtocol(cache)
+	protocol := NewProtocol(cache, nil, nil, nil)
 
 	results := protocol.extractInputOrders("", "0x24608215000000000000000000000000000000000000000000000000000000000000004000000000000000000000000024e24277e2ff8828d5d2e278764ca258c22bd4970000000000000000000000000000000000000000000000000000000000000003000000000000000000000000000000000000000000000000000000000000006000000000000000000000000000000000000000000000000000000000000002a000000000000000000000000000000000000000000000000000000000000004e00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000050cf0e5438354c45bcaf1689916a6ae39a2198059045bb79275c718d4fce7a5d00000000000000000000000000000000000000000000000000000000000001e0000000000000000000000000000000000000000000000000000000037e11d600000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000022000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000046176617800000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000dcf1bc942bb158a669e6ce4bf8714c06aaaf19abbd96c08f5e759f9ca696fda800000000000000000000000000000000000000000000000000000000000001e000000000000000000000000000000000000000000000000000000003b9aca00000000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000002200000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000004617661760000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000084b6f0bd44aba8c87e416c91e0874a6b1d4a4b9eb23a7aec6a93860e3e19ded500000000000000000000000000000000000000000000000000000000000001e00000000000000000000000000000000000000000000000000000000430e234000000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000220000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000478787979000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000")
 
@@ -287,7 +287,7 @@ func TestExtractValidOrdersByExchange(t *testing.T) {
 	}
 
 	cache := dcache.NewManager(nil, "avax")
-	protocol := NewProtocol(cache)
+	protocol := NewProtocol(cache, nil, nil, nil)
 	for _, test := range tests {
 		t.Run(test.Name, func(t *testing.T) {
 			result := protocol.extractValidOrdersByExchange(test.Tx)
@@ -342,7 +342,7 @@ func TestExtractValidOrdersByTransfer(t *testing.T) {
 
 	cache := dcache.NewManager(nil, "avax")
 	cache.Inscription = dcache.NewInscription()
-	protocol := NewProtocol(cache)
+	protocol := NewProtocol(cache, nil, nil, nil)
 	for _, test := range tests {
 		t.Run(test.Name, func(t *testing.T) {
 			for _, tick := range test.Tickers {
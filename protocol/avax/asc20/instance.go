@@ -27,7 +27,9 @@ import (
 	"github.com/uxuycom/indexer/client/xycommon"
 	"github.com/uxuycom/indexer/dcache"
 	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol/bridge"
 	"github.com/uxuycom/indexer/protocol/common"
+	"github.com/uxuycom/indexer/protocol/marketplace"
 	"github.com/uxuycom/indexer/protocol/types"
 	"github.com/uxuycom/indexer/xyerrors"
 	"github.com/uxuycom/indexer/xylog"
@@ -43,9 +45,9 @@ type Protocol struct {
 
 var ParsedABI abi.ABI
 
-func NewProtocol(cache *dcache.Manager) *Protocol {
+func NewProtocol(cache *dcache.Manager, rules *types.RuleSchedule, markets *marketplace.Registry, bridges *bridge.Registry) *Protocol {
 	return &Protocol{
-		common: common.NewProtocol(cache),
+		common: common.NewProtocol(cache, rules, markets, bridges),
 		cache:  cache,
 		ticks:  &sync.Map{},
 	}
@@ -57,7 +59,9 @@ func (p *Protocol) Parse(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction,
 		return p.List(block, tx, md)
 
 	case devents.OperateExchange:
-		return p.Exchange(block, tx, md)
+		items, err := p.Exchange(block, tx, md)
+		p.common.MarkBurns(block, items)
+		return items, err
 	}
 	return p.common.Parse(block, tx, md)
 }
@@ -28,15 +28,16 @@ import (
 	"github.com/shopspring/decimal"
 	"github.com/uxuycom/indexer/client/xycommon"
 	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol/types"
 	"github.com/uxuycom/indexer/xyerrors"
 )
 
 type List struct {
-	Amount decimal.Decimal `json:"amt"`
+	Amount types.Amount `json:"amt"`
 }
 
 func (p *Protocol) List(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) ([]*devents.TxResult, *xyerrors.InsError) {
-	list, err := p.verifyList(tx, md)
+	list, err := p.verifyList(block, tx, md)
 	if err != nil {
 		return nil, xyerrors.ErrDataVerifiedFailed.WrapCause(err)
 	}
@@ -49,15 +50,21 @@ func (p *Protocol) List(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, m
 			Receives: []*devents.Receive{
 				{
 					Address: tx.To,
-					Amount:  list.Amount,
+					Amount:  list.Amount.Decimal,
 				},
 			},
 		},
+		Listing: &devents.Listing{
+			ListId:      tx.Hash,
+			Marketplace: tx.To,
+			Seller:      tx.From,
+			Amount:      list.Amount.Decimal,
+		},
 	}
 	return []*devents.TxResult{result}, nil
 }
 
-func (p *Protocol) verifyList(tx *xycommon.RpcTransaction, md *devents.MetaData) (*List, *xyerrors.InsError) {
+func (p *Protocol) verifyList(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) (*List, *xyerrors.InsError) {
 	tf := &List{}
 	err := json.Unmarshal([]byte(md.Data), tf)
 	if err != nil {
@@ -77,15 +84,20 @@ func (p *Protocol) verifyList(tx *xycommon.RpcTransaction, md *devents.MetaData)
 		return nil, xyerrors.NewInsError(-15, fmt.Sprintf("inscription not exist, protocol[%s]-tick[%s]", protocol, tick))
 	}
 
+	// list amount fraction digits within decimals
+	if err := p.common.RuleSet(block, protocol).CheckAmount(tf.Amount, inscription.Decimals); err != nil {
+		return nil, xyerrors.NewInsError(-18, err.Error())
+	}
+
 	// sender balance checking
 	ok, balance := p.cache.Balance.Get(protocol, tick, tx.From)
 	if !ok {
 		return nil, xyerrors.NewInsError(-16, fmt.Sprintf("sender balance record not exist, tick[%s-%s], address[%s]", protocol, tick, tx.From))
 	}
 
-	// balance available checking
-	if balance.Overall.LessThan(tf.Amount) {
-		return nil, xyerrors.NewInsError(-17, fmt.Sprintf("sender total balance[%v] < transfer amount[%v]", balance.Overall, tf.Amount))
+	// balance available checking, locked balances can't be spent
+	if balance.Available.LessThan(tf.Amount.Decimal) {
+		return nil, xyerrors.NewInsError(-17, fmt.Sprintf("sender available balance[%v] < transfer amount[%v]", balance.Available, tf.Amount))
 	}
 	return tf, nil
 }
@@ -0,0 +1,127 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package blob
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/uxuycom/indexer/utils"
+)
+
+const (
+	// Size eip-4844 blob size, 4096 field elements of 32 bytes
+	Size = 131072
+
+	fieldElementSize = 32
+
+	// terminator marks the end of the payload, the rest of the last blob is zero padded
+	terminator = 0x80
+
+	// MaxPayloadLength maximum decoded (decompressed) payload size, 6 blobs of a block
+	MaxPayloadLength = 6 * Size
+)
+
+// Decode the inscription data carried in the blobs of a tx, in the blob versioned hashes order.
+// Each field element carries 31 bytes after a zero byte, the payload ends with the 0x80 terminator.
+// Data uris (ESIP-7 gzip compressed included) are returned as is, ESIP-8 cbor objects of
+// contentType & content are converted to the equivalent data uri
+func Decode(blobs [][]byte) ([]byte, error) {
+	if len(blobs) == 0 {
+		return nil, fmt.Errorf("blobs empty")
+	}
+
+	payload := make([]byte, 0, len(blobs)*Size)
+	for idx, blob := range blobs {
+		if len(blob) != Size {
+			return nil, fmt.Errorf("blob[%d] size[%d] invalid", idx, len(blob))
+		}
+		for i := 0; i < Size; i += fieldElementSize {
+			if blob[i] != 0 {
+				return nil, fmt.Errorf("blob[%d] field element[%d] not zero prefixed", idx, i/fieldElementSize)
+			}
+			payload = append(payload, blob[i+1:i+fieldElementSize]...)
+		}
+	}
+
+	end := bytes.LastIndexFunc(payload, func(r rune) bool { return r != 0 })
+	if end == -1 || payload[end] != terminator {
+		return nil, fmt.Errorf("blob payload terminator not found")
+	}
+	return decodePayload(payload[:end])
+}
+
+// Encode the payload into blobs, the inverse of Decode
+func Encode(payload []byte) ([][]byte, error) {
+	const chunk = fieldElementSize - 1
+	data := append(append(make([]byte, 0, len(payload)+1), payload...), terminator)
+	if len(data) > MaxPayloadLength/fieldElementSize*chunk {
+		return nil, fmt.Errorf("payload size[%d] exceeds the blobs of a block", len(payload))
+	}
+
+	blobs := make([][]byte, 0, 1)
+	for len(data) > 0 {
+		blob := make([]byte, Size)
+		for i := 0; i < Size && len(data) > 0; i += fieldElementSize {
+			n := copy(blob[i+1:i+fieldElementSize], data)
+			data = data[n:]
+		}
+		blobs = append(blobs, blob)
+	}
+	return blobs, nil
+}
+
+func decodePayload(payload []byte) ([]byte, error) {
+	data := payload
+	if utils.IsGzip(payload) {
+		var err error
+		if data, err = utils.Gunzip(payload, MaxPayloadLength); err != nil {
+			return nil, fmt.Errorf("blob payload %v", err)
+		}
+	}
+
+	// keep compressed data uris, the metadata parsing applies the ESIP-7 rules
+	if bytes.HasPrefix(data, []byte("data:")) {
+		return payload, nil
+	}
+
+	item, err := decodeCBOR(data)
+	if err != nil {
+		return nil, fmt.Errorf("blob payload neither data uri nor cbor, err:%v", err)
+	}
+
+	obj, ok := item.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("blob payload cbor is not a map")
+	}
+	contentType, _ := obj["contentType"].(string)
+	var content []byte
+	switch v := obj["content"].(type) {
+	case []byte:
+		content = v
+	case string:
+		content = []byte(v)
+	default:
+		return nil, fmt.Errorf("blob payload content not found")
+	}
+	return append([]byte("data:"+contentType+","), content...), nil
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package blob
+
+import (
+	"bytes"
+	"compress/gzip"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestDecode(t *testing.T) {
+	// data uri spanning two blobs
+	uri := []byte(`data:,{"p":"asc-20","op":"mint","tick":"blob","amt":"1000","memo":"` + strings.Repeat("x", Size) + `"}`)
+	blobs, err := Encode(uri)
+	assert.NoError(t, err)
+	assert.Len(t, blobs, 2)
+	payload, err := Decode(blobs)
+	assert.NoError(t, err)
+	assert.Equal(t, uri, payload)
+
+	// gzip compressed data uris are kept compressed
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, _ = w.Write(uri)
+	_ = w.Close()
+	blobs, err = Encode(buf.Bytes())
+	assert.NoError(t, err)
+	payload, err = Decode(blobs)
+	assert.NoError(t, err)
+	assert.Equal(t, buf.Bytes(), payload)
+
+	// ESIP-8 cbor {"contentType":"application/json","content":h'...'}
+	content := []byte(`{"p":"asc-20","op":"mint","tick":"blob","amt":"1"}`)
+	cbor := append([]byte{0xa2, 0x6b}, "contentType"...)
+	cbor = append(append(cbor, 0x70), "application/json"...)
+	cbor = append(append(cbor, 0x67), "content"...)
+	cbor = append(append(cbor, 0x58, byte(len(content))), content...)
+	blobs, err = Encode(cbor)
+	assert.NoError(t, err)
+	payload, err = Decode(blobs)
+	assert.NoError(t, err)
+	assert.Equal(t, "data:application/json,"+string(content), string(payload))
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	blobs, err := Encode([]byte("data:,hello"))
+	assert.NoError(t, err)
+
+	_, err = Decode(nil)
+	assert.Error(t, err)
+
+	// truncated blob
+	_, err = Decode([][]byte{blobs[0][:Size-1]})
+	assert.Error(t, err)
+
+	// non canonical field element
+	invalid := append([]byte(nil), blobs[0]...)
+	invalid[32] = 1
+	_, err = Decode([][]byte{invalid})
+	assert.Error(t, err)
+
+	// missing terminator
+	_, err = Decode([][]byte{make([]byte, Size)})
+	assert.Error(t, err)
+
+	// neither data uri nor cbor map
+	blobs, err = Encode([]byte("hello"))
+	assert.NoError(t, err)
+	_, err = Decode(blobs)
+	assert.Error(t, err)
+
+	// cbor map without content, truncated cbor
+	for _, payload := range [][]byte{{0xa0}, {0xa1, 0x67, 'c', 'o', 'n'}} {
+		blobs, err = Encode(payload)
+		assert.NoError(t, err)
+		_, err = Decode(blobs)
+		assert.Error(t, err)
+	}
+
+	_, err = Encode(make([]byte, MaxPayloadLength))
+	assert.Error(t, err)
+}
@@ -0,0 +1,159 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package blob
+
+import (
+	"fmt"
+)
+
+// maxCBORDepth nesting limit of the decoded cbor items
+const maxCBORDepth = 16
+
+// decodeCBOR rfc 8949 subset of ESIP-8 payloads: definite length integers, byte / text strings,
+// arrays, maps of text keys & simple values
+func decodeCBOR(data []byte) (interface{}, error) {
+	d := &cborDecoder{data: data}
+	item, err := d.item(0)
+	if err != nil {
+		return nil, err
+	}
+	if d.pos != len(d.data) {
+		return nil, fmt.Errorf("cbor trailing bytes[%d]", len(d.data)-d.pos)
+	}
+	return item, nil
+}
+
+type cborDecoder struct {
+	data []byte
+	pos  int
+}
+
+func (d *cborDecoder) read(n uint64) ([]byte, error) {
+	if n > uint64(len(d.data)-d.pos) {
+		return nil, fmt.Errorf("cbor unexpected end of data")
+	}
+	b := d.data[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return b, nil
+}
+
+// head the major type, additional info & argument of the next item
+func (d *cborDecoder) head() (byte, byte, uint64, error) {
+	b, err := d.read(1)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	major, info := b[0]>>5, b[0]&0x1f
+	switch {
+	case info < 24:
+		return major, info, uint64(info), nil
+	case info <= 27:
+		arg, err := d.read(1 << (info - 24))
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		var v uint64
+		for _, c := range arg {
+			v = v<<8 | uint64(c)
+		}
+		return major, info, v, nil
+	}
+	return 0, 0, 0, fmt.Errorf("cbor additional info[%d] not supported", info)
+}
+
+func (d *cborDecoder) item(depth int) (interface{}, error) {
+	if depth > maxCBORDepth {
+		return nil, fmt.Errorf("cbor nesting > %d", maxCBORDepth)
+	}
+
+	major, info, arg, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case 0:
+		return arg, nil
+	case 1:
+		return -1 - int64(arg), nil
+	case 2:
+		b, err := d.read(arg)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), b...), nil
+	case 3:
+		b, err := d.read(arg)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case 4:
+		if arg > uint64(len(d.data)-d.pos) {
+			return nil, fmt.Errorf("cbor array length[%d] invalid", arg)
+		}
+		items := make([]interface{}, 0, arg)
+		for i := uint64(0); i < arg; i++ {
+			item, err := d.item(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case 5:
+		if arg > uint64(len(d.data)-d.pos) {
+			return nil, fmt.Errorf("cbor map length[%d] invalid", arg)
+		}
+		items := make(map[string]interface{}, arg)
+		for i := uint64(0); i < arg; i++ {
+			key, err := d.item(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			k, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("cbor map key is not a text string")
+			}
+			if items[k], err = d.item(depth + 1); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	case 7:
+		// floats are not supported
+		if info >= 24 {
+			break
+		}
+		switch arg {
+		case 20:
+			return false, nil
+		case 21:
+			return true, nil
+		case 22, 23:
+			return nil, nil
+		}
+	}
+	return nil, fmt.Errorf("cbor major type[%d] argument[%d] not supported", major, arg)
+}
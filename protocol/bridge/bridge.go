@@ -0,0 +1,379 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package bridge
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol/types"
+	"github.com/uxuycom/indexer/utils"
+	"github.com/uxuycom/indexer/xylog"
+	"math/big"
+	"os"
+	"strings"
+)
+
+// bridge event kinds
+const (
+	KindLock    = "lock"
+	KindRelease = "release"
+	KindMint    = "mint"
+)
+
+// Transfer bridge transfer side decoded from a bridge event
+type Transfer struct {
+	Bridge   string
+	Protocol string // protocol of the bridge adapter
+	Index    int    // log position of the event in the tx
+	Contract string // bridge contract, the holder of the locked tokens
+	Kind     string
+	Id       string
+	Tick     string // tick name, or the tick hash when TickIdx is set
+	TickIdx  bool
+	From     string
+	To       string
+	Amount   decimal.Decimal
+	Chain    string // counterpart chain, empty if the event has none
+}
+
+type event struct {
+	kind   string
+	event  abi.Event
+	fields config.BridgeFields
+}
+
+// Adapter maps the events of a bridge contract to bridge transfers
+type Adapter struct {
+	name     string
+	protocol string
+	contract common.Address
+	abi      abi.ABI
+	events   []*event
+	decimals int32
+}
+
+func NewAdapter(cfg *config.BridgeConfig) (*Adapter, error) {
+	if !common.IsHexAddress(cfg.Contract) {
+		return nil, fmt.Errorf("bridge[%s] invalid contract[%s]", cfg.Name, cfg.Contract)
+	}
+
+	file, err := os.Open(cfg.AbiFile)
+	if err != nil {
+		return nil, fmt.Errorf("bridge[%s] abi file open err:%v", cfg.Name, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	parsedABI, err := abi.JSON(file)
+	if err != nil {
+		return nil, fmt.Errorf("bridge[%s] abi decode err:%v", cfg.Name, err)
+	}
+	return newAdapter(cfg, parsedABI)
+}
+
+func newAdapter(cfg *config.BridgeConfig, parsedABI abi.ABI) (*Adapter, error) {
+	if strings.TrimSpace(cfg.Name) == "" {
+		return nil, fmt.Errorf("bridge contract[%s] name empty", cfg.Contract)
+	}
+
+	a := &Adapter{
+		name:     cfg.Name,
+		protocol: strings.ToLower(strings.TrimSpace(cfg.Protocol)),
+		contract: common.HexToAddress(cfg.Contract),
+		abi:      parsedABI,
+		decimals: cfg.Decimals,
+	}
+	if a.protocol == "" {
+		a.protocol = types.ASC20Protocol
+	}
+
+	kinds := []struct {
+		kind     string
+		cfg      *config.BridgeEventConfig
+		required []string
+	}{
+		{KindLock, cfg.Lock, []string{"id", "tick", "from", "amount"}},
+		{KindRelease, cfg.Release, []string{"id", "tick", "to", "amount"}},
+		{KindMint, cfg.Mint, []string{"id", "tick", "to", "amount"}},
+	}
+	for _, item := range kinds {
+		if item.cfg == nil {
+			continue
+		}
+
+		e, ok := parsedABI.Events[item.cfg.Event]
+		if !ok {
+			return nil, fmt.Errorf("bridge[%s] %s event[%s] not found in abi", cfg.Name, item.kind, item.cfg.Event)
+		}
+
+		fields := map[string]string{
+			"id":     item.cfg.Fields.Id,
+			"tick":   item.cfg.Fields.Tick,
+			"from":   item.cfg.Fields.From,
+			"to":     item.cfg.Fields.To,
+			"amount": item.cfg.Fields.Amount,
+			"chain":  item.cfg.Fields.Chain,
+		}
+		for _, key := range []string{"id", "tick", "from", "to", "amount", "chain"} {
+			name := fields[key]
+			if name == "" {
+				if contains(item.required, key) {
+					return nil, fmt.Errorf("bridge[%s] %s event %s field empty", cfg.Name, item.kind, key)
+				}
+				continue
+			}
+			if !hasInput(e, name) {
+				return nil, fmt.Errorf("bridge[%s] %s field[%s] not found in event[%s]", cfg.Name, key, name, item.cfg.Event)
+			}
+		}
+		a.events = append(a.events, &event{kind: item.kind, event: e, fields: item.cfg.Fields})
+	}
+
+	if len(a.events) <= 0 {
+		return nil, fmt.Errorf("bridge[%s] no events configured", cfg.Name)
+	}
+	return a, nil
+}
+
+func contains(items []string, value string) bool {
+	for _, item := range items {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+func hasInput(e abi.Event, name string) bool {
+	for _, input := range e.Inputs {
+		if input.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Adapter) Protocol() string {
+	return a.protocol
+}
+
+// Topics the event signature hashes
+func (a *Adapter) Topics() []common.Hash {
+	topics := make([]common.Hash, 0, len(a.events))
+	for _, e := range a.events {
+		topics = append(topics, e.event.ID)
+	}
+	return topics
+}
+
+func (a *Adapter) match(log *xycommon.RpcLog) *event {
+	if len(log.Topics) <= 0 || log.Address != a.contract {
+		return nil
+	}
+
+	for _, e := range a.events {
+		if log.Topics[0] == e.event.ID {
+			return e
+		}
+	}
+	return nil
+}
+
+// Decode the matched log into a bridge transfer
+func (a *Adapter) Decode(log *xycommon.RpcLog) (*Transfer, error) {
+	e := a.match(log)
+	if e == nil {
+		return nil, fmt.Errorf("log is not a bridge[%s] event", a.name)
+	}
+
+	values := make(map[string]interface{}, len(e.event.Inputs))
+	_, err := utils.ParseEventToMap(a.abi, utils.EventLog{
+		Address: log.Address,
+		Topics:  log.Topics,
+		Data:    log.Data,
+	}, values)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Transfer{
+		Bridge:   a.name,
+		Contract: strings.ToLower(a.contract.String()),
+		Kind:     e.kind,
+	}
+	if t.Id, err = idValue(values[e.fields.Id]); err != nil {
+		return nil, fmt.Errorf("id field[%s] err:%v", e.fields.Id, err)
+	}
+
+	switch v := values[e.fields.Tick].(type) {
+	case string:
+		t.Tick = strings.ToLower(strings.TrimSpace(v))
+	case common.Hash:
+		t.Tick, t.TickIdx = v.String(), true
+	case [32]byte:
+		t.Tick, t.TickIdx = common.Hash(v).String(), true
+	default:
+		return nil, fmt.Errorf("tick field[%s] type %T unsupported", e.fields.Tick, v)
+	}
+
+	if e.fields.From != "" {
+		if t.From, err = addressValue(values[e.fields.From]); err != nil {
+			return nil, fmt.Errorf("from field[%s] err:%v", e.fields.From, err)
+		}
+	}
+	if e.fields.To != "" {
+		if t.To, err = addressValue(values[e.fields.To]); err != nil {
+			return nil, fmt.Errorf("to field[%s] err:%v", e.fields.To, err)
+		}
+	}
+	if e.fields.Chain != "" {
+		if t.Chain, err = idValue(values[e.fields.Chain]); err != nil {
+			return nil, fmt.Errorf("chain field[%s] err:%v", e.fields.Chain, err)
+		}
+	}
+
+	amount, ok := values[e.fields.Amount].(*big.Int)
+	if !ok || amount == nil {
+		return nil, fmt.Errorf("amount field[%s] type %T unsupported", e.fields.Amount, values[e.fields.Amount])
+	}
+	t.Amount = decimal.NewFromBigInt(amount, -a.decimals)
+	return t, nil
+}
+
+func addressValue(v interface{}) (string, error) {
+	switch addr := v.(type) {
+	case common.Address:
+		return strings.ToLower(addr.String()), nil
+	case string:
+		if common.IsHexAddress(addr) {
+			return strings.ToLower(addr), nil
+		}
+	}
+	return "", fmt.Errorf("type %T value[%v] is not an address", v, v)
+}
+
+// idValue ids & chains of string, bytes32 or uint types
+func idValue(v interface{}) (string, error) {
+	switch id := v.(type) {
+	case string:
+		if id = strings.TrimSpace(id); id != "" {
+			return id, nil
+		}
+	case common.Hash:
+		return id.String(), nil
+	case [32]byte:
+		return common.Hash(id).String(), nil
+	case *big.Int:
+		if id != nil {
+			return id.String(), nil
+		}
+	case uint64:
+		return fmt.Sprintf("%d", id), nil
+	case uint32:
+		return fmt.Sprintf("%d", id), nil
+	}
+	return "", fmt.Errorf("type %T value[%v] unsupported", v, v)
+}
+
+// Registry configured bridge adapters of the chain
+type Registry struct {
+	adapters []*Adapter
+}
+
+func NewRegistry(items []*config.BridgeConfig) (*Registry, error) {
+	r := &Registry{
+		adapters: make([]*Adapter, 0, len(items)),
+	}
+	for _, item := range items {
+		adapter, err := NewAdapter(item)
+		if err != nil {
+			return nil, err
+		}
+		r.adapters = append(r.adapters, adapter)
+	}
+	return r, nil
+}
+
+// Topics the event topics of all bridges, used to filter the chain logs
+func (r *Registry) Topics() []common.Hash {
+	if r == nil {
+		return nil
+	}
+
+	topics := make([]common.Hash, 0, len(r.adapters))
+	for _, adapter := range r.adapters {
+		topics = append(topics, adapter.Topics()...)
+	}
+	return topics
+}
+
+// ParseMetaData bridge metadata of the first bridge event in the tx, Transfers decodes the events of all bridges
+func (r *Registry) ParseMetaData(chain string, tx *xycommon.RpcTransaction) *devents.MetaData {
+	if r == nil {
+		return nil
+	}
+
+	for i := range tx.Events {
+		for _, adapter := range r.adapters {
+			if adapter.match(&tx.Events[i]) != nil {
+				return &devents.MetaData{
+					Chain:    chain,
+					Protocol: adapter.protocol,
+					Operate:  devents.OperateBridge,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Transfers decode the events of every bridge in the tx, in log order
+func (r *Registry) Transfers(tx *xycommon.RpcTransaction) []*Transfer {
+	if r == nil {
+		return nil
+	}
+
+	items := make([]*Transfer, 0, len(tx.Events))
+	for i := range tx.Events {
+		for _, adapter := range r.adapters {
+			if adapter.match(&tx.Events[i]) == nil {
+				continue
+			}
+
+			item, err := adapter.Decode(&tx.Events[i])
+			if err != nil {
+				xylog.Logger.Infof("tx[%s] - bridge[%s] event decode err:%v", tx.Hash, adapter.name, err)
+				continue
+			}
+			item.Protocol, item.Index = adapter.protocol, i
+			items = append(items, item)
+		}
+	}
+	return items
+}
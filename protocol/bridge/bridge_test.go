@@ -0,0 +1,147 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package bridge
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/devents"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+const testABI = `[
+  {"anonymous": false, "name": "Locked", "type": "event", "inputs": [
+    {"indexed": true, "name": "nonce", "type": "uint256"},
+    {"indexed": true, "name": "sender", "type": "address"},
+    {"indexed": false, "name": "recipient", "type": "address"},
+    {"indexed": false, "name": "tick", "type": "string"},
+    {"indexed": false, "name": "amount", "type": "uint256"},
+    {"indexed": false, "name": "dstChain", "type": "string"}
+  ]},
+  {"anonymous": false, "name": "Minted", "type": "event", "inputs": [
+    {"indexed": true, "name": "nonce", "type": "uint256"},
+    {"indexed": true, "name": "recipient", "type": "address"},
+    {"indexed": false, "name": "tick", "type": "string"},
+    {"indexed": false, "name": "amount", "type": "uint256"}
+  ]}
+]`
+
+func TestAdapterDecode(t *testing.T) {
+	parsedABI, err := abi.JSON(strings.NewReader(testABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contract := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	sender := common.HexToAddress("0x2000000000000000000000000000000000000002")
+	recipient := common.HexToAddress("0x3000000000000000000000000000000000000003")
+
+	adapter, err := newAdapter(&config.BridgeConfig{
+		Name:     "example-bridge",
+		Contract: contract.String(),
+		Lock: &config.BridgeEventConfig{
+			Event:  "Locked",
+			Fields: config.BridgeFields{Id: "nonce", Tick: "tick", From: "sender", To: "recipient", Amount: "amount", Chain: "dstChain"},
+		},
+		Mint: &config.BridgeEventConfig{
+			Event:  "Minted",
+			Fields: config.BridgeFields{Id: "nonce", Tick: "tick", To: "recipient", Amount: "amount"},
+		},
+		Decimals: 2,
+	}, parsedABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(adapter.Topics()) != 2 {
+		t.Fatalf("topics[%d] != 2", len(adapter.Topics()))
+	}
+
+	locked := parsedABI.Events["Locked"]
+	minted := parsedABI.Events["Minted"]
+	lockData, _ := locked.Inputs.NonIndexed().Pack(recipient, " AVAV ", big.NewInt(12345), "bsc")
+	mintData, _ := minted.Inputs.NonIndexed().Pack("avav", big.NewInt(12345))
+	nonce := common.BigToHash(big.NewInt(7))
+	tx := &xycommon.RpcTransaction{
+		Hash: "0x01",
+		Events: []xycommon.RpcLog{
+			{Address: contract, Topics: []common.Hash{locked.ID, nonce, common.BytesToHash(sender.Bytes())}, Data: lockData},
+			{Address: sender, Topics: []common.Hash{locked.ID, nonce, common.BytesToHash(sender.Bytes())}, Data: lockData},
+			{Address: contract, Topics: []common.Hash{minted.ID, nonce, common.BytesToHash(recipient.Bytes())}, Data: mintData},
+		},
+	}
+
+	r := &Registry{adapters: []*Adapter{adapter}}
+	md := r.ParseMetaData("avalanche", tx)
+	if md == nil || md.Protocol != "asc-20" || md.Operate != devents.OperateBridge {
+		t.Fatalf("unexpected metadata %+v", md)
+	}
+
+	transfers := r.Transfers(tx)
+	if len(transfers) != 2 {
+		t.Fatalf("transfers[%d] != 2, events of other contracts must be ignored", len(transfers))
+	}
+
+	lock := transfers[0]
+	if lock.Kind != KindLock || lock.Protocol != "asc-20" || lock.Index != 0 || lock.Bridge != "example-bridge" || lock.Id != "7" || lock.Tick != "avav" ||
+		lock.From != strings.ToLower(sender.String()) || lock.To != strings.ToLower(recipient.String()) ||
+		lock.Contract != strings.ToLower(contract.String()) || lock.Amount.String() != "123.45" || lock.Chain != "bsc" {
+		t.Fatalf("unexpected lock %+v", lock)
+	}
+
+	mint := transfers[1]
+	if mint.Kind != KindMint || mint.Index != 2 || mint.Id != "7" || mint.From != "" || mint.To != strings.ToLower(recipient.String()) || mint.Chain != "" {
+		t.Fatalf("unexpected mint %+v", mint)
+	}
+
+	var empty *Registry
+	if empty.ParseMetaData("avalanche", tx) != nil || len(empty.Topics()) != 0 {
+		t.Fatalf("nil registry must match nothing")
+	}
+}
+
+func TestNewAdapterInvalid(t *testing.T) {
+	parsedABI, _ := abi.JSON(strings.NewReader(testABI))
+	invalid := []*config.BridgeConfig{
+		{Name: "", Contract: "0x1000000000000000000000000000000000000001", Mint: &config.BridgeEventConfig{Event: "Minted"}},
+		{Name: "no-events", Contract: "0x1000000000000000000000000000000000000001"},
+		{Name: "no-id", Contract: "0x1000000000000000000000000000000000000001", Mint: &config.BridgeEventConfig{
+			Event: "Minted", Fields: config.BridgeFields{Tick: "tick", To: "recipient", Amount: "amount"},
+		}},
+		{Name: "unknown-field", Contract: "0x1000000000000000000000000000000000000001", Mint: &config.BridgeEventConfig{
+			Event: "Minted", Fields: config.BridgeFields{Id: "nonce", Tick: "tick", To: "recipient", Amount: "qty"},
+		}},
+	}
+	for _, item := range invalid {
+		if _, err := newAdapter(item, parsedABI); err == nil {
+			t.Fatalf("bridge %+v must be rejected", item)
+		}
+	}
+
+	if _, err := NewAdapter(&config.BridgeConfig{Name: "bridge", Contract: "0x01"}); err == nil {
+		t.Fatalf("invalid contract must be rejected")
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package brc20
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"github.com/btcsuite/btcd/txscript"
+)
+
+const (
+	envelopeProtocolId = "ord"
+
+	envelopeTagContentType = 1
+	envelopeTagParent      = 3
+)
+
+// Envelope is an ordinal inscription found in a taproot witness script:
+// OP_FALSE OP_IF "ord" <tag> <value> ... OP_0 <body> ... OP_ENDIF
+type Envelope struct {
+	ContentType string
+	Parent      string // first parent inscription id
+	Body        []byte
+}
+
+// ParseEnvelope returns the first inscription envelope found in the witness items
+func ParseEnvelope(witness []string) *Envelope {
+	for _, item := range witness {
+		script, err := hex.DecodeString(item)
+		if err != nil || len(script) <= 0 {
+			continue
+		}
+
+		if envelope := parseEnvelopeScript(script); envelope != nil {
+			return envelope
+		}
+	}
+	return nil
+}
+
+func parseEnvelopeScript(script []byte) *Envelope {
+	tokenizer := txscript.MakeScriptTokenizer(0, script)
+
+	// match OP_FALSE OP_IF "ord" header
+	matched := 0
+	for matched < 3 && tokenizer.Next() {
+		switch {
+		case matched == 0 && tokenizer.Opcode() == txscript.OP_FALSE:
+			matched = 1
+		case matched == 1 && tokenizer.Opcode() == txscript.OP_IF:
+			matched = 2
+		case matched == 2 && bytes.Equal(tokenizer.Data(), []byte(envelopeProtocolId)):
+			matched = 3
+		case tokenizer.Opcode() == txscript.OP_FALSE:
+			matched = 1
+		default:
+			matched = 0
+		}
+	}
+	if matched < 3 {
+		return nil
+	}
+
+	envelope := &Envelope{}
+	inBody := false
+	var tag []byte
+	for tokenizer.Next() {
+		opcode := tokenizer.Opcode()
+		if opcode == txscript.OP_ENDIF {
+			return envelope
+		}
+
+		if inBody {
+			envelope.Body = append(envelope.Body, tokenizer.Data()...)
+			continue
+		}
+
+		// OP_0 as tag marks the beginning of body pushes
+		if tag == nil && opcode == txscript.OP_0 {
+			inBody = true
+			continue
+		}
+
+		value := tokenizer.Data()
+		if opcode >= txscript.OP_1 && opcode <= txscript.OP_16 {
+			value = []byte{opcode - txscript.OP_1 + 1}
+		}
+
+		if tag == nil {
+			tag = value
+			continue
+		}
+
+		if len(tag) == 1 && tag[0] == envelopeTagContentType {
+			envelope.ContentType = string(value)
+		}
+		if len(tag) == 1 && tag[0] == envelopeTagParent && envelope.Parent == "" {
+			envelope.Parent = parseInscriptionId(value)
+		}
+		tag = nil
+	}
+
+	// envelope without OP_ENDIF is invalid
+	return nil
+}
+
+// parseInscriptionId decodes a serialized inscription id: 32 bytes txid in
+// reversed byte order followed by up to 4 bytes little endian index
+func parseInscriptionId(value []byte) string {
+	if len(value) < 32 || len(value) > 36 {
+		return ""
+	}
+
+	txid := make([]byte, 32)
+	for i := 0; i < 32; i++ {
+		txid[i] = value[31-i]
+	}
+
+	index := uint32(0)
+	for i, b := range value[32:] {
+		index |= uint32(b) << (8 * i)
+	}
+	return fmt.Sprintf("%si%d", hex.EncodeToString(txid), index)
+}
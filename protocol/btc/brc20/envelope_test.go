@@ -0,0 +1,124 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package brc20
+
+import (
+	"encoding/hex"
+	"github.com/btcsuite/btcd/txscript"
+	"testing"
+)
+
+func buildEnvelopeWitness(t *testing.T, contentType string, body []byte, endIf bool) string {
+	builder := txscript.NewScriptBuilder().
+		AddOp(txscript.OP_FALSE).
+		AddOp(txscript.OP_IF).
+		AddData([]byte("ord")).
+		AddOp(txscript.OP_1).
+		AddData([]byte(contentType)).
+		AddOp(txscript.OP_0).
+		AddData(body)
+	if endIf {
+		builder.AddOp(txscript.OP_ENDIF)
+	}
+
+	script, err := builder.Script()
+	if err != nil {
+		t.Fatalf("build script err:%v", err)
+	}
+	return hex.EncodeToString(script)
+}
+
+func TestParseEnvelope(t *testing.T) {
+	content := `{"p":"brc-20","op":"mint","tick":"ordi","amt":"1000"}`
+	tests := []struct {
+		name    string
+		witness []string
+		want    *Envelope
+	}{
+		{
+			name:    "valid envelope",
+			witness: []string{"00", buildEnvelopeWitness(t, "text/plain;charset=utf-8", []byte(content), true)},
+			want:    &Envelope{ContentType: "text/plain;charset=utf-8", Body: []byte(content)},
+		},
+		{
+			name:    "missing endif",
+			witness: []string{buildEnvelopeWitness(t, "text/plain", []byte(content), false)},
+			want:    nil,
+		},
+		{
+			name:    "no envelope",
+			witness: []string{"51", "zz"},
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseEnvelope(tt.witness)
+			if tt.want == nil {
+				if got != nil {
+					t.Fatalf("expected nil envelope, got %+v", got)
+				}
+				return
+			}
+
+			if got == nil {
+				t.Fatalf("expected envelope, got nil")
+			}
+			if got.ContentType != tt.want.ContentType || string(got.Body) != string(tt.want.Body) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEnvelopeParent(t *testing.T) {
+	txid := "e2d7b6e9a4a39ba1e4bb1d7fb2e3c2e5bcf2f0a4f0e5e0f7d1c86b0a0e0c9c81"
+	raw, _ := hex.DecodeString(txid)
+	parent := make([]byte, 0, 33)
+	for i := len(raw) - 1; i >= 0; i-- {
+		parent = append(parent, raw[i])
+	}
+	parent = append(parent, 0x02)
+
+	script, err := txscript.NewScriptBuilder().
+		AddOp(txscript.OP_FALSE).
+		AddOp(txscript.OP_IF).
+		AddData([]byte("ord")).
+		AddOp(txscript.OP_1).
+		AddData([]byte("text/plain")).
+		AddOp(txscript.OP_3).
+		AddData(parent).
+		AddOp(txscript.OP_0).
+		AddData([]byte(`{"p":"brc-20","op":"mint","tick":"dummy","amt":"1"}`)).
+		AddOp(txscript.OP_ENDIF).
+		Script()
+	if err != nil {
+		t.Fatalf("build script err:%v", err)
+	}
+
+	got := ParseEnvelope([]string{hex.EncodeToString(script)})
+	if got == nil || got.Parent != txid+"i2" {
+		t.Fatalf("unexpected envelope parent: %+v", got)
+	}
+}
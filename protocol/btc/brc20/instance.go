@@ -23,16 +23,171 @@
 package brc20
 
 import (
+	"encoding/json"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/client/xycommon"
 	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
 	"github.com/uxuycom/indexer/protocol/common"
+	"github.com/uxuycom/indexer/protocol/types"
+	"github.com/uxuycom/indexer/xyerrors"
+	"math"
+	"strconv"
+)
+
+const (
+	TickLength         = 4
+	SelfMintTickLength = 5
 )
 
 type Protocol struct {
 	*common.Protocol
+	cache *dcache.Manager
 }
 
-func NewProtocol(cache *dcache.Manager) *Protocol {
+func NewProtocol(cache *dcache.Manager, rules *types.RuleSchedule) *Protocol {
 	return &Protocol{
-		Protocol: common.NewProtocol(cache),
+		Protocol: common.NewProtocol(cache, rules, nil, nil),
+		cache:    cache,
+	}
+}
+
+// IsSelfMint 5-byte ticks deployed with "self_mint": "true", the flag is ignored on 4-byte ticks
+func IsSelfMint(tick, selfMint string) bool {
+	return len([]byte(tick)) == SelfMintTickLength && selfMint == "true"
+}
+
+// Parse brc-20 inscriptions, tx.To is the address receiving the inscription.
+// A transfer inscription only locks the amount (inscribe transfer), the balance
+// moves when the inscription itself is sent.
+func (p *Protocol) Parse(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) ([]*devents.TxResult, *xyerrors.InsError) {
+	switch md.Operate {
+	case devents.OperateDeploy:
+		selfMint, err := p.verifyTickLength(block, md)
+		if err != nil {
+			return nil, xyerrors.ErrDataVerifiedFailed.WrapCause(err)
+		}
+		return p.Protocol.Parse(block, tx, p.fillDeployDefaults(md, selfMint))
+	case devents.OperateMint:
+		return p.Protocol.Parse(block, tx, md)
+	case devents.OperateTransfer:
+		return p.InscribeTransfer(block, tx, md)
+	}
+	return nil, nil
+}
+
+// verifyTickLength 4-byte ticks are always valid, 5-byte ticks only as self mint
+// deploys once the self_mint rule is active
+func (p *Protocol) verifyTickLength(block *xycommon.RpcBlock, md *devents.MetaData) (bool, *xyerrors.InsError) {
+	switch len([]byte(md.Tick)) {
+	case TickLength:
+		return false, nil
+	case SelfMintTickLength:
+		if !p.RuleSet(block, md.Protocol).SelfMint {
+			return false, xyerrors.NewInsError(-12, fmt.Sprintf("tick[%s] self mint not activated", md.Tick))
+		}
+
+		deploy := &struct {
+			SelfMint string `json:"self_mint"`
+		}{}
+		if err := json.Unmarshal([]byte(md.Data), deploy); err != nil || !IsSelfMint(md.Tick, deploy.SelfMint) {
+			return false, xyerrors.NewInsError(-12, fmt.Sprintf("tick[%s] 5-byte tick requires self_mint", md.Tick))
+		}
+		return true, nil
+	}
+	return false, xyerrors.NewInsError(-12, fmt.Sprintf("tick[%s] length invalid", md.Tick))
+}
+
+// fillDeployDefaults lim defaults to max & dec defaults to 18,
+// self mint deploys treat max / lim 0 as max uint64
+func (p *Protocol) fillDeployDefaults(md *devents.MetaData, selfMint bool) *devents.MetaData {
+	data := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(md.Data), &data); err != nil {
+		return md
+	}
+
+	if selfMint && isZeroAmount(data["max"]) {
+		data["max"] = strconv.FormatUint(math.MaxUint64, 10)
+	}
+
+	if _, ok := data["lim"]; !ok || (selfMint && isZeroAmount(data["lim"])) {
+		data["lim"] = data["max"]
+	}
+	if _, ok := data["dec"]; !ok {
+		data["dec"] = "18"
+	}
+
+	bytes, err := json.Marshal(data)
+	if err != nil {
+		return md
+	}
+
+	item := md.Copy()
+	item.Data = string(bytes)
+	return item
+}
+
+func isZeroAmount(value interface{}) bool {
+	str, ok := value.(string)
+	if !ok {
+		return false
+	}
+	amount, err := decimal.NewFromString(str)
+	return err == nil && amount.IsZero()
+}
+
+func (p *Protocol) InscribeTransfer(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) ([]*devents.TxResult, *xyerrors.InsError) {
+	amount, err := p.verifyInscribeTransfer(block, tx, md)
+	if err != nil {
+		return nil, xyerrors.ErrDataVerifiedFailed.WrapCause(err)
+	}
+
+	result := &devents.TxResult{
+		MD:    md,
+		Block: block,
+		Tx:    tx,
+		Transfer: &devents.Transfer{
+			Sender: tx.To,
+			Receives: []*devents.Receive{
+				{
+					Address: tx.To,
+					Amount:  amount,
+				},
+			},
+		},
+	}
+	return []*devents.TxResult{result}, nil
+}
+
+func (p *Protocol) verifyInscribeTransfer(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) (decimal.Decimal, *xyerrors.InsError) {
+	tf := &common.Transfer{}
+	if err := json.Unmarshal([]byte(md.Data), tf); err != nil {
+		return decimal.Zero, xyerrors.NewInsError(-13, fmt.Sprintf("data json deocde err:%v, data[%s]", err, md.Data))
+	}
+
+	if tf.Amount.LessThanOrEqual(decimal.Zero) {
+		return decimal.Zero, xyerrors.NewInsError(-14, "transfer amount <= 0")
+	}
+
+	ok, inscription := p.cache.Inscription.Get(md.Protocol, md.Tick)
+	if !ok {
+		return decimal.Zero, xyerrors.NewInsError(-15, fmt.Sprintf("inscription not exist, protocol[%s]-tick[%s]", md.Protocol, md.Tick))
+	}
+
+	// amount fraction digits within decimals
+	if err := p.RuleSet(block, md.Protocol).CheckAmount(tf.Amount, inscription.Decimals); err != nil {
+		return decimal.Zero, xyerrors.NewInsError(-18, err.Error())
+	}
+
+	ok, balance := p.cache.Balance.Get(md.Protocol, md.Tick, tx.To)
+	if !ok {
+		return decimal.Zero, xyerrors.NewInsError(-16, fmt.Sprintf("balance record not exist, tick[%s-%s], address[%s]", md.Protocol, md.Tick, tx.To))
+	}
+
+	// only available balance can be inscribed as transferable
+	if balance.Available.LessThan(tf.Amount.Decimal) {
+		return decimal.Zero, xyerrors.NewInsError(-17, fmt.Sprintf("available balance[%v] < transfer amount[%v]", balance.Available, tf.Amount))
 	}
+	return tf.Amount.Decimal, nil
 }
@@ -0,0 +1,73 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package brc20
+
+import (
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol/types"
+	"math/big"
+	"testing"
+)
+
+func TestVerifyTickLength(t *testing.T) {
+	rules, err := types.NewRuleSchedule([]*config.RuleActivation{
+		{Protocol: types.BRC20Protocol, Rule: types.RuleSelfMint, Height: 837090, Value: "true"},
+	})
+	if err != nil {
+		t.Fatalf("rules err:%v", err)
+	}
+	p := NewProtocol(nil, rules)
+	tests := []struct {
+		name     string
+		height   int64
+		tick     string
+		data     string
+		selfMint bool
+		valid    bool
+	}{
+		{"4-byte tick", 800000, "ordi", `{"p":"brc-20","op":"deploy","tick":"ordi","max":"21000000"}`, false, true},
+		{"4-byte tick ignores self_mint", 840000, "ordi", `{"p":"brc-20","op":"deploy","tick":"ordi","max":"1","self_mint":"true"}`, false, true},
+		{"5-byte tick before activation", 837089, "dummy", `{"p":"brc-20","op":"deploy","tick":"dummy","max":"0","self_mint":"true"}`, false, false},
+		{"5-byte tick without self_mint", 840000, "dummy", `{"p":"brc-20","op":"deploy","tick":"dummy","max":"0"}`, false, false},
+		{"5-byte self mint tick", 837090, "dummy", `{"p":"brc-20","op":"deploy","tick":"dummy","max":"0","self_mint":"true"}`, true, true},
+		{"3-byte tick", 840000, "ord", `{"p":"brc-20","op":"deploy","tick":"ord","max":"1"}`, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block := &xycommon.RpcBlock{Number: big.NewInt(tt.height)}
+			md := &devents.MetaData{Protocol: types.BRC20Protocol, Tick: tt.tick, Data: tt.data}
+			selfMint, err := p.verifyTickLength(block, md)
+			if (err == nil) != tt.valid || selfMint != tt.selfMint {
+				t.Fatalf("got selfMint[%v] err[%v], want selfMint[%v] valid[%v]", selfMint, err, tt.selfMint, tt.valid)
+			}
+		})
+	}
+
+	md := p.fillDeployDefaults(&devents.MetaData{Data: `{"max":"0","self_mint":"true"}`}, true)
+	if md.Data != `{"dec":"18","lim":"18446744073709551615","max":"18446744073709551615","self_mint":"true"}` {
+		t.Fatalf("unexpected self mint deploy defaults: %s", md.Data)
+	}
+}
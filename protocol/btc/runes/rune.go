@@ -0,0 +1,164 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package runes
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	// SubsidyHalvingInterval names unlock over the first halving epoch after activation
+	SubsidyHalvingInterval = 210000
+
+	// FirstRuneHeight runes activation height of mainnet & testnet
+	FirstRuneHeight        = 840000
+	FirstRuneHeightTestnet = 2520000
+
+	// CommitConfirmations confirmations of the etching commitment output
+	CommitConfirmations = 6
+
+	runeSpacer = "•"
+)
+
+var (
+	// reservedRune "AAAAAAAAAAAAAAAAAAAAAAAAAAA", names from here on are assigned to unnamed etchings
+	reservedRune, _ = new(big.Int).SetString("6402364363415443603228541259936211926", 10)
+
+	// steps[i] is the value of the name with i+1 "A"
+	steps = func() []*big.Int {
+		items := make([]*big.Int, 28)
+		items[0] = big.NewInt(0)
+		pow := big.NewInt(1)
+		for i := 1; i < len(items); i++ {
+			pow = new(big.Int).Mul(pow, big.NewInt(26))
+			items[i] = new(big.Int).Add(items[i-1], pow)
+		}
+		return items
+	}()
+)
+
+// RuneName converts rune value to its modified base-26 name
+func RuneName(n *big.Int) string {
+	if n.Cmp(MaxU128) == 0 {
+		return "BCGDENLQRQWDSLRUGSNLBTMFIJAV"
+	}
+
+	v := new(big.Int).Add(n, big.NewInt(1))
+	letters := make([]byte, 0, 28)
+	mod := new(big.Int)
+	for v.Sign() > 0 {
+		v.Sub(v, big.NewInt(1))
+		v.DivMod(v, big.NewInt(26), mod)
+		letters = append(letters, byte('A'+mod.Int64()))
+	}
+
+	for i, j := 0, len(letters)-1; i < j; i, j = i+1, j-1 {
+		letters[i], letters[j] = letters[j], letters[i]
+	}
+	return string(letters)
+}
+
+// ParseRune converts rune name (spacers are ignored) to its value
+func ParseRune(name string) (*big.Int, error) {
+	n := new(big.Int)
+	count := 0
+	for i, c := range strings.ReplaceAll(name, runeSpacer, "") {
+		if c < 'A' || c > 'Z' {
+			return nil, fmt.Errorf("invalid rune character[%c]", c)
+		}
+		if i > 0 {
+			n.Add(n, big.NewInt(1))
+		}
+		n.Mul(n, big.NewInt(26))
+		n.Add(n, big.NewInt(int64(c-'A')))
+		count++
+	}
+
+	if count == 0 || n.Cmp(MaxU128) > 0 {
+		return nil, fmt.Errorf("invalid rune name[%s]", name)
+	}
+	return n, nil
+}
+
+// SpacedRune inserts "•" after the letters flagged by spacers bits
+func SpacedRune(name string, spacers uint32) string {
+	var sb strings.Builder
+	for i, c := range name {
+		sb.WriteRune(c)
+		if i < len(name)-1 && spacers&(1<<uint(i)) != 0 {
+			sb.WriteString(runeSpacer)
+		}
+	}
+	return sb.String()
+}
+
+// IsReserved reserved names can't be etched explicitly
+func IsReserved(n *big.Int) bool {
+	return n.Cmp(reservedRune) >= 0
+}
+
+// ReservedRune name assigned to etchings without rune
+func ReservedRune(block uint64, tx uint32) *big.Int {
+	offset := new(big.Int).Lsh(new(big.Int).SetUint64(block), 32)
+	offset.Or(offset, new(big.Int).SetUint64(uint64(tx)))
+	return offset.Add(offset, reservedRune)
+}
+
+// Commitment rune value little endian bytes without trailing zeros, pushed in the etching input tapscript
+func Commitment(n *big.Int) []byte {
+	be := n.Bytes()
+	le := make([]byte, len(be))
+	for i, b := range be {
+		le[len(be)-1-i] = b
+	}
+	return le
+}
+
+// MinimumAtHeight the shortest name length unlocks by 1 letter every 17500 blocks after activation
+func MinimumAtHeight(height, firstRuneHeight uint64) *big.Int {
+	const interval = SubsidyHalvingInterval / 12
+
+	offset := height + 1
+	start := firstRuneHeight
+	end := start + SubsidyHalvingInterval
+	if offset < start {
+		return new(big.Int).Set(steps[12])
+	}
+
+	if offset >= end {
+		return big.NewInt(0)
+	}
+
+	progress := offset - start
+	length := 12 - progress/interval
+	endStep := steps[length-1]
+	startStep := steps[length]
+	remainder := big.NewInt(int64(progress % interval))
+
+	diff := new(big.Int).Sub(startStep, endStep)
+	diff.Mul(diff, remainder)
+	diff.Div(diff, big.NewInt(interval))
+	return new(big.Int).Sub(startStep, diff)
+}
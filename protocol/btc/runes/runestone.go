@@ -0,0 +1,449 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package runes
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/txscript"
+	"math/big"
+	"unicode/utf8"
+)
+
+const (
+	MaxDivisibility = 38
+	MaxSpacers      = 0x07ffffff
+)
+
+// runestone tags, even unknown tags turn the runestone into a cenotaph
+const (
+	tagBody         = 0
+	tagDivisibility = 1
+	tagFlags        = 2
+	tagSpacers      = 3
+	tagRune         = 4
+	tagSymbol       = 5
+	tagPremine      = 6
+	tagCap          = 8
+	tagAmount       = 10
+	tagHeightStart  = 12
+	tagHeightEnd    = 14
+	tagOffsetStart  = 16
+	tagOffsetEnd    = 18
+	tagMint         = 20
+	tagPointer      = 22
+)
+
+// runestone flags
+const (
+	flagEtching = 0
+	flagTerms   = 1
+	flagTurbo   = 2
+)
+
+// cenotaph flaws
+const (
+	FlawEdictOutput         = "edict_output"
+	FlawEdictRuneId         = "edict_rune_id"
+	FlawInvalidScript       = "invalid_script"
+	FlawOpcode              = "opcode"
+	FlawSupplyOverflow      = "supply_overflow"
+	FlawTrailingIntegers    = "trailing_integers"
+	FlawTruncatedField      = "truncated_field"
+	FlawUnrecognizedEvenTag = "unrecognized_even_tag"
+	FlawUnrecognizedFlag    = "unrecognized_flag"
+	FlawVarint              = "varint"
+)
+
+type RuneId struct {
+	Block uint64
+	Tx    uint32
+}
+
+func (r RuneId) String() string {
+	return fmt.Sprintf("%d:%d", r.Block, r.Tx)
+}
+
+// next applies the delta encoded edict id
+func (r RuneId) next(block, tx *big.Int) (RuneId, bool) {
+	if !block.IsUint64() || !tx.IsUint64() || tx.Uint64() > 0xffffffff {
+		return RuneId{}, false
+	}
+
+	if block.Sign() == 0 {
+		next := uint64(r.Tx) + tx.Uint64()
+		if next > 0xffffffff {
+			return RuneId{}, false
+		}
+		return RuneId{Block: r.Block, Tx: uint32(next)}, true
+	}
+
+	next := r.Block + block.Uint64()
+	if next < r.Block {
+		return RuneId{}, false
+	}
+	return RuneId{Block: next, Tx: uint32(tx.Uint64())}, true
+}
+
+type Edict struct {
+	Id     RuneId
+	Amount *big.Int
+	Output uint32
+}
+
+type Terms struct {
+	Amount      *big.Int
+	Cap         *big.Int
+	HeightStart *uint64
+	HeightEnd   *uint64
+	OffsetStart *uint64
+	OffsetEnd   *uint64
+}
+
+type Etching struct {
+	Divisibility *uint8
+	Premine      *big.Int
+	Rune         *big.Int
+	Spacers      *uint32
+	Symbol       *rune
+	Terms        *Terms
+	Turbo        bool
+}
+
+// Supply premine + cap * amount, nil if overflow u128
+func (e *Etching) Supply() *big.Int {
+	supply := new(big.Int)
+	if e.Premine != nil {
+		supply.Set(e.Premine)
+	}
+
+	if e.Terms != nil && e.Terms.Cap != nil && e.Terms.Amount != nil {
+		supply.Add(supply, new(big.Int).Mul(e.Terms.Cap, e.Terms.Amount))
+	}
+
+	if supply.Cmp(MaxU128) > 0 {
+		return nil
+	}
+	return supply
+}
+
+// Runestone deciphered runes protocol message.
+// A cenotaph only keeps mint & etched rune name, all input runes are burned,
+// a cenotaph etching without rune name etches nothing.
+type Runestone struct {
+	Edicts   []*Edict
+	Etching  *Etching
+	Mint     *RuneId
+	Pointer  *uint32
+	Cenotaph bool
+	Flaw     string
+}
+
+// EtchedRune rune name of the etching, nil if not set
+func (r *Runestone) EtchedRune() *big.Int {
+	if r.Etching == nil {
+		return nil
+	}
+	return r.Etching.Rune
+}
+
+// IsOpReturn checks whether the output script is OP_RETURN
+func IsOpReturn(vout btcjson.Vout) bool {
+	script, err := hex.DecodeString(vout.ScriptPubKey.Hex)
+	return err == nil && len(script) > 0 && script[0] == txscript.OP_RETURN
+}
+
+// Decipher returns the runestone of the tx, nil if no runestone found
+func Decipher(tx btcjson.TxRawResult) *Runestone {
+	payload, flaw, ok := findPayload(tx)
+	if !ok {
+		return nil
+	}
+
+	if flaw != "" {
+		return &Runestone{Cenotaph: true, Flaw: flaw}
+	}
+
+	integers, err := decodeIntegers(payload)
+	if err != nil {
+		return &Runestone{Cenotaph: true, Flaw: FlawVarint}
+	}
+
+	msg := newMessage(tx, integers)
+	stone := &Runestone{
+		Edicts: msg.edicts,
+		Flaw:   msg.flaw,
+	}
+
+	flags := new(big.Int)
+	msg.take(tagFlags, 1, func(v []*big.Int) bool {
+		flags = new(big.Int).Set(v[0])
+		return true
+	})
+
+	if takeFlag(flags, flagEtching) {
+		stone.Etching = msg.etching(flags)
+	}
+
+	msg.take(tagMint, 2, func(v []*big.Int) bool {
+		if !v[0].IsUint64() || !v[1].IsUint64() || v[1].Uint64() > 0xffffffff {
+			return false
+		}
+		stone.Mint = &RuneId{Block: v[0].Uint64(), Tx: uint32(v[1].Uint64())}
+		return true
+	})
+
+	msg.take(tagPointer, 1, func(v []*big.Int) bool {
+		if !v[0].IsUint64() || v[0].Uint64() >= uint64(len(tx.Vout)) {
+			return false
+		}
+		pointer := uint32(v[0].Uint64())
+		stone.Pointer = &pointer
+		return true
+	})
+
+	if stone.Etching != nil && stone.Etching.Supply() == nil {
+		stone.setFlaw(FlawSupplyOverflow)
+	}
+
+	if flags.Sign() != 0 {
+		stone.setFlaw(FlawUnrecognizedFlag)
+	}
+
+	for tag := range msg.fields {
+		msg.unrecognizedEven = msg.unrecognizedEven || tag%2 == 0
+	}
+	if msg.unrecognizedEven {
+		stone.setFlaw(FlawUnrecognizedEvenTag)
+	}
+
+	if stone.Flaw != "" {
+		stone.Cenotaph = true
+		stone.Edicts = nil
+		stone.Pointer = nil
+		if rn := stone.EtchedRune(); rn != nil {
+			stone.Etching = &Etching{Rune: rn}
+		} else {
+			stone.Etching = nil
+		}
+	}
+	return stone
+}
+
+func (r *Runestone) setFlaw(flaw string) {
+	if r.Flaw == "" {
+		r.Flaw = flaw
+	}
+}
+
+// findPayload concatenates the data pushes of the first OP_RETURN OP_13 output
+func findPayload(tx btcjson.TxRawResult) (payload []byte, flaw string, ok bool) {
+	for _, vout := range tx.Vout {
+		script, err := hex.DecodeString(vout.ScriptPubKey.Hex)
+		if err != nil {
+			continue
+		}
+
+		tokenizer := txscript.MakeScriptTokenizer(0, script)
+		if !tokenizer.Next() || tokenizer.Opcode() != txscript.OP_RETURN {
+			continue
+		}
+		if !tokenizer.Next() || tokenizer.Opcode() != txscript.OP_13 {
+			continue
+		}
+
+		payload = make([]byte, 0, len(script))
+		for tokenizer.Next() {
+			if tokenizer.Opcode() > txscript.OP_PUSHDATA4 {
+				return nil, FlawOpcode, true
+			}
+			payload = append(payload, tokenizer.Data()...)
+		}
+
+		if tokenizer.Err() != nil {
+			return nil, FlawInvalidScript, true
+		}
+		return payload, "", true
+	}
+	return nil, "", false
+}
+
+type message struct {
+	edicts           []*Edict
+	fields           map[uint64][]*big.Int
+	flaw             string
+	unrecognizedEven bool
+}
+
+func newMessage(tx btcjson.TxRawResult, integers []*big.Int) *message {
+	msg := &message{
+		edicts: make([]*Edict, 0),
+		fields: make(map[uint64][]*big.Int),
+	}
+
+	for i := 0; i < len(integers); i += 2 {
+		tag := integers[i]
+		if tag.Sign() == tagBody {
+			id := RuneId{}
+			for j := i + 1; j < len(integers); j += 4 {
+				if len(integers)-j < 4 {
+					msg.flaw = FlawTrailingIntegers
+					break
+				}
+
+				next, ok := id.next(integers[j], integers[j+1])
+				if !ok {
+					msg.flaw = FlawEdictRuneId
+					break
+				}
+
+				output := integers[j+3]
+				if !output.IsUint64() || output.Uint64() > uint64(len(tx.Vout)) {
+					msg.flaw = FlawEdictOutput
+					break
+				}
+
+				id = next
+				msg.edicts = append(msg.edicts, &Edict{
+					Id:     next,
+					Amount: integers[j+2],
+					Output: uint32(output.Uint64()),
+				})
+			}
+			break
+		}
+
+		if i+1 >= len(integers) {
+			msg.flaw = FlawTruncatedField
+			break
+		}
+
+		// tags beyond u64 can only be unrecognized
+		if !tag.IsUint64() {
+			msg.unrecognizedEven = msg.unrecognizedEven || tag.Bit(0) == 0
+			continue
+		}
+		msg.fields[tag.Uint64()] = append(msg.fields[tag.Uint64()], integers[i+1])
+	}
+	return msg
+}
+
+// take consumes n values of the tag if the parser accepts them
+func (m *message) take(tag uint64, n int, parser func(v []*big.Int) bool) {
+	values, ok := m.fields[tag]
+	if !ok || len(values) < n {
+		return
+	}
+
+	if !parser(values[:n]) {
+		return
+	}
+
+	if len(values) == n {
+		delete(m.fields, tag)
+		return
+	}
+	m.fields[tag] = values[n:]
+}
+
+func (m *message) etching(flags *big.Int) *Etching {
+	etching := &Etching{}
+	m.take(tagDivisibility, 1, func(v []*big.Int) bool {
+		if !v[0].IsUint64() || v[0].Uint64() > MaxDivisibility {
+			return false
+		}
+		d := uint8(v[0].Uint64())
+		etching.Divisibility = &d
+		return true
+	})
+
+	m.take(tagPremine, 1, func(v []*big.Int) bool {
+		etching.Premine = v[0]
+		return true
+	})
+
+	m.take(tagRune, 1, func(v []*big.Int) bool {
+		etching.Rune = v[0]
+		return true
+	})
+
+	m.take(tagSpacers, 1, func(v []*big.Int) bool {
+		if !v[0].IsUint64() || v[0].Uint64() > MaxSpacers {
+			return false
+		}
+		s := uint32(v[0].Uint64())
+		etching.Spacers = &s
+		return true
+	})
+
+	m.take(tagSymbol, 1, func(v []*big.Int) bool {
+		if !v[0].IsUint64() || v[0].Uint64() > utf8.MaxRune || !utf8.ValidRune(rune(v[0].Uint64())) {
+			return false
+		}
+		s := rune(v[0].Uint64())
+		etching.Symbol = &s
+		return true
+	})
+
+	if takeFlag(flags, flagTerms) {
+		terms := &Terms{}
+		m.take(tagCap, 1, func(v []*big.Int) bool {
+			terms.Cap = v[0]
+			return true
+		})
+		terms.HeightStart = m.takeUint64(tagHeightStart)
+		terms.HeightEnd = m.takeUint64(tagHeightEnd)
+		m.take(tagAmount, 1, func(v []*big.Int) bool {
+			terms.Amount = v[0]
+			return true
+		})
+		terms.OffsetStart = m.takeUint64(tagOffsetStart)
+		terms.OffsetEnd = m.takeUint64(tagOffsetEnd)
+		etching.Terms = terms
+	}
+
+	etching.Turbo = takeFlag(flags, flagTurbo)
+	return etching
+}
+
+func (m *message) takeUint64(tag uint64) (ret *uint64) {
+	m.take(tag, 1, func(v []*big.Int) bool {
+		if !v[0].IsUint64() {
+			return false
+		}
+		n := v[0].Uint64()
+		ret = &n
+		return true
+	})
+	return
+}
+
+// takeFlag checks & clears the flag bit
+func takeFlag(flags *big.Int, flag int) bool {
+	if flags.Bit(flag) == 0 {
+		return false
+	}
+	flags.SetBit(flags, flag, 0)
+	return true
+}
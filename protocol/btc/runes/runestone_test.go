@@ -0,0 +1,167 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package runes
+
+import (
+	"encoding/hex"
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/uxuycom/indexer/dcache"
+	"math/big"
+	"testing"
+)
+
+func runestoneScript(t *testing.T, integers ...uint64) string {
+	payload := make([]byte, 0, len(integers))
+	for _, v := range integers {
+		payload = append(payload, EncodeVarint(new(big.Int).SetUint64(v))...)
+	}
+
+	script, err := txscript.NewScriptBuilder().AddOp(txscript.OP_RETURN).AddOp(txscript.OP_13).AddData(payload).Script()
+	if err != nil {
+		t.Fatalf("build script err:%v", err)
+	}
+	return hex.EncodeToString(script)
+}
+
+func buildTx(txid string, inputs []string, runestone string, addresses ...string) btcjson.TxRawResult {
+	tx := btcjson.TxRawResult{Txid: txid}
+	for _, in := range inputs {
+		tx.Vin = append(tx.Vin, btcjson.Vin{Txid: in, Vout: 0})
+	}
+	for i, addr := range addresses {
+		tx.Vout = append(tx.Vout, btcjson.Vout{N: uint32(i), ScriptPubKey: btcjson.ScriptPubKeyResult{Hex: "5120", Address: addr}})
+	}
+	if runestone != "" {
+		tx.Vout = append(tx.Vout, btcjson.Vout{N: uint32(len(tx.Vout)), ScriptPubKey: btcjson.ScriptPubKeyResult{Hex: runestone}})
+	}
+	return tx
+}
+
+func TestVarint(t *testing.T) {
+	for _, v := range []*big.Int{big.NewInt(0), big.NewInt(127), big.NewInt(128), big.NewInt(300), MaxU128} {
+		n, size, err := DecodeVarint(EncodeVarint(v))
+		if err != nil || n.Cmp(v) != 0 || size != len(EncodeVarint(v)) {
+			t.Fatalf("varint roundtrip failed, v[%v] got[%v] err[%v]", v, n, err)
+		}
+	}
+
+	if _, _, err := DecodeVarint([]byte{0x80}); err != ErrVarintUnterminated {
+		t.Fatalf("expected unterminated err, got %v", err)
+	}
+}
+
+func TestRuneName(t *testing.T) {
+	cases := map[string]int64{"A": 0, "Z": 25, "AA": 26, "AB": 27, "AAA": 702}
+	for name, value := range cases {
+		if got := RuneName(big.NewInt(value)); got != name {
+			t.Fatalf("RuneName(%d) = %s, want %s", value, got, name)
+		}
+		n, err := ParseRune(name)
+		if err != nil || n.Int64() != value {
+			t.Fatalf("ParseRune(%s) = %v, err %v", name, n, err)
+		}
+	}
+
+	if got := SpacedRune("UNCOMMONGOODS", 0b10000000); got != "UNCOMMON•GOODS" {
+		t.Fatalf("SpacedRune = %s", got)
+	}
+}
+
+func TestDecipher(t *testing.T) {
+	// etching flag, divisibility 2, premine 1000, pointer 0, edict 0:0 -> 500 to output 1
+	tx := buildTx("aa", nil, runestoneScript(t, tagFlags, 1, tagDivisibility, 2, tagPremine, 1000, tagPointer, 0, tagBody, 0, 0, 500, 1), "addr0", "addr1")
+	stone := Decipher(tx)
+	if stone == nil || stone.Cenotaph {
+		t.Fatalf("expected runestone, got %+v", stone)
+	}
+	if stone.Etching == nil || *stone.Etching.Divisibility != 2 || stone.Etching.Premine.Int64() != 1000 || stone.Etching.Rune != nil {
+		t.Fatalf("unexpected etching %+v", stone.Etching)
+	}
+	if len(stone.Edicts) != 1 || stone.Edicts[0].Amount.Int64() != 500 || stone.Edicts[0].Output != 1 {
+		t.Fatalf("unexpected edicts %+v", stone.Edicts)
+	}
+
+	cases := map[string]string{
+		"unrecognized even tag": runestoneScript(t, 24, 1),
+		"truncated field":       runestoneScript(t, tagFlags),
+		"edict output":          runestoneScript(t, tagBody, 1, 1, 10, 9),
+		"trailing integers":     runestoneScript(t, tagBody, 1, 1, 10),
+		"unrecognized flag":     runestoneScript(t, tagFlags, 1<<5),
+	}
+	for name, script := range cases {
+		stone = Decipher(buildTx("bb", nil, script, "addr0"))
+		if stone == nil || !stone.Cenotaph {
+			t.Fatalf("%s: expected cenotaph, got %+v", name, stone)
+		}
+	}
+
+	if stone = Decipher(buildTx("cc", nil, "", "addr0")); stone != nil {
+		t.Fatalf("expected no runestone, got %+v", stone)
+	}
+}
+
+func TestUpdater(t *testing.T) {
+	cache := &dcache.Manager{Runes: dcache.NewRunes()}
+	u := NewUpdater(cache, false, nil)
+	height := uint64(FirstRuneHeight + 1)
+
+	// etch an unnamed rune with premine 1000 & open mint terms: cap 10, amount 100
+	etch := buildTx("e1", nil, runestoneScript(t, tagFlags, 0b11, tagPremine, 1000, tagCap, 10, tagAmount, 100), "alice")
+	r, err := u.Index(height, 0, 1, etch)
+	if err != nil || r == nil || r.Etched == nil {
+		t.Fatalf("etching failed, ret %+v err %v", r, err)
+	}
+	id := r.Etched.Id
+	if r.Etched.Rune != RuneName(ReservedRune(height, 1)) {
+		t.Fatalf("unexpected reserved rune name %s", r.Etched.Rune)
+	}
+	if len(r.Outputs) != 1 || r.Outputs[0].Balances[id].IntPart() != 1000 {
+		t.Fatalf("unexpected premine outputs %+v", r.Outputs)
+	}
+
+	// transfer 300 to bob, the rest to carol by default output
+	edict := runestoneScript(t, tagPointer, 1, tagBody, height, 1, 300, 0)
+	r, err = u.Index(height+1, 0, 1, buildTx("t1", []string{"e1"}, edict, "bob", "carol"))
+	if err != nil || r == nil || len(r.Spent) != 1 || len(r.Outputs) != 2 {
+		t.Fatalf("transfer failed, ret %+v err %v", r, err)
+	}
+	if r.Outputs[0].Balances[id].IntPart() != 300 || r.Outputs[1].Balances[id].IntPart() != 700 {
+		t.Fatalf("unexpected transfer outputs %+v %+v", r.Outputs[0], r.Outputs[1])
+	}
+
+	// mint 100 & burn everything by cenotaph
+	block, tx := height, uint64(1)
+	r, err = u.Index(height+2, 0, 1, buildTx("m1", []string{"t1"}, runestoneScript(t, tagMint, block, tagMint, tx, 24, 0), "dave"))
+	if err != nil || r == nil || r.Minted == nil {
+		t.Fatalf("mint failed, ret %+v err %v", r, err)
+	}
+	if len(r.Outputs) != 0 || r.Burned[id].IntPart() != 400 {
+		t.Fatalf("expected cenotaph burn 400, got outputs %+v burned %v", r.Outputs, r.Burned)
+	}
+
+	ok, entry := cache.Runes.Get(id)
+	if !ok || entry.Mints != 1 || entry.Burned.IntPart() != 400 {
+		t.Fatalf("unexpected entry %+v", entry)
+	}
+}
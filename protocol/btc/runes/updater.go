@@ -0,0 +1,511 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package runes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"github.com/btcsuite/btcd/btcjson"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/dcache"
+	"math/big"
+)
+
+const taprootScriptType = "witness_v1_taproot"
+
+// OutputFetcher returns the script type & the confirmed block height of a previous output,
+// used for etching commitment checking
+type OutputFetcher func(txid string, vout uint32) (scriptType string, height uint64, err error)
+
+// Updater applies runestones of block txs to the runes cache
+type Updater struct {
+	cache           *dcache.Manager
+	firstRuneHeight uint64
+	fetchOutput     OutputFetcher
+}
+
+func NewUpdater(cache *dcache.Manager, testnet bool, fetchOutput OutputFetcher) *Updater {
+	firstRuneHeight := uint64(FirstRuneHeight)
+	if testnet {
+		firstRuneHeight = FirstRuneHeightTestnet
+	}
+	return &Updater{
+		cache:           cache,
+		firstRuneHeight: firstRuneHeight,
+		fetchOutput:     fetchOutput,
+	}
+}
+
+// RuneOutput rune balances allocated to a tx output
+type RuneOutput struct {
+	Vout     uint32
+	Address  string
+	Balances map[string]decimal.Decimal
+}
+
+// SpentOutput rune balances spent by a tx input
+type SpentOutput struct {
+	TxHash string
+	Vout   uint32
+	Output *dcache.RuneOutput
+}
+
+// TxResult rune changes of a tx
+type TxResult struct {
+	Runestone *Runestone
+	Etched    *dcache.RuneEntry
+	Minted    *RuneId
+	MintAmt   decimal.Decimal
+	Spent     []*SpentOutput
+	Outputs   []*RuneOutput
+	Burned    map[string]decimal.Decimal
+}
+
+// Updated rune ids whose entries changed by the tx (mints & burns)
+func (r *TxResult) Updated() []string {
+	ids := make([]string, 0, len(r.Burned)+1)
+	if r.Minted != nil {
+		ids = append(ids, r.Minted.String())
+	}
+	for id := range r.Burned {
+		if r.Minted == nil || id != r.Minted.String() {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// balances keeps rune amounts in insertion order
+type balances struct {
+	ids     []string
+	amounts map[string]*big.Int
+}
+
+func newBalances() *balances {
+	return &balances{amounts: make(map[string]*big.Int)}
+}
+
+func (b *balances) add(id string, amount *big.Int) {
+	if v, ok := b.amounts[id]; ok {
+		v.Add(v, amount)
+		return
+	}
+	b.ids = append(b.ids, id)
+	b.amounts[id] = new(big.Int).Set(amount)
+}
+
+// Index applies the tx runestone & input runes, returns nil if the tx doesn't touch runes
+func (u *Updater) Index(height uint64, blockTime int64, txIndex uint32, tx btcjson.TxRawResult) (*TxResult, error) {
+	if height < u.firstRuneHeight {
+		return nil, nil
+	}
+
+	result := &TxResult{
+		Burned: make(map[string]decimal.Decimal),
+	}
+
+	unallocated := u.unallocated(tx, result)
+	allocated := make([]*balances, len(tx.Vout))
+	for i := range allocated {
+		allocated[i] = newBalances()
+	}
+
+	stone := Decipher(tx)
+	result.Runestone = stone
+	if stone == nil && len(result.Spent) == 0 {
+		return nil, nil
+	}
+
+	var etchedId *RuneId
+	var etchedRune *big.Int
+	if stone != nil {
+		if stone.Mint != nil {
+			if amount, ok := u.mint(*stone.Mint, height); ok {
+				result.Minted = stone.Mint
+				result.MintAmt = decimal.NewFromBigInt(amount, 0)
+				unallocated.add(stone.Mint.String(), amount)
+			}
+		}
+
+		var err error
+		etchedId, etchedRune, err = u.etched(height, txIndex, tx, stone)
+		if err != nil {
+			return nil, err
+		}
+
+		if !stone.Cenotaph {
+			if etchedId != nil && stone.Etching.Premine != nil {
+				unallocated.add(etchedId.String(), stone.Etching.Premine)
+			}
+			u.applyEdicts(tx, stone, etchedId, unallocated, allocated)
+		}
+
+		if etchedId != nil {
+			result.Etched = u.createEntry(*etchedId, etchedRune, blockTime, tx.Txid, stone)
+		}
+	}
+
+	burned := newBalances()
+	if stone != nil && stone.Cenotaph {
+		for _, id := range unallocated.ids {
+			burned.add(id, unallocated.amounts[id])
+		}
+	} else {
+		vout := -1
+		if stone != nil && stone.Pointer != nil {
+			vout = int(*stone.Pointer)
+		} else {
+			for i, out := range tx.Vout {
+				if !IsOpReturn(out) {
+					vout = i
+					break
+				}
+			}
+		}
+
+		for _, id := range unallocated.ids {
+			if unallocated.amounts[id].Sign() <= 0 {
+				continue
+			}
+			if vout >= 0 {
+				allocated[vout].add(id, unallocated.amounts[id])
+			} else {
+				burned.add(id, unallocated.amounts[id])
+			}
+		}
+	}
+
+	for vout, items := range allocated {
+		if len(items.ids) == 0 {
+			continue
+		}
+
+		if IsOpReturn(tx.Vout[vout]) {
+			for _, id := range items.ids {
+				burned.add(id, items.amounts[id])
+			}
+			continue
+		}
+
+		output := &RuneOutput{
+			Vout:     uint32(vout),
+			Address:  tx.Vout[vout].ScriptPubKey.Address,
+			Balances: make(map[string]decimal.Decimal, len(items.ids)),
+		}
+		for _, id := range items.ids {
+			if items.amounts[id].Sign() > 0 {
+				output.Balances[id] = decimal.NewFromBigInt(items.amounts[id], 0)
+			}
+		}
+		if len(output.Balances) == 0 {
+			continue
+		}
+
+		result.Outputs = append(result.Outputs, output)
+		u.cache.Runes.AddOutput(Outpoint(tx.Txid, uint32(vout)), &dcache.RuneOutput{
+			Address:  output.Address,
+			Balances: output.Balances,
+		})
+	}
+
+	for _, id := range burned.ids {
+		if burned.amounts[id].Sign() <= 0 {
+			continue
+		}
+		amount := decimal.NewFromBigInt(burned.amounts[id], 0)
+		result.Burned[id] = amount
+		u.cache.Runes.Burn(id, amount)
+	}
+	return result, nil
+}
+
+// unallocated collects & spends the rune balances of tx inputs
+func (u *Updater) unallocated(tx btcjson.TxRawResult, result *TxResult) *balances {
+	unallocated := newBalances()
+	for _, vin := range tx.Vin {
+		if vin.IsCoinBase() {
+			continue
+		}
+
+		ok, output := u.cache.Runes.SpendOutput(Outpoint(vin.Txid, vin.Vout))
+		if !ok {
+			continue
+		}
+
+		result.Spent = append(result.Spent, &SpentOutput{
+			TxHash: vin.Txid,
+			Vout:   vin.Vout,
+			Output: output,
+		})
+		for id, amount := range output.Balances {
+			unallocated.add(id, amount.BigInt())
+		}
+	}
+	return unallocated
+}
+
+func (u *Updater) applyEdicts(tx btcjson.TxRawResult, stone *Runestone, etchedId *RuneId, unallocated *balances, allocated []*balances) {
+	destinations := make([]int, 0, len(tx.Vout))
+	for i, out := range tx.Vout {
+		if !IsOpReturn(out) {
+			destinations = append(destinations, i)
+		}
+	}
+
+	allocate := func(balance *big.Int, amount *big.Int, id string, output int) {
+		if amount.Sign() <= 0 {
+			return
+		}
+		balance.Sub(balance, amount)
+		allocated[output].add(id, amount)
+	}
+
+	for _, edict := range stone.Edicts {
+		id := edict.Id
+		if id.Block == 0 && id.Tx == 0 {
+			if etchedId == nil {
+				continue
+			}
+			id = *etchedId
+		}
+
+		balance, ok := unallocated.amounts[id.String()]
+		if !ok {
+			continue
+		}
+
+		if int(edict.Output) < len(tx.Vout) {
+			amount := balance
+			if edict.Amount.Sign() > 0 && edict.Amount.Cmp(balance) < 0 {
+				amount = edict.Amount
+			}
+			allocate(balance, new(big.Int).Set(amount), id.String(), int(edict.Output))
+			continue
+		}
+
+		// output == len(vout): split to all non OP_RETURN outputs
+		if len(destinations) == 0 {
+			continue
+		}
+
+		if edict.Amount.Sign() == 0 {
+			count := big.NewInt(int64(len(destinations)))
+			amount, remainder := new(big.Int).QuoRem(balance, count, new(big.Int))
+			for i, output := range destinations {
+				share := new(big.Int).Set(amount)
+				if big.NewInt(int64(i)).Cmp(remainder) < 0 {
+					share.Add(share, big.NewInt(1))
+				}
+				allocate(balance, share, id.String(), output)
+			}
+			continue
+		}
+
+		for _, output := range destinations {
+			amount := edict.Amount
+			if balance.Cmp(amount) < 0 {
+				amount = balance
+			}
+			allocate(balance, new(big.Int).Set(amount), id.String(), output)
+		}
+	}
+}
+
+// mint checks the mint terms of the rune & returns the minted amount
+func (u *Updater) mint(id RuneId, height uint64) (*big.Int, bool) {
+	ok, entry := u.cache.Runes.Get(id.String())
+	if !ok || entry.Terms == nil {
+		return nil, false
+	}
+
+	terms := entry.Terms
+	var start, end *uint64
+	if terms.OffsetStart != nil {
+		v := entry.Block + *terms.OffsetStart
+		start = &v
+	}
+	if terms.HeightStart != nil && (start == nil || *terms.HeightStart > *start) {
+		start = terms.HeightStart
+	}
+
+	if terms.OffsetEnd != nil {
+		v := entry.Block + *terms.OffsetEnd
+		end = &v
+	}
+	if terms.HeightEnd != nil && (end == nil || *terms.HeightEnd < *end) {
+		end = terms.HeightEnd
+	}
+
+	if start != nil && height < *start {
+		return nil, false
+	}
+	if end != nil && height >= *end {
+		return nil, false
+	}
+
+	if decimal.NewFromBigInt(new(big.Int).SetUint64(entry.Mints), 0).GreaterThanOrEqual(terms.Cap) {
+		return nil, false
+	}
+
+	u.cache.Runes.Mint(id.String())
+	return terms.Amount.BigInt(), true
+}
+
+// etched returns the id & name of the rune etched by the tx
+func (u *Updater) etched(height uint64, txIndex uint32, tx btcjson.TxRawResult, stone *Runestone) (*RuneId, *big.Int, error) {
+	if stone.Etching == nil {
+		return nil, nil, nil
+	}
+
+	id := &RuneId{Block: height, Tx: txIndex}
+	rn := stone.EtchedRune()
+	if rn == nil {
+		return id, ReservedRune(height, txIndex), nil
+	}
+
+	if rn.Cmp(MinimumAtHeight(height, u.firstRuneHeight)) < 0 || IsReserved(rn) {
+		return nil, nil, nil
+	}
+
+	if ok, _ := u.cache.Runes.GetIdByName(RuneName(rn)); ok {
+		return nil, nil, nil
+	}
+
+	committed, err := u.commitsToRune(height, tx, rn)
+	if err != nil || !committed {
+		return nil, nil, err
+	}
+	return id, rn, nil
+}
+
+// commitsToRune the rune commitment must be pushed in a taproot script spend input,
+// whose previous output has enough confirmations
+func (u *Updater) commitsToRune(height uint64, tx btcjson.TxRawResult, rn *big.Int) (bool, error) {
+	commitment := Commitment(rn)
+	for _, vin := range tx.Vin {
+		tapscript := tapscript(vin.Witness)
+		if tapscript == nil {
+			continue
+		}
+
+		tokenizer := txscript.MakeScriptTokenizer(0, tapscript)
+		for tokenizer.Next() {
+			if tokenizer.Opcode() > txscript.OP_PUSHDATA4 || !bytes.Equal(tokenizer.Data(), commitment) {
+				continue
+			}
+
+			scriptType, commitHeight, err := u.fetchOutput(vin.Txid, vin.Vout)
+			if err != nil {
+				return false, fmt.Errorf("fetch commit output %s:%d err[%v]", vin.Txid, vin.Vout, err)
+			}
+
+			if scriptType != taprootScriptType || commitHeight == 0 || commitHeight > height {
+				continue
+			}
+
+			if height-commitHeight+1 >= CommitConfirmations {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (u *Updater) createEntry(id RuneId, rn *big.Int, blockTime int64, txid string, stone *Runestone) *dcache.RuneEntry {
+	entry := &dcache.RuneEntry{
+		Id:        id.String(),
+		Block:     id.Block,
+		Tx:        id.Tx,
+		Rune:      RuneName(rn),
+		Number:    u.cache.Runes.Number(),
+		Premine:   decimal.Zero,
+		Burned:    decimal.Zero,
+		Etching:   txid,
+		Timestamp: blockTime,
+	}
+
+	// cenotaph etches the rune name only, without any supply
+	if !stone.Cenotaph {
+		etching := stone.Etching
+		if etching.Divisibility != nil {
+			entry.Divisibility = *etching.Divisibility
+		}
+		if etching.Premine != nil {
+			entry.Premine = decimal.NewFromBigInt(etching.Premine, 0)
+		}
+		if etching.Spacers != nil {
+			entry.Spacers = *etching.Spacers
+		}
+		if etching.Symbol != nil {
+			entry.Symbol = string(*etching.Symbol)
+		}
+		if etching.Terms != nil {
+			entry.Terms = &dcache.RuneTerms{
+				Amount:      decimalOrZero(etching.Terms.Amount),
+				Cap:         decimalOrZero(etching.Terms.Cap),
+				HeightStart: etching.Terms.HeightStart,
+				HeightEnd:   etching.Terms.HeightEnd,
+				OffsetStart: etching.Terms.OffsetStart,
+				OffsetEnd:   etching.Terms.OffsetEnd,
+			}
+		}
+		entry.Turbo = etching.Turbo
+	}
+
+	u.cache.Runes.Create(entry)
+	return entry
+}
+
+// tapscript the script of taproot script path spend, annex is skipped
+func tapscript(witness []string) []byte {
+	items := len(witness)
+	if items >= 2 {
+		if last, err := hex.DecodeString(witness[items-1]); err == nil && len(last) > 0 && last[0] == txscript.TaprootAnnexTag {
+			items--
+		}
+	}
+
+	if items < 2 {
+		return nil
+	}
+
+	script, err := hex.DecodeString(witness[items-2])
+	if err != nil {
+		return nil
+	}
+	return script
+}
+
+func decimalOrZero(v *big.Int) decimal.Decimal {
+	if v == nil {
+		return decimal.Zero
+	}
+	return decimal.NewFromBigInt(v, 0)
+}
+
+// Outpoint rune output key: txid:vout
+func Outpoint(txid string, vout uint32) string {
+	return fmt.Sprintf("%s:%d", txid, vout)
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package runes
+
+import (
+	"errors"
+	"math/big"
+)
+
+var (
+	ErrVarintOverlong     = errors.New("varint too long")
+	ErrVarintOverflow     = errors.New("varint overflow")
+	ErrVarintUnterminated = errors.New("varint unterminated")
+
+	// MaxU128 runes integers are unsigned 128-bit values
+	MaxU128 = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+)
+
+// DecodeVarint decodes a LEB128 encoded u128, returns the value & consumed bytes
+func DecodeVarint(buf []byte) (*big.Int, int, error) {
+	n := new(big.Int)
+	for i, b := range buf {
+		if i > 18 {
+			return nil, 0, ErrVarintOverlong
+		}
+
+		value := uint64(b & 0x7f)
+		if i == 18 && value&0x7c != 0 {
+			return nil, 0, ErrVarintOverflow
+		}
+
+		n.Or(n, new(big.Int).Lsh(new(big.Int).SetUint64(value), uint(7*i)))
+		if b&0x80 == 0 {
+			return n, i + 1, nil
+		}
+	}
+	return nil, 0, ErrVarintUnterminated
+}
+
+// EncodeVarint encodes u128 value by LEB128
+func EncodeVarint(n *big.Int) []byte {
+	v := new(big.Int).Set(n)
+	mask := big.NewInt(0x7f)
+	out := make([]byte, 0, 4)
+	for v.Cmp(mask) > 0 {
+		out = append(out, byte(new(big.Int).And(v, mask).Uint64())|0x80)
+		v.Rsh(v, 7)
+	}
+	return append(out, byte(v.Uint64()))
+}
+
+// decodeIntegers decodes the whole runestone payload into integers
+func decodeIntegers(payload []byte) ([]*big.Int, error) {
+	integers := make([]*big.Int, 0, len(payload))
+	for i := 0; i < len(payload); {
+		n, size, err := DecodeVarint(payload[i:])
+		if err != nil {
+			return nil, err
+		}
+		integers = append(integers, n)
+		i += size
+	}
+	return integers, nil
+}
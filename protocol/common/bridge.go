@@ -0,0 +1,115 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package common
+
+import (
+	"fmt"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol/bridge"
+	"github.com/uxuycom/indexer/xyerrors"
+	"github.com/uxuycom/indexer/xylog"
+	"strings"
+)
+
+// bridge token move of a bridge event, the lock moves tokens into the bridge contract,
+// the release pays them out & the mint credits tokens locked on another chain, nil if the event is not valid
+func (base *Protocol) bridge(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, omd *devents.MetaData, transfer *bridge.Transfer, deltas TxDeltas) *devents.TxResult {
+	tick := transfer.Tick
+	if transfer.TickIdx {
+		ok, name := base.cache.Inscription.GetNameByIdx(tick)
+		if !ok {
+			xylog.Logger.Infof("tx[%s] - bridge[%s] tick not found, idx[%s]", tx.Hash, transfer.Bridge, tick)
+			return nil
+		}
+		tick = name
+	}
+
+	md := omd.Copy()
+	md.Protocol = transfer.Protocol
+	md.Tick = strings.ToLower(strings.TrimSpace(tick))
+	result := &devents.TxResult{
+		MD:    md,
+		Block: block,
+		Tx:    tx,
+		Bridge: &devents.Bridge{
+			Name:     transfer.Bridge,
+			Contract: transfer.Contract,
+			Id:       transfer.Id,
+			From:     transfer.From,
+			To:       transfer.To,
+			Amount:   transfer.Amount,
+			Chain:    transfer.Chain,
+		},
+	}
+
+	var err *xyerrors.InsError
+	switch transfer.Kind {
+	case bridge.KindLock:
+		md.Operate = devents.OperateBridgeLock
+		err = base.VerifyExchange(md, transfer.From, transfer.Amount, deltas.Get(md.Protocol, md.Tick, transfer.From))
+		result.Transfer = &devents.Transfer{
+			Sender:   transfer.From,
+			Receives: []*devents.Receive{{Address: transfer.Contract, Amount: transfer.Amount}},
+		}
+	case bridge.KindRelease:
+		md.Operate = devents.OperateBridgeRelease
+		err = base.VerifyExchange(md, transfer.Contract, transfer.Amount, deltas.Get(md.Protocol, md.Tick, transfer.Contract))
+		result.Transfer = &devents.Transfer{
+			Sender:   transfer.Contract,
+			Receives: []*devents.Receive{{Address: transfer.To, Amount: transfer.Amount}},
+		}
+	case bridge.KindMint:
+		md.Operate = devents.OperateBridgeMint
+		err = base.verifyBridgeMint(md, transfer.Amount)
+		result.Mint = &devents.Mint{
+			Minter: transfer.To,
+			Amount: transfer.Amount,
+			Bridge: true,
+		}
+	}
+	if err != nil {
+		xylog.Logger.Infof("bridge[%s] %s verified failed, err:%v, data:%v", transfer.Bridge, transfer.Kind, err, transfer)
+		return nil
+	}
+
+	if result.Transfer != nil {
+		deltas.Move(md.Protocol, md.Tick, result.Transfer.Sender, result.Transfer.Receives[0].Address, transfer.Amount)
+	} else {
+		deltas.Move(md.Protocol, md.Tick, "", transfer.To, transfer.Amount)
+	}
+	return result
+}
+
+// verifyBridgeMint the bridged tick must be deployed on this chain
+func (base *Protocol) verifyBridgeMint(md *devents.MetaData, amount decimal.Decimal) *xyerrors.InsError {
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return xyerrors.NewInsError(-14, "bridge mint amount <= 0")
+	}
+
+	if ok, _ := base.cache.Inscription.Get(md.Protocol, md.Tick); !ok {
+		return xyerrors.NewInsError(-15, fmt.Sprintf("inscription not exist, protocol[%s]-tick[%s]", md.Protocol, md.Tick))
+	}
+	return nil
+}
@@ -28,20 +28,30 @@ import (
 	"github.com/shopspring/decimal"
 	"github.com/uxuycom/indexer/client/xycommon"
 	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol/types"
 	"github.com/uxuycom/indexer/xyerrors"
-	"math"
-	"math/big"
+	"strconv"
+	"strings"
 )
 
 type Deploy struct {
-	Tick      string          `json:"tick"`
-	MaxSupply decimal.Decimal `json:"max"`
-	MintLimit decimal.Decimal `json:"lim"`
-	Decimal   decimal.Decimal `json:"dec"`
+	Tick      string         `json:"tick"`
+	MaxSupply types.Amount   `json:"max"`
+	MintLimit types.Amount   `json:"lim"`
+	Decimal   types.Decimals `json:"dec"`
+
+	// extended params, only parsed once the deploy_params rule is active
+	StartBlock         decimal.Decimal `json:"start_block"`
+	EndBlock           decimal.Decimal `json:"end_block"`
+	MaxMintsPerAddress decimal.Decimal `json:"max_mints_per_address"`
+	MaxMintsPerBlock   decimal.Decimal `json:"max_mints_per_block"`
+	DeployerOnly       json.RawMessage `json:"deployer_only"`
+
+	params *devents.DeployParams
 }
 
 func (base *Protocol) Deploy(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) ([]*devents.TxResult, *xyerrors.InsError) {
-	d, err := base.verifyDeploy(tx, md)
+	d, err := base.verifyDeploy(block, tx, md)
 	if err != nil {
 		return nil, xyerrors.ErrDataVerifiedFailed.WrapCause(err)
 	}
@@ -52,15 +62,16 @@ func (base *Protocol) Deploy(block *xycommon.RpcBlock, tx *xycommon.RpcTransacti
 		Tx:    tx,
 		Deploy: &devents.Deploy{
 			Name:      d.Tick,
-			MaxSupply: d.MaxSupply,
-			MintLimit: d.MintLimit,
+			MaxSupply: d.MaxSupply.Decimal,
+			MintLimit: d.MintLimit.Decimal,
 			Decimal:   int8(d.Decimal.IntPart()),
+			Params:    d.params,
 		},
 	}
 	return []*devents.TxResult{result}, nil
 }
 
-func (base *Protocol) verifyDeploy(tx *xycommon.RpcTransaction, md *devents.MetaData) (*Deploy, *xyerrors.InsError) {
+func (base *Protocol) verifyDeploy(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) (*Deploy, *xyerrors.InsError) {
 	// metadata protocol / tick checking
 	if md.Protocol == "" || md.Tick == "" {
 		return nil, xyerrors.NewInsError(-12, fmt.Sprintf("protocol[%s] / tick[%s] nil", md.Protocol, md.Tick))
@@ -88,7 +99,7 @@ func (base *Protocol) verifyDeploy(tx *xycommon.RpcTransaction, md *devents.Meta
 	}
 
 	// max >= limit
-	if deploy.MaxSupply.LessThan(deploy.MintLimit) {
+	if deploy.MaxSupply.LessThan(deploy.MintLimit.Decimal) {
 		return nil, xyerrors.NewInsError(-16, "max < limit")
 	}
 
@@ -97,15 +108,72 @@ func (base *Protocol) verifyDeploy(tx *xycommon.RpcTransaction, md *devents.Meta
 		return nil, xyerrors.NewInsError(-17, fmt.Sprintf("invalid decimal:%s", deploy.Decimal.String()))
 	}
 
-	// maximum decimals is 18
-	if deploy.Decimal.IntPart() > 18 {
-		return nil, xyerrors.NewInsError(-18, fmt.Sprintf("decimal[%d] > 18", deploy.Decimal.IntPart()))
+	rules := base.RuleSet(block, md.Protocol)
+
+	// canonical decimal, once strict_amounts is active
+	if err := rules.CheckDecimals(deploy.Decimal); err != nil {
+		return nil, xyerrors.NewInsError(-17, err.Error())
+	}
+
+	// maximum decimals, 18 by default
+	if deploy.Decimal.IntPart() > rules.MaxDecimals {
+		return nil, xyerrors.NewInsError(-18, fmt.Sprintf("decimal[%d] > %d", deploy.Decimal.IntPart(), rules.MaxDecimals))
+	}
+
+	// max & limit fraction digits within decimals
+	for _, amount := range []types.Amount{deploy.MaxSupply, deploy.MintLimit} {
+		if err := rules.CheckAmount(amount, int8(deploy.Decimal.IntPart())); err != nil {
+			return nil, xyerrors.NewInsError(-23, err.Error())
+		}
+	}
+
+	// maximum supply, max uint64 by default
+	if deploy.MaxSupply.GreaterThan(rules.MaxSupply) {
+		return nil, xyerrors.NewInsError(-19, fmt.Sprintf("max[%s] > %s", deploy.MaxSupply.String(), rules.MaxSupply.String()))
 	}
 
-	// MaxSupply must <= uint64
-	maxUint64Decimal := decimal.NewFromBigInt(new(big.Int).SetUint64(math.MaxUint64), 0)
-	if deploy.MaxSupply.GreaterThan(maxUint64Decimal) {
-		return nil, xyerrors.NewInsError(-19, fmt.Sprintf("max[%s] > max_uint64", deploy.MaxSupply.String()))
+	// extended params are ignored before the rule activation
+	if rules.DeployParams {
+		params, err := verifyDeployParams(deploy)
+		if err != nil {
+			return nil, err
+		}
+		deploy.params = params
 	}
 	return deploy, nil
 }
+
+func verifyDeployParams(deploy *Deploy) (*devents.DeployParams, *xyerrors.InsError) {
+	params := &devents.DeployParams{}
+	fields := []struct {
+		name  string
+		value decimal.Decimal
+		dest  *uint64
+	}{
+		{"start_block", deploy.StartBlock, &params.StartBlock},
+		{"end_block", deploy.EndBlock, &params.EndBlock},
+		{"max_mints_per_address", deploy.MaxMintsPerAddress, &params.MaxMintsPerAddress},
+		{"max_mints_per_block", deploy.MaxMintsPerBlock, &params.MaxMintsPerBlock},
+	}
+	for _, field := range fields {
+		if !field.value.IsInteger() || field.value.IsNegative() || field.value.BigInt().BitLen() > 64 {
+			return nil, xyerrors.NewInsError(-20, fmt.Sprintf("invalid %s:%s", field.name, field.value.String()))
+		}
+		*field.dest = field.value.BigInt().Uint64()
+	}
+
+	// end_block >= start_block, 0 means unbounded
+	if params.StartBlock > 0 && params.EndBlock > 0 && params.EndBlock < params.StartBlock {
+		return nil, xyerrors.NewInsError(-21, fmt.Sprintf("end_block[%d] < start_block[%d]", params.EndBlock, params.StartBlock))
+	}
+
+	// deployer_only accepts both bool & string values
+	if len(deploy.DeployerOnly) > 0 {
+		v, err := strconv.ParseBool(strings.Trim(string(deploy.DeployerOnly), `"`))
+		if err != nil {
+			return nil, xyerrors.NewInsError(-22, fmt.Sprintf("invalid deployer_only:%s", deploy.DeployerOnly))
+		}
+		params.DeployerOnly = v
+	}
+	return params, nil
+}
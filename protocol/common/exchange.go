@@ -0,0 +1,146 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package common
+
+import (
+	"fmt"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol/marketplace"
+	"github.com/uxuycom/indexer/xyerrors"
+	"github.com/uxuycom/indexer/xylog"
+	"strings"
+)
+
+// Exchange token moves decoded from the configured marketplace & bridge events of every protocol in the tx,
+// the events are verified in log order against the cache state before the tx plus the deltas of the earlier events
+func (base *Protocol) Exchange(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, omd *devents.MetaData, deltas TxDeltas) []*devents.TxResult {
+	markets := base.markets.Transfers(tx)
+	bridges := base.bridges.Transfers(tx)
+
+	items := make([]*devents.TxResult, 0, len(markets)+len(bridges))
+	for len(markets) > 0 || len(bridges) > 0 {
+		var item *devents.TxResult
+		if len(bridges) <= 0 || (len(markets) > 0 && markets[0].Index <= bridges[0].Index) {
+			item = base.exchange(block, tx, omd, markets[0], deltas)
+			markets = markets[1:]
+		} else {
+			item = base.bridge(block, tx, omd, bridges[0], deltas)
+			bridges = bridges[1:]
+		}
+
+		if item != nil {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// exchange token transfer of a marketplace event, nil if the event is not valid
+func (base *Protocol) exchange(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, omd *devents.MetaData, transfer *marketplace.Transfer, deltas TxDeltas) *devents.TxResult {
+	tick := transfer.Tick
+	if transfer.TickIdx {
+		ok, name := base.cache.Inscription.GetNameByIdx(tick)
+		if !ok {
+			xylog.Logger.Infof("tx[%s] - marketplace[%s] tick not found, idx[%s]", tx.Hash, transfer.Marketplace, tick)
+			return nil
+		}
+		tick = name
+	}
+
+	md := omd.Copy()
+	md.Protocol = transfer.Protocol
+	md.Operate = devents.OperateExchange
+	md.Tick = strings.ToLower(strings.TrimSpace(tick))
+	if err := base.VerifyExchange(md, transfer.From, transfer.Amount, deltas.Get(md.Protocol, md.Tick, transfer.From)); err != nil {
+		xylog.Logger.Infof("marketplace[%s] exchange verified failed, err:%v, data:%v", transfer.Marketplace, err, transfer)
+		return nil
+	}
+	deltas.Move(md.Protocol, md.Tick, transfer.From, transfer.To, transfer.Amount)
+
+	return &devents.TxResult{
+		MD:    md,
+		Block: block,
+		Tx:    tx,
+		Transfer: &devents.Transfer{
+			Sender: transfer.From,
+			Receives: []*devents.Receive{
+				{
+					Address: transfer.To,
+					Amount:  transfer.Amount,
+				},
+			},
+		},
+	}
+}
+
+// VerifyExchange checks the sender balance, delta is the sender balance change of the previous events of the tx
+func (base *Protocol) VerifyExchange(md *devents.MetaData, from string, amount, delta decimal.Decimal) *xyerrors.InsError {
+	if amount.LessThanOrEqual(decimal.Zero) {
+		return xyerrors.NewInsError(-14, "exchange amount <= 0")
+	}
+
+	var (
+		protocol = md.Protocol
+		tick     = md.Tick
+	)
+	ok, inscription := base.cache.Inscription.Get(protocol, tick)
+	if !ok || inscription == nil {
+		return xyerrors.NewInsError(-15, fmt.Sprintf("inscription not exist, protocol[%s]-tick[%s]", protocol, tick))
+	}
+
+	// sender balance checking, the sender may only hold what earlier events of the tx sent
+	available := delta
+	if ok, balance := base.cache.Balance.Get(protocol, tick, from); ok {
+		available = available.Add(balance.Available)
+	} else if delta.LessThanOrEqual(decimal.Zero) {
+		return xyerrors.NewInsError(-16, fmt.Sprintf("sender balance record not exist, tick[%s-%s], address[%s]", protocol, tick, from))
+	}
+
+	// balance available checking, locked balances can't be spent
+	if available.LessThan(amount) {
+		return xyerrors.NewInsError(-17, fmt.Sprintf("sender available balance[%v] < transfer amount[%v]", available, amount))
+	}
+	return nil
+}
+
+// TxDeltas net balance changes of the earlier events of a tx by (protocol, tick, address),
+// the events of a tx are verified one after another against the cache state before the tx
+type TxDeltas map[string]decimal.Decimal
+
+func (d TxDeltas) key(protocol, tick, address string) string {
+	return fmt.Sprintf("%s_%s_%s", protocol, tick, strings.ToLower(address))
+}
+
+func (d TxDeltas) Get(protocol, tick, address string) decimal.Decimal {
+	return d[d.key(protocol, tick, address)]
+}
+
+// Move records amount sent from -> to, an empty from credits the receiver only
+func (d TxDeltas) Move(protocol, tick, from, to string, amount decimal.Decimal) {
+	if from != "" {
+		d[d.key(protocol, tick, from)] = d.Get(protocol, tick, from).Sub(amount)
+	}
+	d[d.key(protocol, tick, to)] = d.Get(protocol, tick, to).Add(amount)
+}
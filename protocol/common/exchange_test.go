@@ -0,0 +1,141 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package common
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ecommon "github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol/marketplace"
+	"github.com/uxuycom/indexer/protocol/types"
+	"github.com/uxuycom/indexer/xylog"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const soldABI = `[{"anonymous": false, "name": "Sold", "type": "event", "inputs": [
+  {"indexed": true, "name": "seller", "type": "address"},
+  {"indexed": true, "name": "buyer", "type": "address"},
+  {"indexed": false, "name": "ticker", "type": "string"},
+  {"indexed": false, "name": "qty", "type": "uint256"}
+]}]`
+
+func TestExchangeRunningBalance(t *testing.T) {
+	const (
+		contract = "0x1000000000000000000000000000000000000001"
+		bsc      = "0x5000000000000000000000000000000000000005"
+		seller   = "0x2000000000000000000000000000000000000002"
+		buyer    = "0x3000000000000000000000000000000000000003"
+		other    = "0x4000000000000000000000000000000000000004"
+	)
+	xylog.InitLog(logrus.ErrorLevel, "")
+	abiFile := filepath.Join(t.TempDir(), "sold.json")
+	if err := os.WriteFile(abiFile, []byte(soldABI), 0600); err != nil {
+		t.Fatal(err)
+	}
+	markets, err := marketplace.NewRegistry([]*config.MarketplaceConfig{{
+		Name:     "sold",
+		Contract: contract,
+		AbiFile:  abiFile,
+		Event:    "Sold",
+		Fields:   config.MarketplaceFields{Tick: "ticker", From: "seller", To: "buyer", Amount: "qty"},
+	}, {
+		Name:     "sold-bsc",
+		Protocol: types.BSC20Protocol,
+		Contract: bsc,
+		AbiFile:  abiFile,
+		Event:    "Sold",
+		Fields:   config.MarketplaceFields{Tick: "ticker", From: "seller", To: "buyer", Amount: "qty"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cache := dcache.NewManager(nil, "avalanche")
+	cache.Inscription = dcache.NewInscription()
+	cache.Balance = dcache.NewBalance()
+	cache.Inscription.Create(types.ASC20Protocol, "avav", &dcache.Tick{})
+	cache.Balance.Create(types.ASC20Protocol, "avav", seller, &dcache.BalanceItem{
+		Available: decimal.NewFromInt(100),
+		Overall:   decimal.NewFromInt(100),
+	})
+	cache.Inscription.Create(types.BSC20Protocol, "avav", &dcache.Tick{})
+	cache.Balance.Create(types.BSC20Protocol, "avav", seller, &dcache.BalanceItem{
+		Available: decimal.NewFromInt(10),
+		Overall:   decimal.NewFromInt(10),
+	})
+	p := NewProtocol(cache, nil, markets, nil)
+
+	parsedABI, _ := abi.JSON(strings.NewReader(soldABI))
+	soldAt := func(contract, from, to string, qty int64) xycommon.RpcLog {
+		data, _ := parsedABI.Events["Sold"].Inputs.NonIndexed().Pack("avav", big.NewInt(qty))
+		return xycommon.RpcLog{
+			Address: ecommon.HexToAddress(contract),
+			Topics: []ecommon.Hash{
+				parsedABI.Events["Sold"].ID,
+				ecommon.BytesToHash(ecommon.HexToAddress(from).Bytes()),
+				ecommon.BytesToHash(ecommon.HexToAddress(to).Bytes()),
+			},
+			Data: data,
+		}
+	}
+	sold := func(from, to string, qty int64) xycommon.RpcLog {
+		return soldAt(contract, from, to, qty)
+	}
+
+	tests := []struct {
+		name    string
+		events  []xycommon.RpcLog
+		amounts []string
+	}{
+		{"within balance", []xycommon.RpcLog{sold(seller, buyer, 60), sold(seller, other, 40)}, []string{"asc-20:60", "asc-20:40"}},
+		{"over balance across events", []xycommon.RpcLog{sold(seller, buyer, 60), sold(seller, other, 60)}, []string{"asc-20:60"}},
+		{"received earlier in the tx", []xycommon.RpcLog{sold(seller, buyer, 60), sold(buyer, other, 50)}, []string{"asc-20:60", "asc-20:50"}},
+		{"resold over the received amount", []xycommon.RpcLog{sold(seller, buyer, 60), sold(buyer, other, 70)}, []string{"asc-20:60"}},
+		{"every marketplace of the tx", []xycommon.RpcLog{sold(seller, buyer, 60), soldAt(bsc, seller, other, 10)}, []string{"asc-20:60", "bsc-20:10"}},
+		{"received in another protocol", []xycommon.RpcLog{sold(seller, buyer, 60), soldAt(bsc, buyer, other, 50)}, []string{"asc-20:60"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &xycommon.RpcTransaction{Hash: "0x01", Events: tt.events}
+			items := p.Exchange(&xycommon.RpcBlock{Number: big.NewInt(1)}, tx, &devents.MetaData{Protocol: types.ASC20Protocol}, make(TxDeltas))
+
+			amounts := make([]string, 0, len(items))
+			for _, item := range items {
+				amounts = append(amounts, item.MD.Protocol+":"+item.Transfer.Receives[0].Amount.String())
+			}
+			if strings.Join(amounts, ",") != strings.Join(tt.amounts, ",") {
+				t.Fatalf("exchanged amounts[%v], want[%v]", amounts, tt.amounts)
+			}
+		})
+	}
+}
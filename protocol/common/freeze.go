@@ -0,0 +1,96 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol/types"
+	"github.com/uxuycom/indexer/xyerrors"
+)
+
+type Freeze struct {
+	Amount types.Amount `json:"amt"`
+}
+
+// Freeze moves the amount of the sender from the available into the locked balance, unfreeze moves it back
+func (base *Protocol) Freeze(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) ([]*devents.TxResult, *xyerrors.InsError) {
+	fz, err := base.verifyFreeze(block, tx, md)
+	if err != nil {
+		return nil, xyerrors.ErrDataVerifiedFailed.WrapCause(err)
+	}
+
+	result := &devents.TxResult{
+		MD:    md.Copy(),
+		Block: block,
+		Tx:    tx,
+		Freeze: &devents.Freeze{
+			Address: tx.From,
+			Amount:  fz.Amount.Decimal,
+		},
+	}
+	return []*devents.TxResult{result}, nil
+}
+
+func (base *Protocol) verifyFreeze(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) (*Freeze, *xyerrors.InsError) {
+	if !base.RuleSet(block, md.Protocol).Freeze {
+		return nil, xyerrors.NewInsError(-19, fmt.Sprintf("protocol[%s] %s not activated", md.Protocol, md.Operate))
+	}
+
+	fz := &Freeze{}
+	if err := json.Unmarshal([]byte(md.Data), fz); err != nil {
+		return nil, xyerrors.NewInsError(-13, fmt.Sprintf("data json deocde err:%v, data[%s]", err, md.Data))
+	}
+
+	if fz.Amount.LessThanOrEqual(decimal.Zero) {
+		return nil, xyerrors.NewInsError(-14, fmt.Sprintf("%s amount <= 0", md.Operate))
+	}
+
+	ok, inscription := base.cache.Inscription.Get(md.Protocol, md.Tick)
+	if !ok || inscription == nil {
+		return nil, xyerrors.NewInsError(-15, fmt.Sprintf("inscription not exist, protocol[%s]-tick[%s]", md.Protocol, md.Tick))
+	}
+
+	// amount fraction digits within decimals
+	if err := base.RuleSet(block, md.Protocol).CheckAmount(fz.Amount, inscription.Decimals); err != nil {
+		return nil, xyerrors.NewInsError(-23, err.Error())
+	}
+
+	ok, balance := base.cache.Balance.Get(md.Protocol, md.Tick, tx.From)
+	if !ok {
+		return nil, xyerrors.NewInsError(-16, fmt.Sprintf("sender balance record not exist, tick[%s-%s], address[%s]", md.Protocol, md.Tick, tx.From))
+	}
+
+	// freeze spends the available balance, unfreeze the locked balance
+	if md.Operate == devents.OperateFreeze && balance.Available.LessThan(fz.Amount.Decimal) {
+		return nil, xyerrors.NewInsError(-17, fmt.Sprintf("available balance[%v] < freeze amount[%v]", balance.Available, fz.Amount))
+	}
+	locked := balance.Overall.Sub(balance.Available)
+	if md.Operate == devents.OperateUnfreeze && locked.LessThan(fz.Amount.Decimal) {
+		return nil, xyerrors.NewInsError(-17, fmt.Sprintf("locked balance[%v] < unfreeze amount[%v]", locked, fz.Amount))
+	}
+	return fz, nil
+}
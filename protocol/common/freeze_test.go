@@ -0,0 +1,99 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package common
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol/types"
+	"math/big"
+	"testing"
+)
+
+func TestVerifyFreeze(t *testing.T) {
+	const (
+		holder   = "0x1111111111111111111111111111111111111111"
+		receiver = "0x2222222222222222222222222222222222222222"
+	)
+	rules, err := types.NewRuleSchedule([]*config.RuleActivation{
+		{Protocol: types.ASC20Protocol, Rule: types.RuleFreeze, Height: 100, Value: "true"},
+		{Protocol: types.ASC20Protocol, Rule: types.RuleStrictAmounts, Height: 100, Value: "true"},
+	})
+	if err != nil {
+		t.Fatalf("rules err:%v", err)
+	}
+
+	cache := dcache.NewManager(nil, "avalanche")
+	cache.Inscription = dcache.NewInscription()
+	cache.Balance = dcache.NewBalance()
+	cache.Inscription.Create(types.ASC20Protocol, "avav", &dcache.Tick{Decimals: 2})
+
+	// 100 overall, 60 available & 40 locked
+	cache.Balance.Create(types.ASC20Protocol, "avav", holder, &dcache.BalanceItem{
+		Available: decimal.NewFromInt(60),
+		Overall:   decimal.NewFromInt(100),
+	})
+	p := NewProtocol(cache, rules, nil, nil)
+
+	tests := []struct {
+		name    string
+		height  int64
+		operate string
+		amt     string
+		valid   bool
+	}{
+		{"before activation", 99, devents.OperateFreeze, "1", false},
+		{"freeze available", 100, devents.OperateFreeze, "60", true},
+		{"freeze over available", 100, devents.OperateFreeze, "60.01", false},
+		{"unfreeze locked", 100, devents.OperateUnfreeze, "40", true},
+		{"unfreeze over locked", 100, devents.OperateUnfreeze, "41", false},
+		{"zero amount", 100, devents.OperateFreeze, "0", false},
+		{"decimals exceeded", 100, devents.OperateFreeze, "1.001", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block := &xycommon.RpcBlock{Number: big.NewInt(tt.height)}
+			tx := &xycommon.RpcTransaction{From: holder, To: holder}
+			md := &devents.MetaData{Protocol: types.ASC20Protocol, Tick: "avav", Operate: tt.operate, Data: `{"amt":"` + tt.amt + `"}`}
+			if _, err := p.verifyFreeze(block, tx, md); (err == nil) != tt.valid {
+				t.Fatalf("got err[%v], want valid[%v]", err, tt.valid)
+			}
+		})
+	}
+
+	// locked balances can't be transferred
+	block := &xycommon.RpcBlock{Number: big.NewInt(100)}
+	tx := &xycommon.RpcTransaction{From: holder, To: receiver}
+	md := &devents.MetaData{Protocol: types.ASC20Protocol, Tick: "avav", Operate: devents.OperateTransfer, Data: `{"amt":"61"}`}
+	if _, err := p.verifyTransfer(block, tx, md); err == nil {
+		t.Fatalf("transfer over the available balance must be rejected")
+	}
+	md.Data = `{"amt":"60"}`
+	if _, err := p.verifyTransfer(block, tx, md); err != nil {
+		t.Fatalf("transfer of the available balance err:%v", err)
+	}
+}
@@ -26,22 +26,65 @@ import (
 	"github.com/uxuycom/indexer/client/xycommon"
 	"github.com/uxuycom/indexer/dcache"
 	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol/bridge"
+	"github.com/uxuycom/indexer/protocol/marketplace"
+	"github.com/uxuycom/indexer/protocol/types"
 	"github.com/uxuycom/indexer/xyerrors"
+	"math"
 )
 
 const DataPrefix = "0x646174613a"
 
+// GzipPrefix ESIP-7 gzip compressed calldata
+const GzipPrefix = "0x1f8b"
+
 type Protocol struct {
-	cache *dcache.Manager
+	cache   *dcache.Manager
+	rules   *types.RuleSchedule
+	markets *marketplace.Registry
+	bridges *bridge.Registry
 }
 
-func NewProtocol(cache *dcache.Manager) *Protocol {
+func NewProtocol(cache *dcache.Manager, rules *types.RuleSchedule, markets *marketplace.Registry, bridges *bridge.Registry) *Protocol {
 	return &Protocol{
-		cache: cache,
+		cache:   cache,
+		rules:   rules,
+		markets: markets,
+		bridges: bridges,
 	}
 }
 
+// RuleSet returns the protocol rules active at the block, the latest rules without block
+func (base *Protocol) RuleSet(block *xycommon.RpcBlock, protocol string) *types.RuleSet {
+	height := uint64(math.MaxUint64)
+	if block != nil && block.Number != nil {
+		height = block.Number.Uint64()
+	}
+	return base.rules.At(protocol, height)
+}
+
 func (base *Protocol) Parse(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) ([]*devents.TxResult, *xyerrors.InsError) {
+	items, err := base.parse(block, tx, md)
+	base.MarkBurns(block, items)
+	return items, err
+}
+
+// MarkBurns flags the transfer senders & receives of the burn addresses active at the block
+func (base *Protocol) MarkBurns(block *xycommon.RpcBlock, items []*devents.TxResult) {
+	for _, item := range items {
+		if item.Transfer == nil {
+			continue
+		}
+
+		rs := base.RuleSet(block, item.MD.Protocol)
+		item.Transfer.SenderBurn = rs.IsBurnAddress(item.Transfer.Sender)
+		for _, receive := range item.Transfer.Receives {
+			receive.Burn = rs.IsBurnAddress(receive.Address)
+		}
+	}
+}
+
+func (base *Protocol) parse(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) ([]*devents.TxResult, *xyerrors.InsError) {
 	switch md.Operate {
 	case devents.OperateDeploy:
 		return base.Deploy(block, tx, md)
@@ -49,6 +92,10 @@ func (base *Protocol) Parse(block *xycommon.RpcBlock, tx *xycommon.RpcTransactio
 		return base.Mint(block, tx, md)
 	case devents.OperateTransfer:
 		return base.Transfer(block, tx, md)
+	case devents.OperateExchange, devents.OperateBridge:
+		return base.Exchange(block, tx, md, make(TxDeltas)), nil
+	case devents.OperateFreeze, devents.OperateUnfreeze:
+		return base.Freeze(block, tx, md)
 	}
 	return nil, nil
 }
@@ -27,16 +27,19 @@ import (
 	"fmt"
 	"github.com/shopspring/decimal"
 	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/dcache"
 	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol/types"
 	"github.com/uxuycom/indexer/xyerrors"
+	"strings"
 )
 
 type Mint struct {
-	Amount decimal.Decimal `json:"amt"`
+	Amount types.Amount `json:"amt"`
 }
 
 func (base *Protocol) Mint(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) ([]*devents.TxResult, *xyerrors.InsError) {
-	m, err := base.verifyMint(tx, md)
+	m, err := base.verifyMint(block, tx, md)
 	if err != nil {
 		return nil, xyerrors.ErrDataVerifiedFailed.WrapCause(err)
 	}
@@ -46,13 +49,13 @@ func (base *Protocol) Mint(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction
 		Tx:    tx,
 		Mint: &devents.Mint{
 			Minter: tx.To,
-			Amount: m.Amount,
+			Amount: m.Amount.Decimal,
 		},
 	}
 	return []*devents.TxResult{result}, nil
 }
 
-func (base *Protocol) verifyMint(tx *xycommon.RpcTransaction, md *devents.MetaData) (*Mint, *xyerrors.InsError) {
+func (base *Protocol) verifyMint(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) (*Mint, *xyerrors.InsError) {
 	mint := &Mint{}
 	err := json.Unmarshal([]byte(md.Data), mint)
 	if err != nil {
@@ -72,6 +75,21 @@ func (base *Protocol) verifyMint(tx *xycommon.RpcTransaction, md *devents.MetaDa
 		return nil, xyerrors.NewInsError(-15, fmt.Sprintf("inscription not exist, protocol[%s], tick[%s]", protocol, tick))
 	}
 
+	// self mint ticks can only be minted by children of the deploy inscription
+	if inscription.SelfMint && (md.Parent == "" || md.Parent != inscription.InscriptionId) {
+		return nil, xyerrors.NewInsError(-16, fmt.Sprintf("self mint tick[%s] parent[%s] is not the deploy inscription", tick, md.Parent))
+	}
+
+	// mint amount fraction digits within decimals
+	if err := base.RuleSet(block, protocol).CheckAmount(mint.Amount, inscription.Decimals); err != nil {
+		return nil, xyerrors.NewInsError(-27, err.Error())
+	}
+
+	// deploy params checking
+	if err := base.verifyMintParams(block, tx, md, inscription); err != nil {
+		return nil, err
+	}
+
 	// mint amount maximum checking
 	if mint.Amount.GreaterThan(inscription.LimitPerMint) {
 		return nil, xyerrors.NewInsError(-17, "mint amount exceeds limit per mint")
@@ -87,10 +105,42 @@ func (base *Protocol) verifyMint(tx *xycommon.RpcTransaction, md *devents.MetaDa
 		return nil, xyerrors.NewInsError(-20, "mint completed")
 	}
 
-	// final mint = math.Min(Total Supply - Minted)
+	// final mint = math.Min(Total Supply - Minted), rejected if truncation disabled
 	mintLeft := inscription.TotalSupply.Sub(stats.Minted)
 	if mint.Amount.GreaterThan(mintLeft) {
-		mint.Amount = mintLeft
+		if !base.RuleSet(block, protocol).MintTruncate {
+			return nil, xyerrors.NewInsError(-21, fmt.Sprintf("mint amount[%s] > mint left[%s]", mint.Amount, mintLeft))
+		}
+		mint.Amount = types.NewAmount(mintLeft)
 	}
 	return mint, nil
 }
+
+// verifyMintParams mint windows & caps of the deploy params, 0 means unbounded
+func (base *Protocol) verifyMintParams(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData, inscription *dcache.Tick) *xyerrors.InsError {
+	var height uint64
+	if block != nil && block.Number != nil {
+		height = block.Number.Uint64()
+	}
+
+	if inscription.StartBlock > 0 && height < inscription.StartBlock {
+		return xyerrors.NewInsError(-22, fmt.Sprintf("mint not started, height[%d] < start_block[%d]", height, inscription.StartBlock))
+	}
+
+	if inscription.EndBlock > 0 && height > inscription.EndBlock {
+		return xyerrors.NewInsError(-23, fmt.Sprintf("mint ended, height[%d] > end_block[%d]", height, inscription.EndBlock))
+	}
+
+	if inscription.DeployerOnly && !strings.EqualFold(tx.From, inscription.DeployBy) {
+		return xyerrors.NewInsError(-24, fmt.Sprintf("deployer only tick[%s], sender[%s] is not the deployer", md.Tick, tx.From))
+	}
+
+	if inscription.MaxMintsPerAddress > 0 && base.cache.MintCounter.AddressMints(md.Protocol, md.Tick, tx.To) >= inscription.MaxMintsPerAddress {
+		return xyerrors.NewInsError(-25, fmt.Sprintf("minter[%s] reached max mints per address[%d]", tx.To, inscription.MaxMintsPerAddress))
+	}
+
+	if inscription.MaxMintsPerBlock > 0 && base.cache.MintCounter.BlockMints(md.Protocol, md.Tick, height) >= inscription.MaxMintsPerBlock {
+		return xyerrors.NewInsError(-26, fmt.Sprintf("block[%d] reached max mints per block[%d]", height, inscription.MaxMintsPerBlock))
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package common
+
+import (
+	"encoding/json"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol/types"
+	"math/big"
+	"testing"
+)
+
+func TestVerifyDeployParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		params *devents.DeployParams
+	}{
+		{"no params", `{}`, &devents.DeployParams{}},
+		{"window", `{"start_block":"100","end_block":200}`, &devents.DeployParams{StartBlock: 100, EndBlock: 200}},
+		{"caps", `{"max_mints_per_address":"5","max_mints_per_block":"10","deployer_only":"true"}`, &devents.DeployParams{MaxMintsPerAddress: 5, MaxMintsPerBlock: 10, DeployerOnly: true}},
+		{"deployer only bool", `{"deployer_only":true}`, &devents.DeployParams{DeployerOnly: true}},
+		{"end before start", `{"start_block":"200","end_block":"100"}`, nil},
+		{"negative", `{"max_mints_per_block":"-1"}`, nil},
+		{"fraction", `{"start_block":"1.5"}`, nil},
+		{"overflow", `{"end_block":"18446744073709551616"}`, nil},
+		{"invalid flag", `{"deployer_only":"yes"}`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deploy := &Deploy{}
+			if err := json.Unmarshal([]byte(tt.data), deploy); err != nil {
+				t.Fatalf("json decode err:%v", err)
+			}
+			params, err := verifyDeployParams(deploy)
+			if tt.params == nil {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", params)
+				}
+				return
+			}
+			if err != nil || *params != *tt.params {
+				t.Fatalf("got %+v err[%v], want %+v", params, err, tt.params)
+			}
+		})
+	}
+}
+
+func TestVerifyMintParams(t *testing.T) {
+	const (
+		deployer = "0x1111111111111111111111111111111111111111"
+		minter   = "0x2222222222222222222222222222222222222222"
+	)
+	cache := dcache.NewManager(nil, "avalanche")
+	cache.MintCounter = dcache.NewMintCounter()
+	p := NewProtocol(cache, nil, nil, nil)
+
+	md := &devents.MetaData{Protocol: types.ASC20Protocol, Tick: "avav"}
+	tick := &dcache.Tick{
+		DeployBy:           deployer,
+		StartBlock:         100,
+		EndBlock:           200,
+		MaxMintsPerAddress: 2,
+		MaxMintsPerBlock:   3,
+	}
+	cache.MintCounter.Add(md.Protocol, md.Tick, minter, 150)
+	cache.MintCounter.Add(md.Protocol, md.Tick, minter, 150)
+	cache.MintCounter.Add(md.Protocol, md.Tick, deployer, 150)
+
+	tests := []struct {
+		name         string
+		height       int64
+		from, to     string
+		deployerOnly bool
+		valid        bool
+	}{
+		{"before start", 99, deployer, deployer, false, false},
+		{"after end", 201, deployer, deployer, false, false},
+		{"address cap reached", 151, minter, minter, false, false},
+		{"block cap reached", 150, deployer, deployer, false, false},
+		{"next block", 151, deployer, deployer, false, true},
+		{"not deployer", 151, minter, deployer, true, false},
+		{"deployer", 151, "0x1111111111111111111111111111111111111111", deployer, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tick.DeployerOnly = tt.deployerOnly
+			block := &xycommon.RpcBlock{Number: big.NewInt(tt.height)}
+			tx := &xycommon.RpcTransaction{From: tt.from, To: tt.to}
+			if err := p.verifyMintParams(block, tx, md, tick); (err == nil) != tt.valid {
+				t.Fatalf("got err[%v], want valid[%v]", err, tt.valid)
+			}
+		})
+	}
+}
@@ -23,51 +23,88 @@
 package common
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/shopspring/decimal"
 	"github.com/uxuycom/indexer/client/xycommon"
 	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol/types"
 	"github.com/uxuycom/indexer/xyerrors"
+	"strings"
 )
 
 type Transfer struct {
-	Amount decimal.Decimal `json:"amt"`
+	Amount types.Amount `json:"amt"`
+
+	// To batch transfer recipients list, only decoded when it is a json array
+	To       json.RawMessage    `json:"to,omitempty"`
+	Receives []*TransferReceive `json:"-"`
+}
+
+type TransferReceive struct {
+	Address string       `json:"addr"`
+	Amount  types.Amount `json:"amt"`
 }
 
 func (base *Protocol) Transfer(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) ([]*devents.TxResult, *xyerrors.InsError) {
-	tf, err := base.verifyTransfer(tx, md)
+	tf, err := base.verifyTransfer(block, tx, md)
 	if err != nil {
 		return nil, xyerrors.ErrDataVerifiedFailed.WrapCause(err)
 	}
+
+	receives := make([]*devents.Receive, 0, len(tf.Receives))
+	for _, item := range tf.Receives {
+		receives = append(receives, &devents.Receive{
+			Address: item.Address,
+			Amount:  item.Amount.Decimal,
+		})
+	}
 	result := &devents.TxResult{
 		MD:    md,
 		Block: block,
 		Tx:    tx,
 		Transfer: &devents.Transfer{
-			Sender: tx.From,
-			Receives: []*devents.Receive{
-				{
-					Address: tx.To,
-					Amount:  tf.Amount,
-				},
-			},
+			Sender:   tx.From,
+			Receives: receives,
 		},
 	}
 	return []*devents.TxResult{result}, nil
 }
 
-func (base *Protocol) verifyTransfer(tx *xycommon.RpcTransaction, md *devents.MetaData) (*Transfer, *xyerrors.InsError) {
+func (base *Protocol) verifyTransfer(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) (*Transfer, *xyerrors.InsError) {
 	tf := &Transfer{}
 	err := json.Unmarshal([]byte(md.Data), tf)
 	if err != nil {
 		return nil, xyerrors.NewInsError(-13, fmt.Sprintf("data json deocde err:%v, data[%s]", err, md.Data))
 	}
 
-	if tf.Amount.LessThanOrEqual(decimal.Zero) {
-		return nil, xyerrors.NewInsError(-14, "transfer amount <= 0")
+	// before the batch_transfer rule activation "to" is ignored & amt goes to the tx recipient
+	rules := base.RuleSet(block, md.Protocol)
+	if to := bytes.TrimSpace(tf.To); rules.BatchTransfer > 0 && len(to) > 0 && to[0] == '[' {
+		if err := verifyBatchReceives(md, tf, to, rules.BatchTransfer); err != nil {
+			return nil, err
+		}
+	} else {
+		tf.Receives = []*TransferReceive{{Address: tx.To, Amount: tf.Amount}}
 	}
 
+	// no balance can exceed the maximum supply
+	maxSupply := rules.MaxSupply
+	total := decimal.Zero
+	for _, item := range tf.Receives {
+		if item.Amount.LessThanOrEqual(decimal.Zero) {
+			return nil, xyerrors.NewInsError(-14, "transfer amount <= 0")
+		}
+
+		if item.Amount.GreaterThan(maxSupply) {
+			return nil, xyerrors.NewInsError(-18, fmt.Sprintf("transfer amount[%s] > %s", item.Amount, maxSupply))
+		}
+		total = total.Add(item.Amount.Decimal)
+	}
+	tf.Amount = types.NewAmount(total)
+
 	var (
 		protocol = md.Protocol
 		tick     = md.Tick
@@ -77,15 +114,50 @@ func (base *Protocol) verifyTransfer(tx *xycommon.RpcTransaction, md *devents.Me
 		return nil, xyerrors.NewInsError(-15, fmt.Sprintf("inscription not exist, protocol[%s]-tick[%s]", protocol, tick))
 	}
 
+	// amount fraction digits within decimals
+	for _, item := range tf.Receives {
+		if err := rules.CheckAmount(item.Amount, inscription.Decimals); err != nil {
+			return nil, xyerrors.NewInsError(-23, err.Error())
+		}
+	}
+
 	// sender balance checking
 	ok, balance := base.cache.Balance.Get(protocol, tick, tx.From)
 	if !ok {
 		return nil, xyerrors.NewInsError(-16, fmt.Sprintf("sender balance record not exist, tick[%s-%s], address[%s]", protocol, tick, tx.From))
 	}
 
-	// balance available checking
-	if balance.Overall.LessThan(tf.Amount) {
-		return nil, xyerrors.NewInsError(-17, fmt.Sprintf("sender total balance[%v] < transfer amount[%v]", balance.Overall, tf.Amount))
+	// balance available checking, locked balances can't be spent, a batch transfer moves all or nothing
+	if balance.Available.LessThan(tf.Amount.Decimal) {
+		return nil, xyerrors.NewInsError(-17, fmt.Sprintf("sender available balance[%v] < transfer amount[%v]", balance.Available, tf.Amount))
 	}
 	return tf, nil
 }
+
+// verifyBatchReceives decodes the (addr, amt) pairs of a batch transfer of at most maxReceives recipients
+func verifyBatchReceives(md *devents.MetaData, tf *Transfer, to []byte, maxReceives int) *xyerrors.InsError {
+	receives := make([]*TransferReceive, 0)
+	if err := json.Unmarshal(to, &receives); err != nil {
+		return xyerrors.NewInsError(-13, fmt.Sprintf("batch transfer json deocde err:%v, data[%s]", err, md.Data))
+	}
+
+	if len(receives) <= 0 || len(receives) > maxReceives {
+		return xyerrors.NewInsError(-20, fmt.Sprintf("batch transfer receives[%d] out of range [1, %d]", len(receives), maxReceives))
+	}
+
+	// recipients are unique, one address tx & balance txn per recipient
+	seen := make(map[string]struct{}, len(receives))
+	for _, item := range receives {
+		if item == nil || !common.IsHexAddress(item.Address) {
+			return xyerrors.NewInsError(-21, fmt.Sprintf("batch transfer invalid address, data[%s]", md.Data))
+		}
+
+		item.Address = strings.ToLower(common.HexToAddress(item.Address).Hex())
+		if _, ok := seen[item.Address]; ok {
+			return xyerrors.NewInsError(-22, fmt.Sprintf("batch transfer duplicated address[%s]", item.Address))
+		}
+		seen[item.Address] = struct{}{}
+	}
+	tf.Receives = receives
+	return nil
+}
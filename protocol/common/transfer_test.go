@@ -0,0 +1,101 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package common
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol/types"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestVerifyBatchReceives(t *testing.T) {
+	rules, err := types.NewRuleSchedule([]*config.RuleActivation{
+		{Protocol: types.ASC20Protocol, Rule: types.RuleBatchTransfer, Height: 100, Value: "2"},
+	})
+	if err != nil {
+		t.Fatalf("rules err:%v", err)
+	}
+
+	const (
+		sender = "0x9999999999999999999999999999999999999999"
+		addr1  = "0x1111111111111111111111111111111111111111"
+		addr2  = "0x2222222222222222222222222222222222222222"
+		addr3  = "0x3333333333333333333333333333333333333333"
+	)
+	cache := dcache.NewManager(nil, "avalanche")
+	cache.Inscription = dcache.NewInscription()
+	cache.Balance = dcache.NewBalance()
+	cache.Inscription.Create(types.ASC20Protocol, "avav", &dcache.Tick{Decimals: 18})
+	cache.Balance.Create(types.ASC20Protocol, "avav", sender, &dcache.BalanceItem{
+		Available: decimal.NewFromInt(100),
+		Overall:   decimal.NewFromInt(100),
+	})
+	p := NewProtocol(cache, rules, nil, nil)
+
+	tests := []struct {
+		name      string
+		height    int64
+		to        string
+		receivers []string
+		valid     bool
+	}{
+		{"before activation falls back to the tx recipient", 99, `[{"addr":"` + addr1 + `","amt":"1"}]`, []string{addr3}, true},
+		{"single recipient", 100, `[{"addr":"` + addr1 + `","amt":"1"}]`, []string{addr1}, true},
+		{"two recipients", 100, `[{"addr":"` + addr1 + `","amt":"1"},{"addr":"` + addr2 + `","amt":"2"}]`, []string{addr1, addr2}, true},
+		{"plain to ignored", 100, `"` + addr1 + `"`, []string{addr3}, true},
+		{"too many recipients", 100, `[{"addr":"` + addr1 + `","amt":"1"},{"addr":"` + addr2 + `","amt":"1"},{"addr":"` + addr3 + `","amt":"1"}]`, nil, false},
+		{"empty recipients", 100, `[]`, nil, false},
+		{"invalid address", 100, `[{"addr":"0x1234","amt":"1"}]`, nil, false},
+		{"duplicated address", 100, `[{"addr":"` + addr1 + `","amt":"1"},{"addr":"0X1111111111111111111111111111111111111111","amt":"1"}]`, nil, false},
+		{"invalid json", 100, `[{"addr":1}]`, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			block := &xycommon.RpcBlock{Number: big.NewInt(tt.height)}
+			tx := &xycommon.RpcTransaction{From: sender, To: addr3}
+			md := &devents.MetaData{Protocol: types.ASC20Protocol, Tick: "avav", Data: `{"amt":"5","to":` + tt.to + `}`}
+			tf, err := p.verifyTransfer(block, tx, md)
+			if (err == nil) != tt.valid {
+				t.Fatalf("got err[%v], want valid[%v]", err, tt.valid)
+			}
+			if !tt.valid {
+				return
+			}
+
+			receivers := make([]string, 0, len(tf.Receives))
+			for _, item := range tf.Receives {
+				receivers = append(receivers, item.Address)
+			}
+			if strings.Join(receivers, ",") != strings.Join(tt.receivers, ",") {
+				t.Fatalf("got receivers[%v], want[%v]", receivers, tt.receivers)
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+// Package conformance replays json test vectors through the protocol parsers & cache handler,
+// fixtures are implementation neutral and can be shared with other indexer implementations.
+package conformance
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/model"
+	"github.com/uxuycom/indexer/protocol"
+	"github.com/uxuycom/indexer/xyerrors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// Fixture ordered synthetic blocks of a chain & the expected final state after replaying them
+type Fixture struct {
+	Name        string                   `json:"name"`
+	Description string                   `json:"description"`
+	Chain       string                   `json:"chain"`
+	ChainGroup  model.ChainGroup         `json:"chain_group"` // evm by default
+	Rules       []*config.RuleActivation `json:"rules"`
+	Blocks      []*Block                 `json:"blocks"`
+	Expected    *Expected                `json:"expected"`
+}
+
+type Block struct {
+	Number uint64 `json:"number"`
+	Time   uint64 `json:"time"`
+	Hash   string `json:"hash"`
+	Txs    []*Tx  `json:"txs"`
+}
+
+// Tx synthetic tx, Input hex calldata takes precedence over the plain text Data
+type Tx struct {
+	Hash   string   `json:"hash"` // derived from the block number & tx index when empty
+	From   string   `json:"from"`
+	To     string   `json:"to"`
+	Input  string   `json:"input"`
+	Data   string   `json:"data"`
+	Events []*Event `json:"events"`
+}
+
+type Event struct {
+	Address string   `json:"address"`
+	Topics  []string `json:"topics"`
+	Data    string   `json:"data"`
+}
+
+// Expected final state, only the listed records are checked
+type Expected struct {
+	Inscriptions []*ExpectedInscription `json:"inscriptions"`
+	Stats        []*ExpectedStats       `json:"stats"`
+	Balances     []*ExpectedBalance     `json:"balances"`
+}
+
+type ExpectedInscription struct {
+	Protocol     string          `json:"protocol"`
+	Tick         string          `json:"tick"`
+	TotalSupply  decimal.Decimal `json:"total_supply"`
+	LimitPerMint decimal.Decimal `json:"limit_per_mint"`
+	Decimals     int8            `json:"decimals"`
+}
+
+type ExpectedStats struct {
+	Protocol string          `json:"protocol"`
+	Tick     string          `json:"tick"`
+	Minted   decimal.Decimal `json:"minted"`
+	Holders  int64           `json:"holders"`
+	TxCnt    uint64          `json:"tx_cnt"`
+	Burned   decimal.Decimal `json:"burned"`
+}
+
+// ExpectedBalance zero overall balance also matches a missing balance record
+type ExpectedBalance struct {
+	Protocol  string          `json:"protocol"`
+	Tick      string          `json:"tick"`
+	Address   string          `json:"address"`
+	Overall   decimal.Decimal `json:"overall"`
+	Available decimal.Decimal `json:"available"`
+}
+
+// Load decode the fixture file
+func Load(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &Fixture{}
+	if err = json.Unmarshal(data, f); err != nil {
+		return nil, fmt.Errorf("fixture[%s] decode err:%v", path, err)
+	}
+	if f.Name == "" {
+		f.Name = filepath.Base(path)
+	}
+	if f.Chain == "" {
+		return nil, fmt.Errorf("fixture[%s] chain empty", path)
+	}
+	if f.ChainGroup == "" {
+		f.ChainGroup = model.EvmChainGroup
+	}
+	return f, nil
+}
+
+// LoadDir decode all the *.json fixtures of the dir in file name order
+func LoadDir(dir string) ([]*Fixture, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	fixtures := make([]*Fixture, 0, len(paths))
+	for _, path := range paths {
+		f, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		fixtures = append(fixtures, f)
+	}
+	return fixtures, nil
+}
+
+// Run replay the fixture blocks on an in-memory cache manager & return the mismatches of the expected state.
+// The protocol instances are package level, fixtures must not run in parallel.
+func Run(f *Fixture) ([]string, error) {
+	cfg := &config.Config{
+		Chain: config.ChainConfig{
+			ChainName:  f.Chain,
+			ChainGroup: f.ChainGroup,
+			Rules:      f.Rules,
+		},
+	}
+	cache := dcache.NewMemoryManager(f.Chain)
+	if err := protocol.InitProtocols(cfg, cache); err != nil {
+		return nil, err
+	}
+
+	handler := devents.NewTxResultHandler(cache)
+	for _, b := range f.Blocks {
+		block, err := buildBlock(b)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tx := range block.Transactions {
+			pt, md := protocol.GetProtocol(cfg, tx)
+			if pt == nil {
+				continue
+			}
+
+			txResults, insErr := pt.Parse(block, tx, md)
+			if insErr != nil && errors.Is(insErr, xyerrors.ErrInternal) {
+				return nil, fmt.Errorf("block[%d] tx[%s] parse err:%v", b.Number, tx.Hash, insErr)
+			}
+			if insErr != nil || len(txResults) < 1 {
+				continue
+			}
+
+			number := cache.InscriptionNumber.Next()
+			for _, txResult := range txResults {
+				txResult.Number = number
+				handler.UpdateCache(txResult)
+			}
+		}
+	}
+	return verify(cache, f.Expected), nil
+}
+
+func buildBlock(b *Block) (*xycommon.RpcBlock, error) {
+	block := &xycommon.RpcBlock{
+		Number:       new(big.Int).SetUint64(b.Number),
+		Time:         b.Time,
+		Hash:         b.Hash,
+		Transactions: make([]*xycommon.RpcTransaction, 0, len(b.Txs)),
+	}
+	if block.Hash == "" {
+		block.Hash = fmt.Sprintf("0x%064x", b.Number)
+	}
+
+	for idx, t := range b.Txs {
+		tx := &xycommon.RpcTransaction{
+			BlockHash:   block.Hash,
+			BlockNumber: block.Number,
+			TxIndex:     big.NewInt(int64(idx)),
+			Hash:        t.Hash,
+			From:        t.From,
+			To:          t.To,
+			Input:       t.Input,
+			Value:       big.NewInt(0),
+			Gas:         big.NewInt(0),
+			GasPrice:    big.NewInt(0),
+			Status:      1,
+		}
+		if tx.Hash == "" {
+			tx.Hash = fmt.Sprintf("0x%032x%032x", b.Number, idx)
+		}
+		if tx.Input == "" {
+			tx.Input = "0x" + hex.EncodeToString([]byte(t.Data))
+		}
+
+		for _, e := range t.Events {
+			data, err := hexutil.Decode(e.Data)
+			if err != nil {
+				return nil, fmt.Errorf("block[%d] tx[%s] event data decode err:%v", b.Number, tx.Hash, err)
+			}
+
+			topics := make([]common.Hash, 0, len(e.Topics))
+			for _, topic := range e.Topics {
+				topics = append(topics, common.HexToHash(topic))
+			}
+			tx.Events = append(tx.Events, xycommon.RpcLog{
+				Address:     common.HexToAddress(e.Address),
+				Topics:      topics,
+				Data:        data,
+				BlockNumber: (*hexutil.Big)(block.Number),
+				TxHash:      common.HexToHash(tx.Hash),
+			})
+		}
+		block.Transactions = append(block.Transactions, tx)
+	}
+	return block, nil
+}
+
+func verify(cache *dcache.Manager, expected *Expected) []string {
+	mismatches := make([]string, 0)
+	if expected == nil {
+		return mismatches
+	}
+
+	for _, e := range expected.Inscriptions {
+		ok, tick := cache.Inscription.Get(e.Protocol, e.Tick)
+		if !ok || tick == nil {
+			mismatches = append(mismatches, fmt.Sprintf("inscription[%s-%s] not exist", e.Protocol, e.Tick))
+			continue
+		}
+		if !tick.TotalSupply.Equal(e.TotalSupply) || !tick.LimitPerMint.Equal(e.LimitPerMint) || tick.Decimals != e.Decimals {
+			mismatches = append(mismatches, fmt.Sprintf("inscription[%s-%s] max[%s] lim[%s] dec[%d], expected max[%s] lim[%s] dec[%d]",
+				e.Protocol, e.Tick, tick.TotalSupply, tick.LimitPerMint, tick.Decimals, e.TotalSupply, e.LimitPerMint, e.Decimals))
+		}
+	}
+
+	for _, e := range expected.Stats {
+		ok, stats := cache.InscriptionStats.Get(e.Protocol, e.Tick)
+		if !ok || stats == nil {
+			mismatches = append(mismatches, fmt.Sprintf("stats[%s-%s] not exist", e.Protocol, e.Tick))
+			continue
+		}
+		if !stats.Minted.Equal(e.Minted) || stats.Holders != e.Holders || stats.TxCnt != e.TxCnt || !stats.Burned.Equal(e.Burned) {
+			mismatches = append(mismatches, fmt.Sprintf("stats[%s-%s] minted[%s] holders[%d] tx_cnt[%d] burned[%s], expected minted[%s] holders[%d] tx_cnt[%d] burned[%s]",
+				e.Protocol, e.Tick, stats.Minted, stats.Holders, stats.TxCnt, stats.Burned, e.Minted, e.Holders, e.TxCnt, e.Burned))
+		}
+	}
+
+	for _, e := range expected.Balances {
+		overall, available := decimal.Zero, decimal.Zero
+		if ok, balance := cache.Balance.Get(e.Protocol, e.Tick, e.Address); ok {
+			overall, available = balance.Overall, balance.Available
+		}
+		if !overall.Equal(e.Overall) || !available.Equal(e.Available) {
+			mismatches = append(mismatches, fmt.Sprintf("balance[%s-%s-%s] overall[%s] available[%s], expected overall[%s] available[%s]",
+				e.Protocol, e.Tick, e.Address, overall, available, e.Overall, e.Available))
+		}
+	}
+	return mismatches
+}
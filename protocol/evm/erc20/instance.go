@@ -24,15 +24,18 @@ package erc20
 
 import (
 	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/protocol/bridge"
 	"github.com/uxuycom/indexer/protocol/common"
+	"github.com/uxuycom/indexer/protocol/marketplace"
+	"github.com/uxuycom/indexer/protocol/types"
 )
 
 type Protocol struct {
 	*common.Protocol
 }
 
-func NewProtocol(cache *dcache.Manager) *Protocol {
+func NewProtocol(cache *dcache.Manager, rules *types.RuleSchedule, markets *marketplace.Registry, bridges *bridge.Registry) *Protocol {
 	return &Protocol{
-		Protocol: common.NewProtocol(cache),
+		Protocol: common.NewProtocol(cache, rules, markets, bridges),
 	}
 }
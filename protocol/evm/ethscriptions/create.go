@@ -0,0 +1,103 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package ethscriptions
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/utils"
+	"github.com/uxuycom/indexer/xyerrors"
+	"strings"
+)
+
+// Create an ethscription: calldata is a data uri, the id is the tx hash and the
+// recipient (tx.To) becomes the first owner.
+func (p *Protocol) Create(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) (*devents.TxResult, *xyerrors.InsError) {
+	content, err := p.verifyCreate(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	item := md.Copy()
+	item.Operate = devents.OperateCreate
+	return &devents.TxResult{
+		MD:    item,
+		Block: block,
+		Tx:    tx,
+		Ethscription: &devents.Ethscription{
+			Id:      strings.ToLower(tx.Hash),
+			From:    tx.From,
+			To:      tx.To,
+			Content: content,
+		},
+	}, nil
+}
+
+func (p *Protocol) verifyCreate(tx *xycommon.RpcTransaction) (*devents.Content, *xyerrors.InsError) {
+	if tx.To == "" {
+		return nil, xyerrors.NewInsError(-12, "ethscription recipient empty")
+	}
+
+	bytes, err := hex.DecodeString(tx.Input[2:])
+	if err != nil {
+		return nil, xyerrors.NewInsError(-13, fmt.Sprintf("input hex data decode err:%v", err))
+	}
+
+	if !strings.Contains(string(bytes), ",") {
+		return nil, xyerrors.NewInsError(-13, "data uri separator not found")
+	}
+
+	// one creation per tx hash, smart account inner calls share the hash of their tx
+	if ok, _ := p.cache.Ethscription.Get(tx.Hash); ok {
+		return nil, xyerrors.NewInsError(-14, fmt.Sprintf("ethscription[%s] exists", tx.Hash))
+	}
+
+	// content must be unique unless the creation opts out with the esip6 rule
+	content := ParseContent(string(bytes))
+	if p.cache.Ethscription.ContentExists(content.UriSha256) {
+		if d, err := utils.ParseDataURI(string(bytes)); err != nil || d.Rule() != "esip6" {
+			return nil, xyerrors.NewInsError(-15, fmt.Sprintf("ethscription content[%s] exists", content.UriSha256))
+		}
+	}
+	return content, nil
+}
+
+// ParseContent decodes the data uri payload, a malformed uri keeps the raw data after the separator
+func ParseContent(uri string) *devents.Content {
+	content := &devents.Content{}
+	if d, err := utils.ParseDataURI(uri); err == nil {
+		content.ContentType = d.MediaType
+		content.Data = d.Data
+	} else {
+		content.Data = []byte(uri[strings.Index(uri, ",")+1:])
+	}
+
+	sum := sha256.Sum256(content.Data)
+	content.Sha256 = hex.EncodeToString(sum[:])
+	sum = sha256.Sum256([]byte(uri))
+	content.UriSha256 = hex.EncodeToString(sum[:])
+	return content
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package ethscriptions
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
+	"math/big"
+	"testing"
+)
+
+func TestCreateContent(t *testing.T) {
+	p := newTestProtocol()
+	tests := []struct {
+		name        string
+		uri         string
+		contentType string
+		data        string
+		sha256      string
+	}{
+		{"plain text", "data:,hello", "", "hello", "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+		{"base64 text", "data:text/plain;charset=utf-8;base64,aGVsbG8=", "text/plain", "hello", "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+		{"html", "data:text/html,%3Ch1%3Ehi%3C%2Fh1%3E", "text/html", "<h1>hi</h1>", ""},
+		{"malformed keeps raw data", "data:bad type,abc", "", "abc", ""},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &xycommon.RpcTransaction{
+				Hash:        "0x" + hex.EncodeToString([]byte{byte(i + 1)}),
+				From:        testUser.String(),
+				To:          testBuyer.String(),
+				BlockNumber: big.NewInt(1),
+				Input:       "0x" + hex.EncodeToString([]byte(tt.uri)),
+			}
+			item, err := p.Create(&xycommon.RpcBlock{Number: big.NewInt(1)}, tx, &devents.MetaData{})
+			assert.Nil(t, err)
+			assert.Equal(t, tt.contentType, item.Ethscription.Content.ContentType)
+			assert.Equal(t, tt.data, string(item.Ethscription.Content.Data))
+			if tt.sha256 != "" {
+				assert.Equal(t, tt.sha256, item.Ethscription.Content.Sha256)
+			}
+		})
+	}
+}
+
+func TestCreateUniqueContent(t *testing.T) {
+	p := newTestProtocol()
+	for i, uri := range []string{"data:,hello", "data:;rule=esip6,hello"} {
+		p.cache.Ethscription.Create(fmt.Sprintf("0x%02x", i+1), &dcache.EthscriptionItem{
+			Creator:   testUser.String(),
+			Owner:     testBuyer.String(),
+			UriSha256: ParseContent(uri).UriSha256,
+		})
+	}
+
+	tests := []struct {
+		name string
+		uri  string
+		ok   bool
+	}{
+		{"duplicate uri", "data:,hello", false},
+		{"same data other uri", "data:text/plain,hello", true},
+		{"esip6 duplicate", "data:;rule=esip6,hello", true},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := &xycommon.RpcTransaction{
+				Hash:        "0x" + hex.EncodeToString([]byte{byte(i + 16)}),
+				From:        testUser.String(),
+				To:          testBuyer.String(),
+				BlockNumber: big.NewInt(1),
+				Input:       "0x" + hex.EncodeToString([]byte(tt.uri)),
+			}
+			item, err := p.Create(&xycommon.RpcBlock{Number: big.NewInt(1)}, tx, &devents.MetaData{})
+			if tt.ok {
+				assert.Nil(t, err)
+				assert.NotNil(t, item)
+			} else {
+				assert.NotNil(t, err)
+				assert.Nil(t, item)
+			}
+		})
+	}
+}
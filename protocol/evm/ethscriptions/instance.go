@@ -0,0 +1,119 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package ethscriptions
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol/common"
+	"github.com/uxuycom/indexer/protocol/types"
+	"github.com/uxuycom/indexer/xyerrors"
+	"github.com/uxuycom/indexer/xylog"
+	"strings"
+)
+
+type Protocol struct {
+	cache *dcache.Manager
+}
+
+var ParsedABI abi.ABI
+
+func NewProtocol(cache *dcache.Manager) *Protocol {
+	return &Protocol{
+		cache: cache,
+	}
+}
+
+// Parse handles the calldata operate (create / transfer) first and then the
+// contract events (ESIP-1 / ESIP-2), in the same order the protocol applies them.
+func (p *Protocol) Parse(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData) ([]*devents.TxResult, *xyerrors.InsError) {
+	state := newOwners(p.cache)
+	items := make([]*devents.TxResult, 0, 1)
+	switch {
+	case strings.HasPrefix(tx.Input, common.DataPrefix):
+		item, err := p.Create(block, tx, md)
+		if err != nil {
+			xylog.Logger.Infof("ethscription create verified failed, err:%v, tx:%s", err, tx.Hash)
+			break
+		}
+		state.set(item.Ethscription.Id, &dcache.EthscriptionItem{
+			Creator: item.Ethscription.From,
+			Owner:   item.Ethscription.To,
+		})
+		items = append(items, item)
+
+	case IsTransferInput(tx.Input):
+		items = append(items, p.Transfer(block, tx, md, state)...)
+	}
+
+	items = append(items, p.TransferByEvents(block, tx, md, state)...)
+	return items, nil
+}
+
+// ParseMetaDataByEventLogs matches the ESIP-1 / ESIP-2 transfer events
+func ParseMetaDataByEventLogs(chain string, tx *xycommon.RpcTransaction) (*devents.MetaData, error) {
+	for _, event := range tx.Events {
+		if len(event.Topics) < 1 {
+			continue
+		}
+
+		topic := event.Topics[0].String()
+		if topic == EventTopicHashTransfer || topic == EventTopicHashTransferForPreviousOwner {
+			return &devents.MetaData{
+				Chain:    chain,
+				Protocol: types.EthscriptionsProtocol,
+				Operate:  devents.OperateTransfer,
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// ParseMetaDataByInput matches ethscription creations (data uri) & calldata transfers (ESIP-5)
+func ParseMetaDataByInput(chain string, tx *xycommon.RpcTransaction) (*devents.MetaData, error) {
+	operate := ""
+	switch {
+	case strings.HasPrefix(tx.Input, common.DataPrefix):
+		operate = devents.OperateCreate
+	case IsTransferInput(tx.Input):
+		operate = devents.OperateTransfer
+	default:
+		return nil, nil
+	}
+
+	return &devents.MetaData{
+		Chain:    chain,
+		Protocol: types.EthscriptionsProtocol,
+		Operate:  operate,
+	}, nil
+}
+
+func init() {
+	var err error
+	ParsedABI, err = abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		xylog.Logger.Fatalf("ethscriptions abi decode err:%v", err)
+	}
+}
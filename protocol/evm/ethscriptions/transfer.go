@@ -0,0 +1,188 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package ethscriptions
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/utils"
+	"github.com/uxuycom/indexer/xyerrors"
+	"github.com/uxuycom/indexer/xylog"
+	"regexp"
+	"strings"
+)
+
+const (
+	// EventTopicHashTransfer ESIP-1: ethscriptions_protocol_TransferEthscription(index_topic_1 address recipient, index_topic_2 bytes32 ethscriptionId)
+	EventTopicHashTransfer = "0xf30861289185032f511ff94a8127e470f3d0e6230be4925cb6fad33f3436dffb"
+
+	// EventTopicHashTransferForPreviousOwner ESIP-2: ethscriptions_protocol_TransferEthscriptionForPreviousOwner(index_topic_1 address previousOwner, index_topic_2 address recipient, index_topic_3 bytes32 ethscriptionId)
+	EventTopicHashTransferForPreviousOwner = "0xf1d95ed4d1680e6f665104f19c296ae52c1f64cd8114e84d55dc6349dbdafea3"
+)
+
+var transferInputRegexp = regexp.MustCompile(`^0x([0-9a-fA-F]{64})+$`)
+
+// IsTransferInput checks calldata made of one or more (ESIP-5) ethscription ids
+func IsTransferInput(input string) bool {
+	return transferInputRegexp.MatchString(input)
+}
+
+// TransferEthscriptionEvent covers both ESIP-1 & ESIP-2 event arguments
+type TransferEthscriptionEvent struct {
+	PreviousOwner  common.Address `json:"previousOwner"`
+	Recipient      common.Address `json:"recipient"`
+	EthscriptionId common.Hash    `json:"ethscriptionId"`
+}
+
+// owners records ownership changes made earlier in the same tx on top of the cache
+type owners struct {
+	cache *dcache.Manager
+	items map[string]*dcache.EthscriptionItem
+}
+
+func newOwners(cache *dcache.Manager) *owners {
+	return &owners{
+		cache: cache,
+		items: make(map[string]*dcache.EthscriptionItem),
+	}
+}
+
+func (o *owners) get(id string) (bool, *dcache.EthscriptionItem) {
+	id = strings.ToLower(id)
+	if item, ok := o.items[id]; ok {
+		return true, item
+	}
+	return o.cache.Ethscription.Get(id)
+}
+
+func (o *owners) set(id string, item *dcache.EthscriptionItem) {
+	o.items[strings.ToLower(id)] = item
+}
+
+func (o *owners) transfer(id, from, to string) *xyerrors.InsError {
+	ok, item := o.get(id)
+	if !ok {
+		return xyerrors.NewInsError(-15, fmt.Sprintf("ethscription[%s] not found", id))
+	}
+
+	if !strings.EqualFold(item.Owner, from) {
+		return xyerrors.NewInsError(-16, fmt.Sprintf("ethscription[%s] owner[%s] mismatch sender[%s]", id, item.Owner, from))
+	}
+
+	o.set(id, &dcache.EthscriptionItem{
+		Creator:       item.Creator,
+		Owner:         to,
+		PreviousOwner: item.Owner,
+	})
+	return nil
+}
+
+// Transfer ethscriptions by calldata, the sender must be the current owner
+func (p *Protocol) Transfer(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData, state *owners) []*devents.TxResult {
+	data := tx.Input[2:]
+	items := make([]*devents.TxResult, 0, len(data)/64)
+	for i := 0; i+64 <= len(data); i += 64 {
+		id := "0x" + strings.ToLower(data[i:i+64])
+		if err := state.transfer(id, tx.From, tx.To); err != nil {
+			xylog.Logger.Infof("ethscription transfer verified failed, err:%v, tx:%s", err, tx.Hash)
+			continue
+		}
+		items = append(items, p.buildTransfer(block, tx, md, id, tx.From, tx.To))
+	}
+	return items
+}
+
+// TransferByEvents applies the ESIP-1 / ESIP-2 events emitted by contracts. The
+// emitting contract must own the ethscription, and for ESIP-2 the previous owner
+// must match the event argument.
+func (p *Protocol) TransferByEvents(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, md *devents.MetaData, state *owners) []*devents.TxResult {
+	items := make([]*devents.TxResult, 0, len(tx.Events))
+	for _, event := range tx.Events {
+		if len(event.Topics) < 1 {
+			continue
+		}
+
+		topic := event.Topics[0].String()
+		if topic != EventTopicHashTransfer && topic != EventTopicHashTransferForPreviousOwner {
+			continue
+		}
+
+		e := &TransferEthscriptionEvent{}
+		_, err := utils.ParseEventToStruct(ParsedABI, utils.EventLog{
+			Address: event.Address,
+			Topics:  event.Topics,
+			Data:    event.Data,
+		}, e)
+		if err != nil {
+			xylog.Logger.Infof("ethscription event parse error[%v], tx:%s", err, tx.Hash)
+			continue
+		}
+
+		id := strings.ToLower(e.EthscriptionId.String())
+		sender := event.Address.String()
+		if topic == EventTopicHashTransferForPreviousOwner {
+			if err1 := p.verifyPreviousOwner(state, id, sender, e.PreviousOwner.String()); err1 != nil {
+				xylog.Logger.Infof("ethscription transfer verified failed, err:%v, tx:%s", err1, tx.Hash)
+				continue
+			}
+		}
+
+		if err1 := state.transfer(id, sender, e.Recipient.String()); err1 != nil {
+			xylog.Logger.Infof("ethscription transfer verified failed, err:%v, tx:%s", err1, tx.Hash)
+			continue
+		}
+		items = append(items, p.buildTransfer(block, tx, md, id, sender, e.Recipient.String()))
+	}
+	return items
+}
+
+func (p *Protocol) verifyPreviousOwner(state *owners, id, sender, previousOwner string) *xyerrors.InsError {
+	ok, item := state.get(id)
+	if !ok {
+		return xyerrors.NewInsError(-15, fmt.Sprintf("ethscription[%s] not found", id))
+	}
+
+	// the contract holds the ethscription, so its previous owner is whoever deposited it
+	if strings.EqualFold(item.Owner, sender) && !strings.EqualFold(item.PreviousOwner, previousOwner) {
+		return xyerrors.NewInsError(-17, fmt.Sprintf("ethscription[%s] previous owner[%s] mismatch[%s]", id, item.PreviousOwner, previousOwner))
+	}
+	return nil
+}
+
+func (p *Protocol) buildTransfer(block *xycommon.RpcBlock, tx *xycommon.RpcTransaction, omd *devents.MetaData, id, from, to string) *devents.TxResult {
+	md := omd.Copy()
+	md.Operate = devents.OperateTransfer
+	return &devents.TxResult{
+		MD:    md,
+		Block: block,
+		Tx:    tx,
+		Ethscription: &devents.Ethscription{
+			Id:   id,
+			From: from,
+			To:   to,
+		},
+	}
+}
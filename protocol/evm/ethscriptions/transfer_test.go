@@ -0,0 +1,129 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package ethscriptions
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/dcache"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/xylog"
+	"math/big"
+	"testing"
+)
+
+func init() {
+	xylog.InitLog(logrus.DebugLevel, "")
+}
+
+var (
+	testId       = common.HexToHash("0x6c0f3c1ab9f1a2b0ae2d2e8a3b5e4b5f1d1f5ff8c2d6a7e2f1f0e9d8c7b6a590")
+	testUser     = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	testMarket   = common.HexToAddress("0x2222222222222222222222222222222222222222")
+	testBuyer    = common.HexToAddress("0x3333333333333333333333333333333333333333")
+	testStranger = common.HexToAddress("0x4444444444444444444444444444444444444444")
+)
+
+func newTestProtocol() *Protocol {
+	cache := dcache.NewManager(nil, "eth")
+	cache.Ethscription = dcache.NewEthscription()
+
+	// user deposited the ethscription into the market contract
+	cache.Ethscription.Create(testId.String(), &dcache.EthscriptionItem{
+		Creator:       testUser.String(),
+		Owner:         testMarket.String(),
+		PreviousOwner: testUser.String(),
+	})
+	return NewProtocol(cache)
+}
+
+func transferTx(events ...xycommon.RpcLog) *xycommon.RpcTransaction {
+	return &xycommon.RpcTransaction{
+		Hash:        "0xabc",
+		BlockNumber: big.NewInt(1),
+		Input:       "0x",
+		Events:      events,
+	}
+}
+
+func esip1Log(contract, recipient common.Address) xycommon.RpcLog {
+	return xycommon.RpcLog{
+		Address: contract,
+		Topics: []common.Hash{
+			common.HexToHash(EventTopicHashTransfer),
+			common.BytesToHash(recipient.Bytes()),
+			testId,
+		},
+	}
+}
+
+func esip2Log(contract, previousOwner, recipient common.Address) xycommon.RpcLog {
+	return xycommon.RpcLog{
+		Address: contract,
+		Topics: []common.Hash{
+			common.HexToHash(EventTopicHashTransferForPreviousOwner),
+			common.BytesToHash(previousOwner.Bytes()),
+			common.BytesToHash(recipient.Bytes()),
+			testId,
+		},
+	}
+}
+
+func TestTransferByEvents(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []xycommon.RpcLog
+		want   int
+	}{
+		{"esip-1 from owner contract", []xycommon.RpcLog{esip1Log(testMarket, testBuyer)}, 1},
+		{"esip-1 from non-owner contract", []xycommon.RpcLog{esip1Log(testStranger, testBuyer)}, 0},
+		{"esip-2 previous owner matched", []xycommon.RpcLog{esip2Log(testMarket, testUser, testBuyer)}, 1},
+		{"esip-2 previous owner mismatched", []xycommon.RpcLog{esip2Log(testMarket, testStranger, testBuyer)}, 0},
+		{"contract no longer owns after first transfer", []xycommon.RpcLog{esip1Log(testMarket, testBuyer), esip1Log(testMarket, testStranger)}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProtocol()
+			md, _ := ParseMetaDataByEventLogs("eth", transferTx(tt.events...))
+			assert.NotNil(t, md)
+
+			items, err := p.Parse(&xycommon.RpcBlock{Number: big.NewInt(1)}, transferTx(tt.events...), md)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, len(items))
+			for _, item := range items {
+				assert.Equal(t, devents.OperateTransfer, item.MD.Operate)
+				assert.Equal(t, testBuyer.String(), item.Ethscription.To)
+			}
+		})
+	}
+}
+
+func TestIsTransferInput(t *testing.T) {
+	assert.True(t, IsTransferInput(testId.String()))
+	assert.True(t, IsTransferInput(testId.String()+testId.String()[2:]))
+	assert.False(t, IsTransferInput("0x"))
+	assert.False(t, IsTransferInput("0xa9059cbb"+testId.String()[2:]))
+}
@@ -0,0 +1,265 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package marketplace
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol/types"
+	"github.com/uxuycom/indexer/utils"
+	"github.com/uxuycom/indexer/xylog"
+	"math/big"
+	"os"
+	"strings"
+)
+
+// Transfer token transfer decoded from a marketplace event
+type Transfer struct {
+	Marketplace string
+	Protocol    string // protocol of the marketplace adapter
+	Index       int    // log position of the event in the tx
+	Tick        string // tick name, or the tick hash when TickIdx is set
+	TickIdx     bool
+	From        string
+	To          string
+	Amount      decimal.Decimal
+}
+
+// Adapter maps the event of a marketplace contract to token transfers
+type Adapter struct {
+	name     string
+	protocol string
+	contract common.Address
+	abi      abi.ABI
+	event    abi.Event
+	fields   config.MarketplaceFields
+	decimals int32
+}
+
+func NewAdapter(cfg *config.MarketplaceConfig) (*Adapter, error) {
+	if !common.IsHexAddress(cfg.Contract) {
+		return nil, fmt.Errorf("marketplace[%s] invalid contract[%s]", cfg.Name, cfg.Contract)
+	}
+
+	file, err := os.Open(cfg.AbiFile)
+	if err != nil {
+		return nil, fmt.Errorf("marketplace[%s] abi file open err:%v", cfg.Name, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	parsedABI, err := abi.JSON(file)
+	if err != nil {
+		return nil, fmt.Errorf("marketplace[%s] abi decode err:%v", cfg.Name, err)
+	}
+	return newAdapter(cfg, parsedABI)
+}
+
+func newAdapter(cfg *config.MarketplaceConfig, parsedABI abi.ABI) (*Adapter, error) {
+	event, ok := parsedABI.Events[cfg.Event]
+	if !ok {
+		return nil, fmt.Errorf("marketplace[%s] event[%s] not found in abi", cfg.Name, cfg.Event)
+	}
+
+	fields := map[string]string{
+		"tick":   cfg.Fields.Tick,
+		"from":   cfg.Fields.From,
+		"to":     cfg.Fields.To,
+		"amount": cfg.Fields.Amount,
+	}
+	for key, name := range fields {
+		found := false
+		for _, input := range event.Inputs {
+			if input.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("marketplace[%s] %s field[%s] not found in event[%s]", cfg.Name, key, name, cfg.Event)
+		}
+	}
+
+	protocol := strings.ToLower(strings.TrimSpace(cfg.Protocol))
+	if protocol == "" {
+		protocol = types.ASC20Protocol
+	}
+	return &Adapter{
+		name:     cfg.Name,
+		protocol: protocol,
+		contract: common.HexToAddress(cfg.Contract),
+		abi:      parsedABI,
+		event:    event,
+		fields:   cfg.Fields,
+		decimals: cfg.Decimals,
+	}, nil
+}
+
+func (a *Adapter) Protocol() string {
+	return a.protocol
+}
+
+// Topic the event signature hash
+func (a *Adapter) Topic() common.Hash {
+	return a.event.ID
+}
+
+func (a *Adapter) Match(log *xycommon.RpcLog) bool {
+	return len(log.Topics) > 0 && log.Topics[0] == a.event.ID && log.Address == a.contract
+}
+
+// Decode the matched log into a transfer
+func (a *Adapter) Decode(log *xycommon.RpcLog) (*Transfer, error) {
+	values := make(map[string]interface{}, len(a.event.Inputs))
+	_, err := utils.ParseEventToMap(a.abi, utils.EventLog{
+		Address: log.Address,
+		Topics:  log.Topics,
+		Data:    log.Data,
+	}, values)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Transfer{
+		Marketplace: a.name,
+	}
+	switch v := values[a.fields.Tick].(type) {
+	case string:
+		t.Tick = strings.ToLower(strings.TrimSpace(v))
+	case common.Hash:
+		t.Tick, t.TickIdx = v.String(), true
+	case [32]byte:
+		t.Tick, t.TickIdx = common.Hash(v).String(), true
+	default:
+		return nil, fmt.Errorf("tick field[%s] type %T unsupported", a.fields.Tick, v)
+	}
+
+	if t.From, err = addressValue(values[a.fields.From]); err != nil {
+		return nil, fmt.Errorf("from field[%s] err:%v", a.fields.From, err)
+	}
+	if t.To, err = addressValue(values[a.fields.To]); err != nil {
+		return nil, fmt.Errorf("to field[%s] err:%v", a.fields.To, err)
+	}
+
+	amount, ok := values[a.fields.Amount].(*big.Int)
+	if !ok || amount == nil {
+		return nil, fmt.Errorf("amount field[%s] type %T unsupported", a.fields.Amount, values[a.fields.Amount])
+	}
+	t.Amount = decimal.NewFromBigInt(amount, -a.decimals)
+	return t, nil
+}
+
+func addressValue(v interface{}) (string, error) {
+	switch addr := v.(type) {
+	case common.Address:
+		return addr.String(), nil
+	case string:
+		if common.IsHexAddress(addr) {
+			return addr, nil
+		}
+	}
+	return "", fmt.Errorf("type %T value[%v] is not an address", v, v)
+}
+
+// Registry configured marketplace adapters of the chain
+type Registry struct {
+	adapters []*Adapter
+}
+
+func NewRegistry(items []*config.MarketplaceConfig) (*Registry, error) {
+	r := &Registry{
+		adapters: make([]*Adapter, 0, len(items)),
+	}
+	for _, item := range items {
+		adapter, err := NewAdapter(item)
+		if err != nil {
+			return nil, err
+		}
+		r.adapters = append(r.adapters, adapter)
+	}
+	return r, nil
+}
+
+// Topics the event topics of all marketplaces, used to filter the chain logs
+func (r *Registry) Topics() []common.Hash {
+	if r == nil {
+		return nil
+	}
+
+	topics := make([]common.Hash, 0, len(r.adapters))
+	for _, adapter := range r.adapters {
+		topics = append(topics, adapter.Topic())
+	}
+	return topics
+}
+
+// ParseMetaData exchange metadata of the first marketplace event in the tx, Transfers decodes the events of all marketplaces
+func (r *Registry) ParseMetaData(chain string, tx *xycommon.RpcTransaction) *devents.MetaData {
+	if r == nil {
+		return nil
+	}
+
+	for i := range tx.Events {
+		for _, adapter := range r.adapters {
+			if adapter.Match(&tx.Events[i]) {
+				return &devents.MetaData{
+					Chain:    chain,
+					Protocol: adapter.protocol,
+					Operate:  devents.OperateExchange,
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Transfers decode the events of every marketplace in the tx, in log order
+func (r *Registry) Transfers(tx *xycommon.RpcTransaction) []*Transfer {
+	if r == nil {
+		return nil
+	}
+
+	items := make([]*Transfer, 0, len(tx.Events))
+	for i := range tx.Events {
+		for _, adapter := range r.adapters {
+			if !adapter.Match(&tx.Events[i]) {
+				continue
+			}
+
+			item, err := adapter.Decode(&tx.Events[i])
+			if err != nil {
+				xylog.Logger.Infof("tx[%s] - marketplace[%s] event decode err:%v", tx.Hash, adapter.name, err)
+				continue
+			}
+			item.Protocol, item.Index = adapter.protocol, i
+			items = append(items, item)
+		}
+	}
+	return items
+}
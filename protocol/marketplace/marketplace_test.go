@@ -0,0 +1,136 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package marketplace
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/devents"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+const testABI = `[
+  {"anonymous": false, "name": "Sold", "type": "event", "inputs": [
+    {"indexed": true, "name": "seller", "type": "address"},
+    {"indexed": true, "name": "buyer", "type": "address"},
+    {"indexed": false, "name": "ticker", "type": "string"},
+    {"indexed": false, "name": "qty", "type": "uint256"}
+  ]},
+  {"anonymous": false, "name": "Moved", "type": "event", "inputs": [
+    {"indexed": true, "name": "from", "type": "address"},
+    {"indexed": true, "name": "to", "type": "address"},
+    {"indexed": true, "name": "tick", "type": "string"},
+    {"indexed": false, "name": "amount", "type": "uint256"}
+  ]}
+]`
+
+func TestAdapterDecode(t *testing.T) {
+	parsedABI, err := abi.JSON(strings.NewReader(testABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	contract := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	seller := common.HexToAddress("0x2000000000000000000000000000000000000002")
+	buyer := common.HexToAddress("0x3000000000000000000000000000000000000003")
+
+	sold, err := newAdapter(&config.MarketplaceConfig{
+		Name:     "sold",
+		Contract: contract.String(),
+		Event:    "Sold",
+		Fields:   config.MarketplaceFields{Tick: "ticker", From: "seller", To: "buyer", Amount: "qty"},
+		Decimals: 2,
+	}, parsedABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	moved, err := newAdapter(&config.MarketplaceConfig{
+		Name:     "moved",
+		Protocol: "PRC-20",
+		Contract: contract.String(),
+		Event:    "Moved",
+		Fields:   config.MarketplaceFields{Tick: "tick", From: "from", To: "to", Amount: "amount"},
+	}, parsedABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := parsedABI.Events["Sold"].Inputs.NonIndexed().Pack(" AVAV ", big.NewInt(12345))
+	amount, _ := parsedABI.Events["Moved"].Inputs.NonIndexed().Pack(big.NewInt(7))
+	tickHash := crypto.Keccak256Hash([]byte("avav"))
+	tx := &xycommon.RpcTransaction{
+		Hash: "0x01",
+		Events: []xycommon.RpcLog{
+			{Address: contract, Topics: []common.Hash{sold.Topic(), common.BytesToHash(seller.Bytes()), common.BytesToHash(buyer.Bytes())}, Data: data},
+			{Address: seller, Topics: []common.Hash{sold.Topic(), common.BytesToHash(seller.Bytes()), common.BytesToHash(buyer.Bytes())}, Data: data},
+			{Address: contract, Topics: []common.Hash{moved.Topic(), common.BytesToHash(seller.Bytes()), common.BytesToHash(buyer.Bytes()), tickHash}, Data: amount},
+		},
+	}
+
+	r := &Registry{adapters: []*Adapter{sold, moved}}
+	md := r.ParseMetaData("avalanche", tx)
+	if md == nil || md.Protocol != "asc-20" || md.Operate != devents.OperateExchange {
+		t.Fatalf("unexpected metadata %+v", md)
+	}
+
+	transfers := r.Transfers(tx)
+	if len(transfers) != 2 {
+		t.Fatalf("transfers[%d] != 2, events of other contracts must be ignored", len(transfers))
+	}
+
+	if tf := transfers[0]; tf.Protocol != "asc-20" || tf.Index != 0 || tf.Tick != "avav" || tf.TickIdx ||
+		tf.From != seller.String() || tf.To != buyer.String() || tf.Amount.String() != "123.45" {
+		t.Fatalf("unexpected transfer %+v", tf)
+	}
+	if tf := transfers[1]; tf.Protocol != "prc-20" || tf.Index != 2 || tf.Tick != tickHash.String() || !tf.TickIdx || tf.Amount.String() != "7" {
+		t.Fatalf("unexpected transfer %+v", tf)
+	}
+
+	var empty *Registry
+	if empty.ParseMetaData("avalanche", tx) != nil || len(empty.Topics()) != 0 {
+		t.Fatalf("nil registry must match nothing")
+	}
+}
+
+func TestNewAdapterInvalid(t *testing.T) {
+	parsedABI, _ := abi.JSON(strings.NewReader(testABI))
+	_, err := newAdapter(&config.MarketplaceConfig{
+		Name:     "sold",
+		Contract: "0x1000000000000000000000000000000000000001",
+		Event:    "Sold",
+		Fields:   config.MarketplaceFields{Tick: "tick", From: "seller", To: "buyer", Amount: "qty"},
+	}, parsedABI)
+	if err == nil {
+		t.Fatalf("missing event field must be rejected")
+	}
+
+	if _, err = NewAdapter(&config.MarketplaceConfig{Name: "sold", Contract: "0x01"}); err == nil {
+		t.Fatalf("invalid contract must be rejected")
+	}
+}
@@ -27,13 +27,20 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
 	"github.com/uxuycom/indexer/devents"
 	"github.com/uxuycom/indexer/model"
 	"github.com/uxuycom/indexer/protocol/avax/asc20"
-	"github.com/uxuycom/indexer/protocol/types"
+	btcBrc20 "github.com/uxuycom/indexer/protocol/btc/brc20"
+	"github.com/uxuycom/indexer/protocol/evm/ethscriptions"
+	"github.com/uxuycom/indexer/utils"
+	"math"
 	"strings"
 )
 
+// MaxGzipDataLength maximum decompressed calldata size
+const MaxGzipDataLength = 64 * 1024
+
 var EVMValidContentTypes = map[string]struct{}{
 	"":                 {},
 	"text/plain":       {},
@@ -41,19 +48,53 @@ var EVMValidContentTypes = map[string]struct{}{
 }
 
 func ParseMetaData(chainName string, tx *xycommon.RpcTransaction) (*devents.MetaData, error) {
-	switch chainName {
-	case model.ChainBTC:
+	profile := config.ChainProfileOf(chainName)
+	if profile.ChainGroup == model.BtcChainGroup {
 		return ParseBTCMetaData(chainName, tx)
 	}
 
-	// MethodID: 0xd9b3d6d0
-	if chainName == model.ChainAVAX && len(tx.Events) > 0 {
-		return asc20.ParseMetaDataByEventLogs(chainName, tx)
+	// ethscriptions ESIP-1 / ESIP-2 contract transfer events
+	if md, _ := ethscriptions.ParseMetaDataByEventLogs(chainName, tx); md != nil {
+		return md, nil
+	}
+
+	// MethodID: 0xd9b3d6d0, the asc-20 exchange parses the configured contract events of the tx as well
+	if profile.ExchangeEvents {
+		if md, _ := asc20.ParseMetaDataByEventLogs(chainName, tx); md != nil {
+			return md, nil
+		}
+	}
+
+	// configured marketplace & bridge contract events, every event of the tx is parsed whichever matches first
+	if md := markets.ParseMetaData(chainName, tx); md != nil {
+		return md, nil
+	}
+	if md := bridges.ParseMetaData(chainName, tx); md != nil {
+		return md, nil
+	}
+
+	if profile.ExchangeEvents && len(tx.Events) > 0 {
+		return nil, nil
+	}
+
+	height := uint64(math.MaxUint64)
+	if tx.BlockNumber != nil {
+		height = tx.BlockNumber.Uint64()
+	}
+	md, err := ParseEVMMetaData(chainName, tx.Input, height)
+	if md != nil {
+		return md, nil
 	}
-	return ParseEVMMetaData(chainName, tx.Input)
+
+	// non-token data uri & calldata transfers fall back to ethscriptions
+	if emd, _ := ethscriptions.ParseMetaDataByInput(chainName, tx); emd != nil {
+		return emd, nil
+	}
+	return nil, err
 }
 
-func ParseEVMMetaData(chain string, inputData string) (*devents.MetaData, error) {
+// ParseEVMMetaData height selects the data length rule, max uint64 for the latest rules
+func ParseEVMMetaData(chain string, inputData string, height uint64) (*devents.MetaData, error) {
 	// 0x prefix checking
 	if !strings.HasPrefix(inputData, "0x") {
 		return nil, fmt.Errorf("input 0x prefix checking failed")
@@ -64,6 +105,16 @@ func ParseEVMMetaData(chain string, inputData string) (*devents.MetaData, error)
 		return nil, fmt.Errorf("input hex data decode err:%v", err)
 	}
 
+	// ESIP-7 gzip compressed calldata
+	extended := false
+	if utils.IsGzip(bytes) {
+		bytes, err = utils.Gunzip(bytes, MaxGzipDataLength)
+		if err != nil {
+			return nil, fmt.Errorf("input gzip data err:%v", err)
+		}
+		extended = true
+	}
+
 	// try json format data
 	input := string(bytes)
 	dataPrefixIdx := strings.Index(input, ",")
@@ -77,11 +128,22 @@ func ParseEVMMetaData(chain string, inputData string) (*devents.MetaData, error)
 		contentType = input[5:dataPrefixIdx]
 	}
 	contentType = strings.ToLower(contentType)
-	if _, ok := EVMValidContentTypes[contentType]; !ok {
-		return nil, fmt.Errorf("tx content-type invalid & filtered, ct:%s", contentType)
-	}
 
 	data := input[dataPrefixIdx+1:]
+	if _, ok := EVMValidContentTypes[contentType]; !ok || extended {
+		// rfc 2397 media type params, base64 payload, e.g. data:application/json;rule=esip6;base64,
+		uri, err := utils.ParseDataURI(input)
+		if err != nil {
+			return nil, fmt.Errorf("tx content-type invalid & filtered, ct:%s, err:%v", contentType, err)
+		}
+		if _, ok := EVMValidContentTypes[uri.MediaType]; !ok {
+			return nil, fmt.Errorf("tx content-type invalid & filtered, ct:%s", uri.MediaType)
+		}
+		data = string(uri.Data)
+		contentType = uri.MediaType
+		extended = true
+	}
+
 	proto := &devents.MetaData{}
 	if err := json.Unmarshal([]byte(data), proto); err != nil {
 		return nil, fmt.Errorf("tx input data parsed failed, data[%s], err[%v]", data, err)
@@ -90,14 +152,17 @@ func ParseEVMMetaData(chain string, inputData string) (*devents.MetaData, error)
 	// trim prefix / suffix spaces & case insensitive
 	proto.Protocol = strings.ToLower(strings.TrimSpace(proto.Protocol))
 
-	maxDataLength := types.DefaultMaxDataLength
-	if value, ok := types.DefaultMaxDataLengthMap[proto.Protocol]; ok {
-		maxDataLength = value
-	}
-
-	// max length limit
-	if len(input) > maxDataLength {
-		return nil, fmt.Errorf("data character size[%d] > 256", len(input))
+	// max length limit, extended data uris limit the decoded payload
+	rs := rules.At(proto.Protocol, height)
+	if extended {
+		if !rs.ExtendedDataURI {
+			return nil, fmt.Errorf("extended data uri not enabled, protocol[%s]", proto.Protocol)
+		}
+		if len(data) > rs.MaxDataLength {
+			return nil, fmt.Errorf("decoded data size[%d] > %d", len(data), rs.MaxDataLength)
+		}
+	} else if len(input) > rs.MaxDataLength {
+		return nil, fmt.Errorf("data character size[%d] > %d", len(input), rs.MaxDataLength)
 	}
 
 	proto.Operate = strings.ToLower(strings.TrimSpace(proto.Operate))
@@ -109,9 +174,47 @@ func ParseEVMMetaData(chain string, inputData string) (*devents.MetaData, error)
 	}
 	proto.Chain = chain
 	proto.Data = data
+	proto.Content = devents.NewContent(contentType, []byte(data))
 	return proto, nil
 }
 
+var BTCValidContentTypes = map[string]struct{}{
+	"text/plain":       {},
+	"application/json": {},
+}
+
+// ParseBTCMetaData extracts the inscription envelope from the first input witness
 func ParseBTCMetaData(chain string, tx *xycommon.RpcTransaction) (*devents.MetaData, error) {
-	return nil, nil
+	if len(tx.Vin) <= 0 {
+		return nil, fmt.Errorf("tx vin empty")
+	}
+
+	envelope := btcBrc20.ParseEnvelope(tx.Vin[0].Witness)
+	if envelope == nil {
+		return nil, fmt.Errorf("inscription envelope not found")
+	}
+
+	// ignore content-type params, e.g. text/plain;charset=utf-8
+	contentType := strings.ToLower(strings.TrimSpace(strings.Split(envelope.ContentType, ";")[0]))
+	if _, ok := BTCValidContentTypes[contentType]; !ok {
+		return nil, fmt.Errorf("inscription content-type invalid & filtered, ct:%s", contentType)
+	}
+
+	data := strings.TrimSpace(string(envelope.Body))
+	proto := &devents.MetaData{}
+	if err := json.Unmarshal([]byte(data), proto); err != nil {
+		return nil, fmt.Errorf("inscription data parsed failed, data[%s], err[%v]", data, err)
+	}
+
+	proto.Protocol = strings.ToLower(strings.TrimSpace(proto.Protocol))
+	proto.Operate = strings.ToLower(strings.TrimSpace(proto.Operate))
+	proto.Tick = strings.ToLower(proto.Tick)
+	if proto.Protocol == "" || proto.Tick == "" {
+		return nil, fmt.Errorf("inscription data protocol / tick empty, data[%s]", data)
+	}
+	proto.Chain = chain
+	proto.Data = data
+	proto.Parent = envelope.Parent
+	proto.Content = devents.NewContent(contentType, envelope.Body)
+	return proto, nil
 }
@@ -23,10 +23,17 @@
 package protocol
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
 	"encoding/hex"
+	"github.com/uxuycom/indexer/config"
 	"github.com/uxuycom/indexer/devents"
 	"github.com/uxuycom/indexer/model"
+	"github.com/uxuycom/indexer/protocol/types"
+	"math"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -56,6 +63,7 @@ func TestParseEVMMetaData(t *testing.T) {
 				Protocol: "asc-20",
 				Tick:     "tduck",
 				Data:     "{\"p\":\"asc-20\",\"op\":\"deploy\",\"tick\":\"Tduck\",\"max\":\"210000000\",\"lim\":\"1000\"}",
+				Content:  devents.NewContent("", []byte("{\"p\":\"asc-20\",\"op\":\"deploy\",\"tick\":\"Tduck\",\"max\":\"210000000\",\"lim\":\"1000\"}")),
 			},
 			wantErr: false,
 		},
@@ -71,13 +79,14 @@ func TestParseEVMMetaData(t *testing.T) {
 				Protocol: "asc-20",
 				Tick:     "tduck",
 				Data:     "{\"p\":\"asc-20\",\"op\":\"deploy\",\"tick\":\"Tduck\",\"max\":\"210000000\",\"lim\":\"1000\"}",
+				Content:  devents.NewContent("", []byte("{\"p\":\"asc-20\",\"op\":\"deploy\",\"tick\":\"Tduck\",\"max\":\"210000000\",\"lim\":\"1000\"}")),
 			},
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := ParseEVMMetaData(tt.args.chain, tt.args.inputData)
+			got, err := ParseEVMMetaData(tt.args.chain, tt.args.inputData, math.MaxUint64)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("ParseEVMMetaData() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -89,6 +98,59 @@ func TestParseEVMMetaData(t *testing.T) {
 	}
 }
 
+func TestParseEVMMetaDataExtended(t *testing.T) {
+	schedule, err := types.NewRuleSchedule([]*config.RuleActivation{
+		{Protocol: "asc-20", Rule: types.RuleExtendedDataURI, Height: 100, Value: "true"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules = schedule
+	defer func() {
+		rules = nil
+	}()
+
+	payload := `{"p":"asc-20","op":"mint","tick":"avav","amt":"1000"}`
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, _ = w.Write([]byte("data:," + payload))
+	_ = w.Close()
+
+	inputs := map[string]string{
+		"base64":  "data:application/json;rule=esip6;base64," + base64.StdEncoding.EncodeToString([]byte(payload)),
+		"params":  "data:application/json;charset=utf-8," + payload,
+		"gzip":    buf.String(),
+		"padding": "data:;base64," + base64.StdEncoding.EncodeToString([]byte(payload+strings.Repeat(" ", 300))),
+	}
+	for name, input := range inputs {
+		inputData := "0x" + hex.EncodeToString([]byte(input))
+
+		// historical blocks keep rejecting the extended encodings
+		if md, _ := ParseEVMMetaData(model.ChainAVAX, inputData, 99); md != nil {
+			t.Fatalf("[%s] extended data uri parsed before activation", name)
+		}
+
+		md, err := ParseEVMMetaData(model.ChainAVAX, inputData, 100)
+		if name == "padding" {
+			if err == nil {
+				t.Fatalf("[%s] decoded payload length limit not enforced", name)
+			}
+			continue
+		}
+		if err != nil || md.Tick != "avav" || md.Operate != "mint" || md.Data != payload {
+			t.Fatalf("[%s] parsed md[%+v], err[%v]", name, md, err)
+		}
+
+		// the decoded payload is the content of the inscription
+		if md.Content == nil || string(md.Content.Data) != payload || md.Content.Sha256 != devents.NewContent("", []byte(payload)).Sha256 {
+			t.Fatalf("[%s] parsed content[%+v]", name, md.Content)
+		}
+		if name != "gzip" && md.Content.ContentType != "application/json" {
+			t.Fatalf("[%s] content type[%s], want application/json", name, md.Content.ContentType)
+		}
+	}
+}
+
 func Test_DecodeInput(t *testing.T) {
 
 	input := "25341e04f01db076c85ea9a27c84c83e13b166fe9db95c2865cf5e393704210c46af140780b39dc3380ee31204d7b80ce612f7754100458e4f1f7a37b86a18b623e463544d0198b72972179bf9fa39013e777240cc618957ed0ad94ab05e57505b01f0b423c4c1c000025fd711602d"
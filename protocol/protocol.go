@@ -23,18 +23,28 @@
 package protocol
 
 import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/uxuycom/indexer/client/xycommon"
 	"github.com/uxuycom/indexer/config"
 	"github.com/uxuycom/indexer/dcache"
 	"github.com/uxuycom/indexer/devents"
 	"github.com/uxuycom/indexer/model"
 	"github.com/uxuycom/indexer/protocol/avax/asc20"
+	"github.com/uxuycom/indexer/protocol/blob"
+	"github.com/uxuycom/indexer/protocol/bridge"
 	btcBrc20 "github.com/uxuycom/indexer/protocol/btc/brc20"
 	"github.com/uxuycom/indexer/protocol/evm/brc20"
 	"github.com/uxuycom/indexer/protocol/evm/erc20"
+	"github.com/uxuycom/indexer/protocol/evm/ethscriptions"
+	"github.com/uxuycom/indexer/protocol/marketplace"
+	"github.com/uxuycom/indexer/protocol/smartaccount"
 	"github.com/uxuycom/indexer/protocol/types"
+	"github.com/uxuycom/indexer/protocol/wrapper"
 	"github.com/uxuycom/indexer/storage"
 	"github.com/uxuycom/indexer/xylog"
+	"math"
 )
 
 var (
@@ -42,13 +52,180 @@ var (
 	EvmAsc20Protocol *asc20.Protocol
 	EvmBrc20Protocol *brc20.Protocol
 	EvmErc20Protocol *erc20.Protocol
+	EvmEthsProtocol  *ethscriptions.Protocol
+
+	// rules protocol rule schedule of the chain
+	rules *types.RuleSchedule
+
+	// markets configured marketplace adapters of the chain
+	markets *marketplace.Registry
+
+	// bridges configured bridge adapters of the chain
+	bridges *bridge.Registry
+
+	// wrappers configured wrapper contracts of the chain
+	wrappers *wrapper.Registry
 )
 
-func InitProtocols(cache *dcache.Manager) {
-	BTCBrc20Protocol = btcBrc20.NewProtocol(cache)
-	EvmBrc20Protocol = brc20.NewProtocol(cache)
-	EvmAsc20Protocol = asc20.NewProtocol(cache)
-	EvmErc20Protocol = erc20.NewProtocol(cache)
+// InitProtocols init protocol instances with the chain rule schedule
+func InitProtocols(cfg *config.Config, cache *dcache.Manager) error {
+	schedule, err := types.NewRuleSchedule(cfg.Chain.Rules)
+	if err != nil {
+		return fmt.Errorf("invalid protocol rules err:%v", err)
+	}
+	rules = schedule
+
+	registry, err := marketplace.NewRegistry(cfg.Chain.Marketplaces)
+	if err != nil {
+		return fmt.Errorf("invalid marketplaces err:%v", err)
+	}
+	markets = registry
+
+	bridgeRegistry, err := bridge.NewRegistry(cfg.Chain.Bridges)
+	if err != nil {
+		return fmt.Errorf("invalid bridges err:%v", err)
+	}
+	bridges = bridgeRegistry
+
+	wrapperRegistry, err := wrapper.NewRegistry(cfg.Chain.Wrappers)
+	if err != nil {
+		return fmt.Errorf("invalid wrappers err:%v", err)
+	}
+	wrappers = wrapperRegistry
+	for _, adapter := range wrappers.Adapters() {
+		item := &dcache.WrapperItem{
+			Name:     adapter.Name(),
+			Protocol: adapter.Protocol(),
+			Tick:     adapter.Tick(),
+			Address:  adapter.Address(),
+			Contract: adapter.Contract(),
+		}
+		if ok, balance := cache.Balance.Get(item.Protocol, item.Tick, item.Address); ok {
+			item.Locked = balance.Overall
+		}
+		cache.Wrapper.Create(item)
+	}
+
+	BTCBrc20Protocol = btcBrc20.NewProtocol(cache, rules)
+	EvmBrc20Protocol = brc20.NewProtocol(cache, rules, markets, bridges)
+	EvmAsc20Protocol = asc20.NewProtocol(cache, rules, markets, bridges)
+	EvmErc20Protocol = erc20.NewProtocol(cache, rules, markets, bridges)
+	EvmEthsProtocol = ethscriptions.NewProtocol(cache)
+	return nil
+}
+
+// MarketplaceTopics event topics of the configured marketplaces
+func MarketplaceTopics() []common.Hash {
+	return markets.Topics()
+}
+
+// BridgeTopics event topics of the configured bridges
+func BridgeTopics() []common.Hash {
+	return bridges.Topics()
+}
+
+// WrapperTopics event topics of the configured wrappers
+func WrapperTopics() []common.Hash {
+	return wrappers.Topics()
+}
+
+// HasWraps the tx has wrapper erc-20 mint & burn events
+func HasWraps(tx *xycommon.RpcTransaction) bool {
+	return wrappers.Match(tx)
+}
+
+// ParseWraps wrapper erc-20 mint & burn events of the tx
+func ParseWraps(cfg *config.Config, block *xycommon.RpcBlock, tx *xycommon.RpcTransaction) []*devents.TxResult {
+	return wrappers.Parse(cfg.Chain.ChainName, block, tx)
+}
+
+// SmartAccountTopics success event topics of the smart account calls, only when the smart_account rule is configured
+func SmartAccountTopics() []common.Hash {
+	if !rules.Configured(types.RuleSmartAccount) {
+		return nil
+	}
+	return smartaccount.Topics()
+}
+
+// UnwrapSmartAccount the inner calls of erc-4337 user operations or a safe transaction as txs sent by the smart accounts,
+// the sub index is the position of the call within the tx. Calls without an inscription or whose protocol has no active
+// smart_account rule are skipped, nil if none is left
+func UnwrapSmartAccount(cfg *config.Config, tx *xycommon.RpcTransaction) []*xycommon.RpcTransaction {
+	if cfg.Chain.ChainGroup == model.BtcChainGroup || !rules.Configured(types.RuleSmartAccount) {
+		return nil
+	}
+
+	height := uint64(math.MaxUint64)
+	if tx.BlockNumber != nil {
+		height = tx.BlockNumber.Uint64()
+	}
+
+	var txs []*xycommon.RpcTransaction
+	for idx, call := range smartaccount.Unwrap(tx) {
+		// the inner call only carries the inscription data
+		inner := *tx
+		inner.From = call.Account
+		inner.To = call.To
+		inner.Input = hexutil.Encode(call.Data)
+		inner.Events = nil
+		inner.SubIndex = idx
+
+		md, _ := ParseMetaData(cfg.Chain.ChainName, &inner)
+		if md == nil || !rules.At(md.Protocol, height).SmartAccount {
+			continue
+		}
+		txs = append(txs, &inner)
+	}
+	return txs
+}
+
+// BlobsConfigured the blobs rule is configured, the scan only fetches blob sidecars then
+func BlobsConfigured() bool {
+	return rules.Configured(types.RuleBlobs)
+}
+
+// BlobsActive the blobs rule of any protocol is active at the block height
+func BlobsActive(height uint64) bool {
+	return rules.Any(height, func(rs *types.RuleSet) bool {
+		return rs.Blobs
+	})
+}
+
+// UnwrapBlobs the tx with the inscription decoded from its eip-4844 blobs as calldata,
+// nil if the calldata is an inscription itself, the blobs carry none or the blobs rule of its protocol is not active
+func UnwrapBlobs(cfg *config.Config, tx *xycommon.RpcTransaction) *xycommon.RpcTransaction {
+	if cfg.Chain.ChainGroup == model.BtcChainGroup || len(tx.Blobs) == 0 || !rules.Configured(types.RuleBlobs) {
+		return nil
+	}
+
+	// calldata inscriptions take precedence, e.g. ESIP-8 blob attachments
+	if md, _ := ParseMetaData(cfg.Chain.ChainName, tx); md != nil {
+		return nil
+	}
+
+	payload, err := blob.Decode(tx.Blobs)
+	if err != nil {
+		xylog.Logger.Debugf("blobs decode failed, tx:%s, err:%v", tx.Hash, err)
+		return nil
+	}
+
+	inner := *tx
+	inner.Input = hexutil.Encode(payload)
+	inner.Blobs = nil
+
+	md, _ := ParseMetaData(cfg.Chain.ChainName, &inner)
+	if md == nil {
+		return nil
+	}
+
+	height := uint64(math.MaxUint64)
+	if tx.BlockNumber != nil {
+		height = tx.BlockNumber.Uint64()
+	}
+	if !rules.At(md.Protocol, height).Blobs {
+		return nil
+	}
+	return &inner
 }
 
 func GetProtocol(cfg *config.Config, tx *xycommon.RpcTransaction) (types.IProtocol, *devents.MetaData) {
@@ -73,6 +250,8 @@ func GetProtocol(cfg *config.Config, tx *xycommon.RpcTransaction) (types.IProtoc
 		return EvmAsc20Protocol, md
 	case types.ERC20Protocol:
 		return EvmErc20Protocol, md
+	case types.EthscriptionsProtocol:
+		return EvmEthsProtocol, md
 	default:
 		return EvmBrc20Protocol, md
 	}
@@ -0,0 +1,178 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package smartaccount
+
+// abiJSON erc-4337 EntryPoint v0.6 & v0.7 handleOps, the account execute calls & the safe execTransaction
+var abiJSON = `
+[
+  {
+    "inputs": [
+      {
+        "components": [
+          {"internalType": "address", "name": "sender", "type": "address"},
+          {"internalType": "uint256", "name": "nonce", "type": "uint256"},
+          {"internalType": "bytes", "name": "initCode", "type": "bytes"},
+          {"internalType": "bytes", "name": "callData", "type": "bytes"},
+          {"internalType": "uint256", "name": "callGasLimit", "type": "uint256"},
+          {"internalType": "uint256", "name": "verificationGasLimit", "type": "uint256"},
+          {"internalType": "uint256", "name": "preVerificationGas", "type": "uint256"},
+          {"internalType": "uint256", "name": "maxFeePerGas", "type": "uint256"},
+          {"internalType": "uint256", "name": "maxPriorityFeePerGas", "type": "uint256"},
+          {"internalType": "bytes", "name": "paymasterAndData", "type": "bytes"},
+          {"internalType": "bytes", "name": "signature", "type": "bytes"}
+        ],
+        "internalType": "struct UserOperation[]",
+        "name": "ops",
+        "type": "tuple[]"
+      },
+      {"internalType": "address payable", "name": "beneficiary", "type": "address"}
+    ],
+    "name": "handleOps",
+    "outputs": [],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  },
+  {
+    "inputs": [
+      {
+        "components": [
+          {"internalType": "address", "name": "sender", "type": "address"},
+          {"internalType": "uint256", "name": "nonce", "type": "uint256"},
+          {"internalType": "bytes", "name": "initCode", "type": "bytes"},
+          {"internalType": "bytes", "name": "callData", "type": "bytes"},
+          {"internalType": "bytes32", "name": "accountGasLimits", "type": "bytes32"},
+          {"internalType": "uint256", "name": "preVerificationGas", "type": "uint256"},
+          {"internalType": "bytes32", "name": "gasFees", "type": "bytes32"},
+          {"internalType": "bytes", "name": "paymasterAndData", "type": "bytes"},
+          {"internalType": "bytes", "name": "signature", "type": "bytes"}
+        ],
+        "internalType": "struct PackedUserOperation[]",
+        "name": "ops",
+        "type": "tuple[]"
+      },
+      {"internalType": "address payable", "name": "beneficiary", "type": "address"}
+    ],
+    "name": "handleOps",
+    "outputs": [],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  },
+  {
+    "anonymous": false,
+    "inputs": [
+      {"indexed": true, "internalType": "bytes32", "name": "userOpHash", "type": "bytes32"},
+      {"indexed": true, "internalType": "address", "name": "sender", "type": "address"},
+      {"indexed": true, "internalType": "address", "name": "paymaster", "type": "address"},
+      {"indexed": false, "internalType": "uint256", "name": "nonce", "type": "uint256"},
+      {"indexed": false, "internalType": "bool", "name": "success", "type": "bool"},
+      {"indexed": false, "internalType": "uint256", "name": "actualGasCost", "type": "uint256"},
+      {"indexed": false, "internalType": "uint256", "name": "actualGasUsed", "type": "uint256"}
+    ],
+    "name": "UserOperationEvent",
+    "type": "event"
+  },
+  {
+    "inputs": [
+      {"internalType": "address", "name": "dest", "type": "address"},
+      {"internalType": "uint256", "name": "value", "type": "uint256"},
+      {"internalType": "bytes", "name": "func", "type": "bytes"}
+    ],
+    "name": "execute",
+    "outputs": [],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  },
+  {
+    "inputs": [
+      {"internalType": "address[]", "name": "dest", "type": "address[]"},
+      {"internalType": "bytes[]", "name": "func", "type": "bytes[]"}
+    ],
+    "name": "executeBatch",
+    "outputs": [],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  },
+  {
+    "inputs": [
+      {"internalType": "address[]", "name": "dest", "type": "address[]"},
+      {"internalType": "uint256[]", "name": "value", "type": "uint256[]"},
+      {"internalType": "bytes[]", "name": "func", "type": "bytes[]"}
+    ],
+    "name": "executeBatch",
+    "outputs": [],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  },
+  {
+    "inputs": [
+      {"internalType": "address", "name": "to", "type": "address"},
+      {"internalType": "uint256", "name": "value", "type": "uint256"},
+      {"internalType": "bytes", "name": "data", "type": "bytes"},
+      {"internalType": "uint8", "name": "operation", "type": "uint8"}
+    ],
+    "name": "executeUserOp",
+    "outputs": [],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  },
+  {
+    "inputs": [
+      {"internalType": "address", "name": "to", "type": "address"},
+      {"internalType": "uint256", "name": "value", "type": "uint256"},
+      {"internalType": "bytes", "name": "data", "type": "bytes"},
+      {"internalType": "uint8", "name": "operation", "type": "uint8"}
+    ],
+    "name": "executeUserOpWithErrorString",
+    "outputs": [],
+    "stateMutability": "nonpayable",
+    "type": "function"
+  },
+  {
+    "inputs": [
+      {"internalType": "address", "name": "to", "type": "address"},
+      {"internalType": "uint256", "name": "value", "type": "uint256"},
+      {"internalType": "bytes", "name": "data", "type": "bytes"},
+      {"internalType": "uint8", "name": "operation", "type": "uint8"},
+      {"internalType": "uint256", "name": "safeTxGas", "type": "uint256"},
+      {"internalType": "uint256", "name": "baseGas", "type": "uint256"},
+      {"internalType": "uint256", "name": "gasPrice", "type": "uint256"},
+      {"internalType": "address", "name": "gasToken", "type": "address"},
+      {"internalType": "address payable", "name": "refundReceiver", "type": "address"},
+      {"internalType": "bytes", "name": "signatures", "type": "bytes"}
+    ],
+    "name": "execTransaction",
+    "outputs": [{"internalType": "bool", "name": "success", "type": "bool"}],
+    "stateMutability": "payable",
+    "type": "function"
+  },
+  {
+    "anonymous": false,
+    "inputs": [
+      {"indexed": false, "internalType": "bytes32", "name": "txHash", "type": "bytes32"},
+      {"indexed": false, "internalType": "uint256", "name": "payment", "type": "uint256"}
+    ],
+    "name": "ExecutionSuccess",
+    "type": "event"
+  }
+]
+`
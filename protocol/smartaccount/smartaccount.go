@@ -0,0 +1,219 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+// Package smartaccount decodes inscriptions sent by smart accounts, the inner calls of erc-4337 EntryPoint handleOps
+// and safe execTransaction are decoded from the calldata without traces.
+package smartaccount
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/utils"
+	"github.com/uxuycom/indexer/xylog"
+	"math/big"
+	"strings"
+)
+
+const (
+	// EntryPointV06 erc-4337 EntryPoint v0.6
+	EntryPointV06 = "0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789"
+
+	// EntryPointV07 erc-4337 EntryPoint v0.7
+	EntryPointV07 = "0x0000000071727De22E5E9d8BAf0edAc6f37da032"
+
+	// operationCall safe operation, delegate calls are ignored
+	operationCall = 0
+)
+
+var (
+	ParsedABI abi.ABI
+
+	// entryPoints only the known EntryPoint contracts can attribute calls to other senders
+	entryPoints = map[common.Address]struct{}{
+		common.HexToAddress(EntryPointV06): {},
+		common.HexToAddress(EntryPointV07): {},
+	}
+)
+
+// Call inner call of a smart account carrying the inscription data
+type Call struct {
+	Account string // smart account, the sender of the inscription
+	To      string
+	Data    []byte
+}
+
+type userOperation struct {
+	Sender   common.Address
+	Nonce    *big.Int
+	CallData []byte
+}
+
+// Topics the success events of the inner calls, logs are required to skip reverted user operations & safe txs
+func Topics() []common.Hash {
+	return []common.Hash{
+		ParsedABI.Events["UserOperationEvent"].ID,
+		ParsedABI.Events["ExecutionSuccess"].ID,
+	}
+}
+
+// Unwrap the successful inner calls carrying a data uri in calldata order, user operations of the bundle & the
+// executeBatch items of each operation, nil for other txs
+func Unwrap(tx *xycommon.RpcTransaction) []*Call {
+	method, args := unpack(tx.Input)
+	if method == nil {
+		return nil
+	}
+
+	switch method.RawName {
+	case "handleOps":
+		if _, ok := entryPoints[common.HexToAddress(tx.To)]; !ok {
+			return nil
+		}
+		return unwrapUserOps(tx, args)
+	case "execTransaction":
+		return unwrapSafe(tx, args)
+	}
+	return nil
+}
+
+func unpack(input string) (*abi.Method, []interface{}) {
+	callData := common.FromHex(input)
+	if len(callData) < 4 {
+		return nil, nil
+	}
+
+	method, err := ParsedABI.MethodById(callData[:4])
+	if err != nil {
+		return nil, nil
+	}
+
+	args, err := method.Inputs.UnpackValues(callData[4:])
+	if err != nil {
+		xylog.Logger.Debugf("smart account method[%s] unpack err:%v", method.Name, err)
+		return nil, nil
+	}
+	return method, args
+}
+
+func unwrapUserOps(tx *xycommon.RpcTransaction, args []interface{}) []*Call {
+	encodeBytes, _ := json.Marshal(args[0])
+	ops := make([]*userOperation, 0)
+	if err := json.Unmarshal(encodeBytes, &ops); err != nil {
+		xylog.Logger.Errorf("tx[%s] - user operations unmarshal err:%v", tx.Hash, err)
+		return nil
+	}
+
+	var calls []*Call
+	for _, op := range ops {
+		items := accountCalls(op.Sender, op.CallData)
+		if len(items) == 0 || !userOpSucceeded(tx, op) {
+			continue
+		}
+		calls = append(calls, items...)
+	}
+	return calls
+}
+
+// accountCalls the inner calls with a data uri of the account execute calls
+func accountCalls(account common.Address, callData []byte) []*Call {
+	method, args := unpack(common.Bytes2Hex(callData))
+	if method == nil {
+		return nil
+	}
+
+	var calls []*Call
+	switch method.RawName {
+	case "execute":
+		if to, data := args[0].(common.Address), args[2].([]byte); isDataURI(data) {
+			calls = append(calls, &Call{Account: account.String(), To: to.String(), Data: data})
+		}
+	case "executeUserOp", "executeUserOpWithErrorString":
+		if to, data := args[0].(common.Address), args[2].([]byte); isDataURI(data) && args[3].(uint8) == operationCall {
+			calls = append(calls, &Call{Account: account.String(), To: to.String(), Data: data})
+		}
+	case "executeBatch":
+		dests, items := args[0].([]common.Address), args[len(args)-1].([][]byte)
+		for idx, data := range items {
+			if idx < len(dests) && isDataURI(data) {
+				calls = append(calls, &Call{Account: account.String(), To: dests[idx].String(), Data: data})
+			}
+		}
+	}
+	return calls
+}
+
+// userOpSucceeded the EntryPoint logged the user operation of the sender & nonce as successful
+func userOpSucceeded(tx *xycommon.RpcTransaction, op *userOperation) bool {
+	event := ParsedABI.Events["UserOperationEvent"]
+	for _, log := range tx.Events {
+		if len(log.Topics) != 4 || log.Topics[0] != event.ID || !strings.EqualFold(log.Address.String(), tx.To) {
+			continue
+		}
+
+		if common.BytesToAddress(log.Topics[2].Bytes()) != op.Sender {
+			continue
+		}
+
+		values, err := event.Inputs.NonIndexed().Unpack(log.Data)
+		if err != nil || len(values) < 2 {
+			continue
+		}
+
+		if nonce, ok := values[0].(*big.Int); ok && nonce.Cmp(op.Nonce) == 0 {
+			success, _ := values[1].(bool)
+			return success
+		}
+	}
+	return false
+}
+
+func unwrapSafe(tx *xycommon.RpcTransaction, args []interface{}) []*Call {
+	to, data, operation := args[0].(common.Address), args[2].([]byte), args[3].(uint8)
+	if !isDataURI(data) || operation != operationCall {
+		return nil
+	}
+
+	// the safe logs ExecutionSuccess, ExecutionFailure when the inner call reverted
+	event := ParsedABI.Events["ExecutionSuccess"]
+	for _, log := range tx.Events {
+		if len(log.Topics) > 0 && log.Topics[0] == event.ID && strings.EqualFold(log.Address.String(), tx.To) {
+			return []*Call{{Account: common.HexToAddress(tx.To).String(), To: to.String(), Data: data}}
+		}
+	}
+	return nil
+}
+
+// isDataURI plain & ESIP-7 gzip compressed data uri calldata
+func isDataURI(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("data:")) || utils.IsGzip(data)
+}
+
+func init() {
+	var err error
+	ParsedABI, err = abi.JSON(strings.NewReader(abiJSON))
+	if err != nil {
+		xylog.Logger.Fatalf("smart account abi decode err:%v", err)
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package smartaccount
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/xylog"
+	"math/big"
+	"testing"
+)
+
+func init() {
+	xylog.InitLog(logrus.ErrorLevel, "")
+}
+
+type userOperationV06 struct {
+	Sender               common.Address
+	Nonce                *big.Int
+	InitCode             []byte
+	CallData             []byte
+	CallGasLimit         *big.Int
+	VerificationGasLimit *big.Int
+	PreVerificationGas   *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	PaymasterAndData     []byte
+	Signature            []byte
+}
+
+type userOperationV07 struct {
+	Sender             common.Address
+	Nonce              *big.Int
+	InitCode           []byte
+	CallData           []byte
+	AccountGasLimits   [32]byte
+	PreVerificationGas *big.Int
+	GasFees            [32]byte
+	PaymasterAndData   []byte
+	Signature          []byte
+}
+
+var (
+	account   = common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	account2  = common.HexToAddress("0x00000000000000000000000000000000000000a2")
+	dest      = common.HexToAddress("0x00000000000000000000000000000000000000d1")
+	dest2     = common.HexToAddress("0x00000000000000000000000000000000000000d2")
+	inscribed = []byte(`data:,{"p":"asc-20","op":"mint","tick":"avav","amt":"1000"}`)
+)
+
+func pack(t *testing.T, name string, args ...interface{}) []byte {
+	data, err := ParsedABI.Pack(name, args...)
+	assert.NoError(t, err)
+	return data
+}
+
+func userOpLog(t *testing.T, entryPoint, sender common.Address, nonce int64, success bool) xycommon.RpcLog {
+	event := ParsedABI.Events["UserOperationEvent"]
+	data, err := event.Inputs.NonIndexed().Pack(big.NewInt(nonce), success, big.NewInt(1), big.NewInt(1))
+	assert.NoError(t, err)
+	return xycommon.RpcLog{
+		Address: entryPoint,
+		Topics:  []common.Hash{event.ID, {}, common.BytesToHash(sender.Bytes()), {}},
+		Data:    data,
+	}
+}
+
+func v06Op(sender common.Address, nonce int64, callData []byte) userOperationV06 {
+	zero := big.NewInt(0)
+	return userOperationV06{
+		Sender: sender, Nonce: big.NewInt(nonce), CallData: callData, CallGasLimit: zero, VerificationGasLimit: zero,
+		PreVerificationGas: zero, MaxFeePerGas: zero, MaxPriorityFeePerGas: zero,
+	}
+}
+
+func TestUnwrapUserOps(t *testing.T) {
+	entryPoint := common.HexToAddress(EntryPointV06)
+	execute := pack(t, "execute", dest, big.NewInt(0), inscribed)
+	input := hexutil.Encode(pack(t, "handleOps", []userOperationV06{v06Op(account, 7, execute)}, account))
+
+	tx := &xycommon.RpcTransaction{To: EntryPointV06, Input: input, Events: []xycommon.RpcLog{userOpLog(t, entryPoint, account, 7, true)}}
+	calls := Unwrap(tx)
+	if assert.Len(t, calls, 1) {
+		assert.Equal(t, account.String(), calls[0].Account)
+		assert.Equal(t, dest.String(), calls[0].To)
+		assert.Equal(t, inscribed, calls[0].Data)
+	}
+
+	// reverted user operation
+	tx.Events = []xycommon.RpcLog{userOpLog(t, entryPoint, account, 7, false)}
+	assert.Nil(t, Unwrap(tx))
+
+	// success event of another nonce
+	tx.Events = []xycommon.RpcLog{userOpLog(t, entryPoint, account, 8, true)}
+	assert.Nil(t, Unwrap(tx))
+
+	// unknown EntryPoint contracts can't attribute calls to other senders
+	fake := common.HexToAddress("0x00000000000000000000000000000000000000ff")
+	tx = &xycommon.RpcTransaction{To: fake.String(), Input: input, Events: []xycommon.RpcLog{userOpLog(t, fake, account, 7, true)}}
+	assert.Nil(t, Unwrap(tx))
+}
+
+func TestUnwrapPackedUserOps(t *testing.T) {
+	entryPoint := common.HexToAddress(EntryPointV07)
+	plain := pack(t, "execute", dest, big.NewInt(1), []byte{})
+	batch := pack(t, "executeBatch", []common.Address{dest, dest2}, [][]byte{{}, inscribed})
+	input := hexutil.Encode(pack(t, "handleOps0", []userOperationV07{
+		{Sender: account, Nonce: big.NewInt(1), CallData: plain, PreVerificationGas: big.NewInt(0)},
+		{Sender: account2, Nonce: big.NewInt(2), CallData: batch, PreVerificationGas: big.NewInt(0)},
+	}, account))
+
+	tx := &xycommon.RpcTransaction{To: EntryPointV07, Input: input, Events: []xycommon.RpcLog{
+		userOpLog(t, entryPoint, account, 1, true),
+		userOpLog(t, entryPoint, account2, 2, true),
+	}}
+	calls := Unwrap(tx)
+	if assert.Len(t, calls, 1) {
+		assert.Equal(t, account2.String(), calls[0].Account)
+		assert.Equal(t, dest2.String(), calls[0].To)
+		assert.Equal(t, inscribed, calls[0].Data)
+	}
+
+	// safe 4337 module calls, delegate calls are ignored
+	for operation, expected := range map[uint8]bool{0: true, 1: false} {
+		callData := pack(t, "executeUserOp", dest, big.NewInt(0), inscribed, operation)
+		input = hexutil.Encode(pack(t, "handleOps0", []userOperationV07{
+			{Sender: account, Nonce: big.NewInt(1), CallData: callData, PreVerificationGas: big.NewInt(0)},
+		}, account))
+		tx = &xycommon.RpcTransaction{To: EntryPointV07, Input: input, Events: []xycommon.RpcLog{userOpLog(t, entryPoint, account, 1, true)}}
+		assert.Equal(t, expected, len(Unwrap(tx)) == 1)
+	}
+}
+
+func TestUnwrapBundle(t *testing.T) {
+	entryPoint := common.HexToAddress(EntryPointV06)
+	transfer := []byte(`data:,{"p":"asc-20","op":"transfer","tick":"avav","amt":"10"}`)
+	execute := pack(t, "execute", dest, big.NewInt(0), inscribed)
+	batch := pack(t, "executeBatch", []common.Address{dest, dest2, dest}, [][]byte{inscribed, {}, transfer})
+	input := hexutil.Encode(pack(t, "handleOps", []userOperationV06{
+		v06Op(account, 1, execute),
+		v06Op(account2, 2, batch),
+		v06Op(account, 3, execute),
+	}, account))
+
+	// every successful user operation & every batch item with a data uri in calldata order, the reverted one is skipped
+	tx := &xycommon.RpcTransaction{To: EntryPointV06, Input: input, Events: []xycommon.RpcLog{
+		userOpLog(t, entryPoint, account, 1, true),
+		userOpLog(t, entryPoint, account2, 2, true),
+		userOpLog(t, entryPoint, account, 3, false),
+	}}
+	calls := Unwrap(tx)
+	if assert.Len(t, calls, 3) {
+		assert.Equal(t, []string{account.String(), account2.String(), account2.String()},
+			[]string{calls[0].Account, calls[1].Account, calls[2].Account})
+		assert.Equal(t, []string{dest.String(), dest.String(), dest.String()}, []string{calls[0].To, calls[1].To, calls[2].To})
+		assert.Equal(t, [][]byte{inscribed, inscribed, transfer}, [][]byte{calls[0].Data, calls[1].Data, calls[2].Data})
+	}
+}
+
+func TestUnwrapSafe(t *testing.T) {
+	safe := common.HexToAddress("0x000000000000000000000000000000000000005a")
+	success := xycommon.RpcLog{Address: safe, Topics: []common.Hash{ParsedABI.Events["ExecutionSuccess"].ID}}
+	execTransaction := func(operation uint8) string {
+		zero := big.NewInt(0)
+		return hexutil.Encode(pack(t, "execTransaction", dest, zero, inscribed, operation, zero, zero, zero, common.Address{}, common.Address{}, []byte{1}))
+	}
+
+	tx := &xycommon.RpcTransaction{From: account.String(), To: safe.String(), Input: execTransaction(0), Events: []xycommon.RpcLog{success}}
+	calls := Unwrap(tx)
+	if assert.Len(t, calls, 1) {
+		assert.Equal(t, safe.String(), calls[0].Account)
+		assert.Equal(t, dest.String(), calls[0].To)
+		assert.Equal(t, inscribed, calls[0].Data)
+	}
+
+	// inner call failed
+	tx.Events = nil
+	assert.Nil(t, Unwrap(tx))
+
+	// delegate call
+	tx = &xycommon.RpcTransaction{To: safe.String(), Input: execTransaction(1), Events: []xycommon.RpcLog{success}}
+	assert.Nil(t, Unwrap(tx))
+
+	// plain inscription txs
+	assert.Nil(t, Unwrap(&xycommon.RpcTransaction{To: safe.String(), Input: hexutil.Encode(inscribed)}))
+}
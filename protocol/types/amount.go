@@ -0,0 +1,174 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/shopspring/decimal"
+	"strconv"
+	"strings"
+)
+
+// amounts are stored as decimal(38,18)
+const (
+	MaxAmountIntegerDigits  = 20
+	MaxAmountFractionDigits = 18
+)
+
+// Amount inscription amount of the canonical form `[0-9]+(\.[0-9]+)?`, as a json string or number.
+// Signs, exponents, whitespace, "1." and ".5" are not canonical, leading & trailing zeros are normalized.
+// Non canonical values still decoding as a plain decimal are kept for the heights before the strict_amounts rule.
+type Amount struct {
+	decimal.Decimal
+	scale int32 // fraction digits as written
+	err   error // canonical form error
+}
+
+func NewAmount(value decimal.Decimal) Amount {
+	return Amount{Decimal: value}
+}
+
+// ParseAmount parse a canonical amount string
+func ParseAmount(value string) (Amount, error) {
+	if value == "" {
+		return Amount{}, fmt.Errorf("amount empty")
+	}
+
+	integer, fraction, hasDot := strings.Cut(value, ".")
+	if integer == "" || (hasDot && fraction == "") || !isDigits(integer) || !isDigits(fraction) {
+		return Amount{}, fmt.Errorf("invalid amount[%s]", value)
+	}
+
+	// decimal(38,18) precision
+	if digits := len(strings.TrimLeft(integer, "0")); digits > MaxAmountIntegerDigits {
+		return Amount{}, fmt.Errorf("amount[%s] integer digits %d > %d", value, digits, MaxAmountIntegerDigits)
+	}
+	if len(fraction) > MaxAmountFractionDigits {
+		return Amount{}, fmt.Errorf("amount[%s] fraction digits %d > %d", value, len(fraction), MaxAmountFractionDigits)
+	}
+
+	// canonical value, "007.50" is 7.5
+	canonical := integer
+	if f := strings.TrimRight(fraction, "0"); f != "" {
+		canonical += "." + f
+	}
+	d, err := decimal.NewFromString(canonical)
+	if err != nil {
+		return Amount{}, fmt.Errorf("invalid amount[%s]", value)
+	}
+	return Amount{Decimal: d, scale: int32(len(fraction))}, nil
+}
+
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	value := string(data)
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		value = value[1 : len(value)-1]
+	}
+
+	v, err := ParseAmount(value)
+	if err != nil {
+		// legacy plain decimal
+		var d decimal.Decimal
+		if d.UnmarshalJSON(data) != nil {
+			return err
+		}
+		v = Amount{Decimal: d, err: err}
+		if d.Exponent() < 0 {
+			v.scale = -d.Exponent()
+		}
+	}
+	*a = v
+	return nil
+}
+
+// Canonical error of a value not written in the canonical form
+func (a Amount) Canonical() error {
+	return a.err
+}
+
+// Scale fraction digits as written, "1.50" is 2
+func (a Amount) Scale() int32 {
+	return a.scale
+}
+
+// CheckDecimals fraction digits can not exceed the tick decimals
+func (a Amount) CheckDecimals(decimals int8) error {
+	if a.scale > int32(decimals) {
+		return fmt.Errorf("amount[%s] fraction digits %d > decimals %d", a.String(), a.scale, decimals)
+	}
+	return nil
+}
+
+// Decimals tick decimals of the canonical form `0|[1-9][0-9]*`, as a json string or number.
+// Non canonical values still decoding as a plain decimal are kept for the heights before the strict_amounts rule.
+type Decimals struct {
+	decimal.Decimal
+	err error // canonical form error
+}
+
+func (d *Decimals) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	// legacy plain decimal
+	var v decimal.Decimal
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+
+	value := string(data)
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		value = value[1 : len(value)-1]
+	}
+
+	var err error
+	if value == "" || !isDigits(value) || (len(value) > 1 && value[0] == '0') {
+		err = fmt.Errorf("invalid decimals[%s]", value)
+	} else if _, e := strconv.ParseInt(value, 10, 64); e != nil {
+		err = fmt.Errorf("invalid decimals[%s]", value)
+	}
+	*d = Decimals{Decimal: v, err: err}
+	return nil
+}
+
+// Canonical error of a value not written in the canonical form
+func (d Decimals) Canonical() error {
+	return d.err
+}
+
+func isDigits(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
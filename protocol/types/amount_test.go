@@ -0,0 +1,175 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestAmountConformance amount edge cases, valid values are compared in the canonical form
+func TestAmountConformance(t *testing.T) {
+	tests := []struct {
+		data      string
+		canonical string // empty means invalid
+		scale     int32
+	}{
+		// plain values
+		{`"1"`, "1", 0},
+		{`"1000"`, "1000", 0},
+		{`"0"`, "0", 0},
+		{`"1.5"`, "1.5", 1},
+		{`"0.000000000000000001"`, "0.000000000000000001", 18},
+		{`1000`, "1000", 0},
+		{`1.25`, "1.25", 2},
+
+		// leading & trailing zeros are normalized
+		{`"007"`, "7", 0},
+		{`"00.10"`, "0.1", 2},
+		{`"1.500"`, "1.5", 3},
+		{`"1.0"`, "1", 1},
+
+		// missing integer / fraction digits
+		{`"1."`, "", 0},
+		{`".5"`, "", 0},
+		{`"."`, "", 0},
+		{`""`, "", 0},
+
+		// exponent notation
+		{`"1e3"`, "", 0},
+		{`"1E3"`, "", 0},
+		{`"1.5e-3"`, "", 0},
+		{`1e3`, "", 0},
+
+		// signs, whitespace & separators
+		{`"-1"`, "", 0},
+		{`"+1"`, "", 0},
+		{`-1`, "", 0},
+		{`" 1"`, "", 0},
+		{`"1 "`, "", 0},
+		{`"1,000"`, "", 0},
+		{`"1.2.3"`, "", 0},
+		{`"0x10"`, "", 0},
+		{`"NaN"`, "", 0},
+		{`true`, "", 0},
+
+		// decimal(38,18) precision
+		{`"99999999999999999999"`, "99999999999999999999", 0},
+		{`"000099999999999999999999"`, "99999999999999999999", 0},
+		{`"100000000000000000000"`, "", 0},
+		{`"1.0000000000000000001"`, "", 0},
+		{`"99999999999999999999.999999999999999999"`, "99999999999999999999.999999999999999999", 18},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			var v struct {
+				Amount Amount `json:"amt"`
+			}
+			err := json.Unmarshal([]byte(`{"amt":`+tt.data+`}`), &v)
+			if tt.canonical == "" {
+				if err == nil && v.Amount.Canonical() == nil {
+					t.Fatalf("expected error, got %s", v.Amount.String())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected err:%v", err)
+			}
+			if v.Amount.String() != tt.canonical || v.Amount.Scale() != tt.scale {
+				t.Fatalf("got %s scale[%d], want %s scale[%d]", v.Amount.String(), v.Amount.Scale(), tt.canonical, tt.scale)
+			}
+		})
+	}
+}
+
+func TestAmountCheckDecimals(t *testing.T) {
+	tests := []struct {
+		value    string
+		decimals int8
+		valid    bool
+	}{
+		{"1", 0, true},
+		{"1.5", 0, false},
+		{"1.5", 1, true},
+		{"1.50", 1, false},
+		{"0.000000000000000001", 18, true},
+		{"0.123", 2, false},
+	}
+
+	for _, tt := range tests {
+		amount, err := ParseAmount(tt.value)
+		if err != nil {
+			t.Fatalf("parse amount[%s] err:%v", tt.value, err)
+		}
+		if err := amount.CheckDecimals(tt.decimals); (err == nil) != tt.valid {
+			t.Fatalf("amount[%s] decimals[%d] got err[%v], want valid[%v]", tt.value, tt.decimals, err, tt.valid)
+		}
+	}
+}
+
+func TestDecimalsConformance(t *testing.T) {
+	tests := []struct {
+		data      string
+		value     int64
+		valid     bool // decodes as a plain decimal
+		canonical bool
+	}{
+		{`"18"`, 18, true, true},
+		{`8`, 8, true, true},
+		{`"0"`, 0, true, true},
+		{`null`, 0, true, true},
+		{`"18.0"`, 18, true, false},
+		{`"08"`, 8, true, false},
+		{`"1e1"`, 10, true, false},
+		{`1e1`, 10, true, false},
+		{`"-1"`, -1, true, false},
+		{`"2.5"`, 0, true, false},
+		{`""`, 0, false, false},
+		{`"abc"`, 0, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			var v struct {
+				Decimals Decimals `json:"dec"`
+			}
+			err := json.Unmarshal([]byte(`{"dec":`+tt.data+`}`), &v)
+			if !tt.valid {
+				if err == nil {
+					t.Fatalf("expected error, got %s", v.Decimals.String())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unmarshal err:%v", err)
+			}
+			if tt.canonical && v.Decimals.IntPart() != tt.value {
+				t.Fatalf("got %s, want %d", v.Decimals.String(), tt.value)
+			}
+			if (v.Decimals.Canonical() == nil) != tt.canonical {
+				t.Fatalf("got canonical err[%v], want canonical[%v]", v.Decimals.Canonical(), tt.canonical)
+			}
+		})
+	}
+}
@@ -39,6 +39,9 @@ const (
 	PRC20Protocol = "prc-20"
 	ERC20Protocol = "erc-20"
 
+	EthscriptionsProtocol = "ethscriptions"
+	RunesProtocol         = "runes"
+
 	DefaultMaxDataLength = 256
 )
 
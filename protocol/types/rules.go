@@ -0,0 +1,328 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package types
+
+import (
+	"fmt"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/config"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// protocol rules configurable by activation height
+const (
+	RuleMaxDecimals   = "max_decimals"    // maximum deploy decimals
+	RuleMaxSupply     = "max_supply"      // maximum deploy max supply
+	RuleMintTruncate  = "mint_truncate"   // truncate the last mint to the supply left, otherwise reject it
+	RuleMaxDataLength = "max_data_length" // maximum inscription data length
+	RuleSelfMint      = "self_mint"       // brc-20 5-byte self mint ticks
+
+	// RuleExtendedDataURI rfc 2397 data uri params, base64 & gzip calldata, the data length limit applies to the decoded payload
+	RuleExtendedDataURI = "extended_data_uri"
+
+	// RuleBatchTransfer maximum recipients of a single transfer inscription listing (to, amt) pairs, 0 disables it
+	RuleBatchTransfer = "batch_transfer"
+
+	// RuleBurnAddresses comma separated unspendable addresses, receives are counted as burned supply
+	RuleBurnAddresses = "burn_addresses"
+
+	// RuleDeployParams deploy mint windows (start_block, end_block), mint caps (max_mints_per_address, max_mints_per_block) & deployer_only
+	RuleDeployParams = "deploy_params"
+
+	// RuleFreeze freeze / unfreeze operations moving balances between available & locked
+	RuleFreeze = "freeze"
+
+	// RuleSmartAccount inscriptions in the inner calls of erc-4337 user operations & safe transactions
+	RuleSmartAccount = "smart_account"
+
+	// RuleBlobs inscriptions carried in the eip-4844 blobs of type-3 txs
+	RuleBlobs = "blobs"
+
+	// RuleStrictAmounts canonical amount format & amount fraction digits within the tick decimals
+	RuleStrictAmounts = "strict_amounts"
+)
+
+// RuleSet protocol rules active at a block height
+type RuleSet struct {
+	MaxDecimals     int64
+	MaxSupply       decimal.Decimal
+	MintTruncate    bool
+	MaxDataLength   int
+	SelfMint        bool
+	ExtendedDataURI bool
+	BatchTransfer   int
+	BurnAddresses   map[string]struct{}
+	DeployParams    bool
+	Freeze          bool
+	SmartAccount    bool
+	Blobs           bool
+	StrictAmounts   bool
+}
+
+// DefaultRuleSet rules applied before any configured activation
+func DefaultRuleSet(protocol string) *RuleSet {
+	maxDataLength := DefaultMaxDataLength
+	if value, ok := DefaultMaxDataLengthMap[protocol]; ok {
+		maxDataLength = value
+	}
+
+	return &RuleSet{
+		MaxDecimals:   18,
+		MaxSupply:     decimal.NewFromBigInt(new(big.Int).SetUint64(math.MaxUint64), 0),
+		MintTruncate:  true,
+		MaxDataLength: maxDataLength,
+	}
+}
+
+// ruleStep rules of a protocol from the activation height on
+type ruleStep struct {
+	height uint64
+	rules  *RuleSet
+}
+
+// RuleSchedule per protocol rule activations sorted by height, the rules of each activation height are built once
+type RuleSchedule struct {
+	activations map[string][]*config.RuleActivation
+	steps       map[string][]*ruleStep
+	defaults    map[string]*RuleSet
+	fallback    *RuleSet
+}
+
+func NewRuleSchedule(items []*config.RuleActivation) (*RuleSchedule, error) {
+	s := &RuleSchedule{
+		activations: make(map[string][]*config.RuleActivation, len(items)),
+		steps:       make(map[string][]*ruleStep, len(items)),
+		defaults:    make(map[string]*RuleSet, len(DefaultMaxDataLengthMap)),
+		fallback:    DefaultRuleSet(""),
+	}
+	for protocol := range DefaultMaxDataLengthMap {
+		s.defaults[protocol] = DefaultRuleSet(protocol)
+	}
+
+	for _, item := range items {
+		if item == nil {
+			continue
+		}
+
+		protocol := strings.ToLower(strings.TrimSpace(item.Protocol))
+		if protocol == "" {
+			return nil, fmt.Errorf("rule[%s] protocol empty", item.Rule)
+		}
+
+		// validate value format ahead, apply never fails later
+		if err := DefaultRuleSet(protocol).apply(item); err != nil {
+			return nil, err
+		}
+		s.activations[protocol] = append(s.activations[protocol], item)
+	}
+
+	for protocol, items := range s.activations {
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].Height < items[j].Height
+		})
+
+		// replay the activations once, a copy of the rules is kept after the last activation of each height
+		rs := DefaultRuleSet(protocol)
+		steps := make([]*ruleStep, 0, len(items))
+		for i, item := range items {
+			_ = rs.apply(item)
+			if i+1 < len(items) && items[i+1].Height == item.Height {
+				continue
+			}
+
+			step := *rs
+			steps = append(steps, &ruleStep{height: item.Height, rules: &step})
+		}
+		s.steps[protocol] = steps
+	}
+	return s, nil
+}
+
+// At returns the rules of the protocol active at the block height, nil schedule returns the default rules.
+// The rules are shared by all callers and must not be modified
+func (s *RuleSchedule) At(protocol string, height uint64) *RuleSet {
+	if s == nil {
+		return DefaultRuleSet(protocol)
+	}
+
+	protocol = strings.ToLower(protocol)
+	steps := s.steps[protocol]
+	if i := sort.Search(len(steps), func(i int) bool { return steps[i].height > height }); i > 0 {
+		return steps[i-1].rules
+	}
+
+	if rs, ok := s.defaults[protocol]; ok {
+		return rs
+	}
+	return s.fallback
+}
+
+// Configured the rule is activated for any protocol at any height
+func (s *RuleSchedule) Configured(rule string) bool {
+	if s == nil {
+		return false
+	}
+
+	for _, items := range s.activations {
+		for _, item := range items {
+			if item.Rule == rule {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Any the rules of any protocol active at the block height match
+func (s *RuleSchedule) Any(height uint64, match func(rs *RuleSet) bool) bool {
+	if s == nil {
+		return false
+	}
+
+	for protocol := range s.activations {
+		if match(s.At(protocol, height)) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBurnAddress receives of burn addresses are burned supply
+func (rs *RuleSet) IsBurnAddress(address string) bool {
+	_, ok := rs.BurnAddresses[strings.ToLower(address)]
+	return ok
+}
+
+// CheckAmount canonical format & fraction digits within the tick decimals, once strict_amounts is active
+func (rs *RuleSet) CheckAmount(amount Amount, decimals int8) error {
+	if !rs.StrictAmounts {
+		return nil
+	}
+	if err := amount.Canonical(); err != nil {
+		return err
+	}
+	return amount.CheckDecimals(decimals)
+}
+
+// CheckDecimals canonical integer format of the deploy decimals, once strict_amounts is active
+func (rs *RuleSet) CheckDecimals(decimals Decimals) error {
+	if !rs.StrictAmounts {
+		return nil
+	}
+	return decimals.Canonical()
+}
+
+func (rs *RuleSet) apply(item *config.RuleActivation) error {
+	value := strings.TrimSpace(item.Value)
+	switch item.Rule {
+	case RuleMaxDecimals:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || v < 0 || v > 18 {
+			return fmt.Errorf("rule[%s] invalid value[%s]", item.Rule, item.Value)
+		}
+		rs.MaxDecimals = v
+	case RuleMaxSupply:
+		v, err := decimal.NewFromString(value)
+		if err != nil || v.LessThanOrEqual(decimal.Zero) {
+			return fmt.Errorf("rule[%s] invalid value[%s]", item.Rule, item.Value)
+		}
+		rs.MaxSupply = v
+	case RuleMintTruncate:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("rule[%s] invalid value[%s]", item.Rule, item.Value)
+		}
+		rs.MintTruncate = v
+	case RuleMaxDataLength:
+		v, err := strconv.Atoi(value)
+		if err != nil || v <= 0 {
+			return fmt.Errorf("rule[%s] invalid value[%s]", item.Rule, item.Value)
+		}
+		rs.MaxDataLength = v
+	case RuleSelfMint:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("rule[%s] invalid value[%s]", item.Rule, item.Value)
+		}
+		rs.SelfMint = v
+	case RuleExtendedDataURI:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("rule[%s] invalid value[%s]", item.Rule, item.Value)
+		}
+		rs.ExtendedDataURI = v
+	case RuleBatchTransfer:
+		v, err := strconv.Atoi(value)
+		if err != nil || v < 0 {
+			return fmt.Errorf("rule[%s] invalid value[%s]", item.Rule, item.Value)
+		}
+		rs.BatchTransfer = v
+	case RuleBurnAddresses:
+		addresses := make(map[string]struct{})
+		for _, address := range strings.Split(value, ",") {
+			address = strings.ToLower(strings.TrimSpace(address))
+			if address == "" {
+				return fmt.Errorf("rule[%s] invalid value[%s]", item.Rule, item.Value)
+			}
+			addresses[address] = struct{}{}
+		}
+		rs.BurnAddresses = addresses
+	case RuleDeployParams:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("rule[%s] invalid value[%s]", item.Rule, item.Value)
+		}
+		rs.DeployParams = v
+	case RuleFreeze:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("rule[%s] invalid value[%s]", item.Rule, item.Value)
+		}
+		rs.Freeze = v
+	case RuleSmartAccount:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("rule[%s] invalid value[%s]", item.Rule, item.Value)
+		}
+		rs.SmartAccount = v
+	case RuleBlobs:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("rule[%s] invalid value[%s]", item.Rule, item.Value)
+		}
+		rs.Blobs = v
+	case RuleStrictAmounts:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("rule[%s] invalid value[%s]", item.Rule, item.Value)
+		}
+		rs.StrictAmounts = v
+	default:
+		return fmt.Errorf("unknown rule[%s]", item.Rule)
+	}
+	return nil
+}
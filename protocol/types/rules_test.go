@@ -0,0 +1,173 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package types
+
+import (
+	"encoding/json"
+	"github.com/uxuycom/indexer/config"
+	"testing"
+)
+
+func TestRuleSchedule(t *testing.T) {
+	schedule, err := NewRuleSchedule([]*config.RuleActivation{
+		{Protocol: ASC20Protocol, Rule: RuleMaxDecimals, Height: 200, Value: "8"},
+		{Protocol: "ASC-20", Rule: RuleMaxDecimals, Height: 100, Value: "12"},
+		{Protocol: ASC20Protocol, Rule: RuleMintTruncate, Height: 150, Value: "false"},
+		{Protocol: ASC20Protocol, Rule: RuleMaxDataLength, Height: 150, Value: "512"},
+	})
+	if err != nil {
+		t.Fatalf("new rule schedule err:%v", err)
+	}
+
+	if !schedule.Configured(RuleMintTruncate) || schedule.Configured(RuleSmartAccount) {
+		t.Fatalf("configured rules mismatch")
+	}
+
+	tests := []struct {
+		height        uint64
+		maxDecimals   int64
+		mintTruncate  bool
+		maxDataLength int
+	}{
+		{99, 18, true, 256},
+		{100, 12, true, 256},
+		{150, 12, false, 512},
+		{200, 8, false, 512},
+	}
+	for _, tt := range tests {
+		rs := schedule.At(ASC20Protocol, tt.height)
+		if rs.MaxDecimals != tt.maxDecimals || rs.MintTruncate != tt.mintTruncate || rs.MaxDataLength != tt.maxDataLength {
+			t.Fatalf("height[%d] got %+v", tt.height, rs)
+		}
+	}
+
+	// the rules of an activation height are built once & shared up to the next activation
+	if schedule.At(ASC20Protocol, 150) != schedule.At(ASC20Protocol, 199) || schedule.At(ASC20Protocol, 0) != schedule.At(ASC20Protocol, 99) {
+		t.Fatalf("rules of the same activation rebuilt")
+	}
+	if schedule.At(ASC20Protocol, 100) == schedule.At(ASC20Protocol, 150) {
+		t.Fatalf("rules of different activations shared")
+	}
+
+	// other protocols keep the default rules
+	if rs := schedule.At(BRC20Protocol, 200); rs.MaxDecimals != 18 || !rs.MintTruncate || rs.SelfMint {
+		t.Fatalf("unexpected brc-20 rules %+v", rs)
+	}
+	if rs := schedule.At("ethscriptions", 200); rs.MaxDataLength != DefaultMaxDataLength {
+		t.Fatalf("unexpected ethscriptions rules %+v", rs)
+	}
+
+	// nil schedule returns the default rules
+	var empty *RuleSchedule
+	if rs := empty.At(ASC20Protocol, 200); !rs.MaxSupply.Equal(DefaultRuleSet(ASC20Protocol).MaxSupply) {
+		t.Fatalf("unexpected default rules %+v", rs)
+	}
+}
+
+func TestRuleScheduleInvalid(t *testing.T) {
+	invalid := []*config.RuleActivation{
+		{Protocol: "", Rule: RuleMaxDecimals, Value: "8"},
+		{Protocol: ASC20Protocol, Rule: "unknown", Value: "8"},
+		{Protocol: ASC20Protocol, Rule: RuleMaxDecimals, Value: "19"},
+		{Protocol: ASC20Protocol, Rule: RuleMaxSupply, Value: "-1"},
+		{Protocol: ASC20Protocol, Rule: RuleSelfMint, Value: "yes"},
+		{Protocol: ASC20Protocol, Rule: RuleBatchTransfer, Value: "-1"},
+		{Protocol: ASC20Protocol, Rule: RuleBurnAddresses, Value: "0x0000000000000000000000000000000000000000,,"},
+		{Protocol: ASC20Protocol, Rule: RuleDeployParams, Value: "on"},
+		{Protocol: ASC20Protocol, Rule: RuleFreeze, Value: "on"},
+		{Protocol: ASC20Protocol, Rule: RuleSmartAccount, Value: "yes"},
+		{Protocol: ASC20Protocol, Rule: RuleBlobs, Value: "on"},
+		{Protocol: ASC20Protocol, Rule: RuleStrictAmounts, Value: "1.5"},
+	}
+	for _, item := range invalid {
+		if _, err := NewRuleSchedule([]*config.RuleActivation{item}); err == nil {
+			t.Fatalf("expected error for rule %+v", item)
+		}
+	}
+}
+
+func TestRuleBurnAddresses(t *testing.T) {
+	schedule, err := NewRuleSchedule([]*config.RuleActivation{
+		{Protocol: ASC20Protocol, Rule: RuleBurnAddresses, Height: 100, Value: "0x0000000000000000000000000000000000000000, 0x000000000000000000000000000000000000dEaD"},
+	})
+	if err != nil {
+		t.Fatalf("new rule schedule err:%v", err)
+	}
+
+	if schedule.At(ASC20Protocol, 99).IsBurnAddress("0x0000000000000000000000000000000000000000") {
+		t.Fatalf("burn address before activation")
+	}
+
+	rs := schedule.At(ASC20Protocol, 100)
+	if !rs.IsBurnAddress("0x0000000000000000000000000000000000000000") || !rs.IsBurnAddress("0x000000000000000000000000000000000000DEAD") {
+		t.Fatalf("burn addresses not matched %+v", rs.BurnAddresses)
+	}
+	if rs.IsBurnAddress("0x1111111111111111111111111111111111111111") {
+		t.Fatalf("unexpected burn address")
+	}
+}
+
+func TestRuleStrictAmounts(t *testing.T) {
+	schedule, err := NewRuleSchedule([]*config.RuleActivation{
+		{Protocol: ASC20Protocol, Rule: RuleStrictAmounts, Height: 100, Value: "true"},
+	})
+	if err != nil {
+		t.Fatalf("new rule schedule err:%v", err)
+	}
+
+	tests := []struct {
+		data     string
+		decimals int8
+		legacy   bool // valid before the activation
+		strict   bool // valid after the activation
+	}{
+		{`"1.5"`, 2, true, true},
+		{`"1.505"`, 2, true, false},
+		{`"1e3"`, 2, true, false},
+		{`"1000"`, 0, true, true},
+		{`1.50`, 1, true, false},
+	}
+
+	for _, tt := range tests {
+		var amount Amount
+		if err := json.Unmarshal([]byte(tt.data), &amount); err != nil {
+			t.Fatalf("amount[%s] unmarshal err:%v", tt.data, err)
+		}
+		if err := schedule.At(ASC20Protocol, 99).CheckAmount(amount, tt.decimals); (err == nil) != tt.legacy {
+			t.Fatalf("amount[%s] before activation got err[%v], want valid[%v]", tt.data, err, tt.legacy)
+		}
+		if err := schedule.At(ASC20Protocol, 100).CheckAmount(amount, tt.decimals); (err == nil) != tt.strict {
+			t.Fatalf("amount[%s] after activation got err[%v], want valid[%v]", tt.data, err, tt.strict)
+		}
+	}
+	var decimals Decimals
+	if err := json.Unmarshal([]byte(`"18.0"`), &decimals); err != nil {
+		t.Fatalf("decimals unmarshal err:%v", err)
+	}
+	if err := schedule.At(ASC20Protocol, 99).CheckDecimals(decimals); err != nil {
+		t.Fatalf("decimals before activation got err[%v]", err)
+	}
+	if err := schedule.At(ASC20Protocol, 100).CheckDecimals(decimals); err == nil {
+		t.Fatalf("decimals after activation expected error")
+	}
+}
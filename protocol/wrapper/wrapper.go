@@ -0,0 +1,329 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package wrapper
+
+import (
+	"fmt"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/devents"
+	"github.com/uxuycom/indexer/protocol/types"
+	"github.com/uxuycom/indexer/utils"
+	"github.com/uxuycom/indexer/xylog"
+	"math/big"
+	"os"
+	"strings"
+)
+
+type event struct {
+	operate string
+	event   abi.Event
+	account string
+	amount  string
+}
+
+// Adapter maps the erc-20 mint & burn events of a wrapper to wrapped supply changes
+type Adapter struct {
+	name     string
+	protocol string
+	tick     string
+	address  string
+	contract common.Address
+	abi      abi.ABI
+	events   []*event
+	decimals int32
+}
+
+func NewAdapter(cfg *config.WrapperConfig) (*Adapter, error) {
+	file, err := os.Open(cfg.AbiFile)
+	if err != nil {
+		return nil, fmt.Errorf("wrapper[%s] abi file open err:%v", cfg.Name, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	parsedABI, err := abi.JSON(file)
+	if err != nil {
+		return nil, fmt.Errorf("wrapper[%s] abi decode err:%v", cfg.Name, err)
+	}
+	return newAdapter(cfg, parsedABI)
+}
+
+func newAdapter(cfg *config.WrapperConfig, parsedABI abi.ABI) (*Adapter, error) {
+	if strings.TrimSpace(cfg.Name) == "" {
+		return nil, fmt.Errorf("wrapper address[%s] name empty", cfg.Address)
+	}
+
+	if !common.IsHexAddress(cfg.Address) {
+		return nil, fmt.Errorf("wrapper[%s] invalid address[%s]", cfg.Name, cfg.Address)
+	}
+
+	contract := cfg.Contract
+	if contract == "" {
+		contract = cfg.Address
+	}
+	if !common.IsHexAddress(contract) {
+		return nil, fmt.Errorf("wrapper[%s] invalid contract[%s]", cfg.Name, contract)
+	}
+
+	a := &Adapter{
+		name:     cfg.Name,
+		protocol: strings.ToLower(strings.TrimSpace(cfg.Protocol)),
+		tick:     strings.ToLower(strings.TrimSpace(cfg.Tick)),
+		address:  strings.ToLower(cfg.Address),
+		contract: common.HexToAddress(contract),
+		abi:      parsedABI,
+		decimals: cfg.Decimals,
+	}
+	if a.protocol == "" {
+		a.protocol = types.ASC20Protocol
+	}
+	if a.tick == "" {
+		return nil, fmt.Errorf("wrapper[%s] tick empty", cfg.Name)
+	}
+
+	kinds := []struct {
+		operate string
+		cfg     *config.WrapperEventConfig
+	}{
+		{devents.OperateWrapMint, cfg.Mint},
+		{devents.OperateWrapBurn, cfg.Burn},
+	}
+	for _, item := range kinds {
+		if item.cfg == nil {
+			return nil, fmt.Errorf("wrapper[%s] %s event not configured", cfg.Name, item.operate)
+		}
+
+		e, ok := parsedABI.Events[item.cfg.Event]
+		if !ok {
+			return nil, fmt.Errorf("wrapper[%s] %s event[%s] not found in abi", cfg.Name, item.operate, item.cfg.Event)
+		}
+
+		if item.cfg.Amount == "" {
+			return nil, fmt.Errorf("wrapper[%s] %s event amount field empty", cfg.Name, item.operate)
+		}
+		for _, name := range []string{item.cfg.Account, item.cfg.Amount} {
+			if name != "" && !hasInput(e, name) {
+				return nil, fmt.Errorf("wrapper[%s] field[%s] not found in event[%s]", cfg.Name, name, item.cfg.Event)
+			}
+		}
+		a.events = append(a.events, &event{operate: item.operate, event: e, account: item.cfg.Account, amount: item.cfg.Amount})
+	}
+
+	// the mint & burn are told apart by the event topic only
+	if a.events[0].event.ID == a.events[1].event.ID {
+		return nil, fmt.Errorf("wrapper[%s] mint & burn events must differ", cfg.Name)
+	}
+	return a, nil
+}
+
+func hasInput(e abi.Event, name string) bool {
+	for _, input := range e.Inputs {
+		if input.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Adapter) Name() string {
+	return a.name
+}
+
+func (a *Adapter) Protocol() string {
+	return a.protocol
+}
+
+func (a *Adapter) Tick() string {
+	return a.tick
+}
+
+// Address holder of the locked inscription tokens
+func (a *Adapter) Address() string {
+	return a.address
+}
+
+// Contract the erc-20 contract
+func (a *Adapter) Contract() string {
+	return strings.ToLower(a.contract.String())
+}
+
+// Topics the event signature hashes
+func (a *Adapter) Topics() []common.Hash {
+	topics := make([]common.Hash, 0, len(a.events))
+	for _, e := range a.events {
+		topics = append(topics, e.event.ID)
+	}
+	return topics
+}
+
+func (a *Adapter) match(log *xycommon.RpcLog) *event {
+	if len(log.Topics) <= 0 || log.Address != a.contract {
+		return nil
+	}
+
+	for _, e := range a.events {
+		if log.Topics[0] == e.event.ID {
+			return e
+		}
+	}
+	return nil
+}
+
+// Decode the matched log into the wrap operate & its values
+func (a *Adapter) Decode(log *xycommon.RpcLog) (string, *devents.Wrap, error) {
+	e := a.match(log)
+	if e == nil {
+		return "", nil, fmt.Errorf("log is not a wrapper[%s] event", a.name)
+	}
+
+	values := make(map[string]interface{}, len(e.event.Inputs))
+	_, err := utils.ParseEventToMap(a.abi, utils.EventLog{
+		Address: log.Address,
+		Topics:  log.Topics,
+		Data:    log.Data,
+	}, values)
+	if err != nil {
+		return "", nil, err
+	}
+
+	w := &devents.Wrap{
+		Name: a.name,
+	}
+	if e.account != "" {
+		account, ok := values[e.account].(common.Address)
+		if !ok {
+			return "", nil, fmt.Errorf("account field[%s] type %T unsupported", e.account, values[e.account])
+		}
+		w.Account = strings.ToLower(account.String())
+	}
+
+	amount, ok := values[e.amount].(*big.Int)
+	if !ok || amount == nil || amount.Sign() <= 0 {
+		return "", nil, fmt.Errorf("amount field[%s] value[%v] invalid", e.amount, values[e.amount])
+	}
+	w.Amount = decimal.NewFromBigInt(amount, -a.decimals)
+	return e.operate, w, nil
+}
+
+// Registry configured wrappers of the chain
+type Registry struct {
+	adapters []*Adapter
+}
+
+func NewRegistry(items []*config.WrapperConfig) (*Registry, error) {
+	r := &Registry{
+		adapters: make([]*Adapter, 0, len(items)),
+	}
+
+	names := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		adapter, err := NewAdapter(item)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := names[adapter.name]; ok {
+			return nil, fmt.Errorf("wrapper[%s] name duplicated", adapter.name)
+		}
+		names[adapter.name] = struct{}{}
+		r.adapters = append(r.adapters, adapter)
+	}
+	return r, nil
+}
+
+// Adapters the configured wrappers
+func (r *Registry) Adapters() []*Adapter {
+	if r == nil {
+		return nil
+	}
+	return r.adapters
+}
+
+// Topics the event topics of all wrappers, used to filter the chain logs
+func (r *Registry) Topics() []common.Hash {
+	if r == nil {
+		return nil
+	}
+
+	topics := make([]common.Hash, 0, len(r.adapters)*2)
+	for _, adapter := range r.adapters {
+		topics = append(topics, adapter.Topics()...)
+	}
+	return topics
+}
+
+// Match the tx has events of the wrappers
+func (r *Registry) Match(tx *xycommon.RpcTransaction) bool {
+	if r == nil {
+		return false
+	}
+
+	for i := range tx.Events {
+		for _, adapter := range r.adapters {
+			if adapter.match(&tx.Events[i]) != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Parse the wrapper mint & burn events in the tx, in log order
+func (r *Registry) Parse(chain string, block *xycommon.RpcBlock, tx *xycommon.RpcTransaction) []*devents.TxResult {
+	if r == nil || len(tx.Events) <= 0 {
+		return nil
+	}
+
+	items := make([]*devents.TxResult, 0, 1)
+	for i := range tx.Events {
+		for _, adapter := range r.adapters {
+			if adapter.match(&tx.Events[i]) == nil {
+				continue
+			}
+
+			operate, wrap, err := adapter.Decode(&tx.Events[i])
+			if err != nil {
+				xylog.Logger.Infof("tx[%s] - wrapper[%s] event decode err:%v", tx.Hash, adapter.name, err)
+				continue
+			}
+
+			items = append(items, &devents.TxResult{
+				MD: &devents.MetaData{
+					Chain:    chain,
+					Protocol: adapter.protocol,
+					Operate:  operate,
+					Tick:     adapter.tick,
+				},
+				Block: block,
+				Tx:    tx,
+				Wrap:  wrap,
+			})
+		}
+	}
+	return items
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package wrapper
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/uxuycom/indexer/client/xycommon"
+	"github.com/uxuycom/indexer/config"
+	"github.com/uxuycom/indexer/devents"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+const testABI = `[
+  {"anonymous": false, "name": "Wrapped", "type": "event", "inputs": [
+    {"indexed": true, "name": "to", "type": "address"},
+    {"indexed": false, "name": "amount", "type": "uint256"}
+  ]},
+  {"anonymous": false, "name": "Unwrapped", "type": "event", "inputs": [
+    {"indexed": true, "name": "from", "type": "address"},
+    {"indexed": false, "name": "amount", "type": "uint256"}
+  ]}
+]`
+
+func testConfig() *config.WrapperConfig {
+	return &config.WrapperConfig{
+		Name:     "example-wrapper",
+		Tick:     " AVAV ",
+		Address:  "0x1000000000000000000000000000000000000001",
+		Mint:     &config.WrapperEventConfig{Event: "Wrapped", Account: "to", Amount: "amount"},
+		Burn:     &config.WrapperEventConfig{Event: "Unwrapped", Account: "from", Amount: "amount"},
+		Decimals: 2,
+	}
+}
+
+func TestRegistryParse(t *testing.T) {
+	parsedABI, err := abi.JSON(strings.NewReader(testABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	adapter, err := newAdapter(testConfig(), parsedABI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if adapter.Contract() != adapter.Address() || adapter.Tick() != "avav" || adapter.Protocol() != "asc-20" {
+		t.Fatalf("unexpected adapter %+v", adapter)
+	}
+
+	contract := common.HexToAddress("0x1000000000000000000000000000000000000001")
+	holder := common.HexToAddress("0x2000000000000000000000000000000000000002")
+	wrapped := parsedABI.Events["Wrapped"]
+	unwrapped := parsedABI.Events["Unwrapped"]
+	mintData, _ := wrapped.Inputs.NonIndexed().Pack(big.NewInt(12345))
+	burnData, _ := unwrapped.Inputs.NonIndexed().Pack(big.NewInt(100))
+	tx := &xycommon.RpcTransaction{
+		Hash: "0x01",
+		Events: []xycommon.RpcLog{
+			{Address: contract, Topics: []common.Hash{wrapped.ID, common.BytesToHash(holder.Bytes())}, Data: mintData},
+			{Address: holder, Topics: []common.Hash{wrapped.ID, common.BytesToHash(holder.Bytes())}, Data: mintData},
+			{Address: contract, Topics: []common.Hash{unwrapped.ID, common.BytesToHash(holder.Bytes())}, Data: burnData},
+		},
+	}
+
+	r := &Registry{adapters: []*Adapter{adapter}}
+	if !r.Match(tx) || r.Match(&xycommon.RpcTransaction{Events: tx.Events[1:2]}) {
+		t.Fatalf("only events of the wrapper contract must match")
+	}
+
+	items := r.Parse("avalanche", nil, tx)
+	if len(items) != 2 {
+		t.Fatalf("items[%d] != 2, events of other contracts must be ignored", len(items))
+	}
+
+	mint := items[0]
+	if mint.MD.Operate != devents.OperateWrapMint || mint.MD.Tick != "avav" || mint.Wrap.Name != "example-wrapper" ||
+		mint.Wrap.Account != strings.ToLower(holder.String()) || mint.Wrap.Amount.String() != "123.45" {
+		t.Fatalf("unexpected mint %+v %+v", mint.MD, mint.Wrap)
+	}
+
+	burn := items[1]
+	if burn.MD.Operate != devents.OperateWrapBurn || burn.Wrap.Amount.String() != "1" {
+		t.Fatalf("unexpected burn %+v %+v", burn.MD, burn.Wrap)
+	}
+
+	var empty *Registry
+	if len(empty.Parse("avalanche", nil, tx)) != 0 || len(empty.Topics()) != 0 || empty.Match(tx) {
+		t.Fatalf("nil registry must match nothing")
+	}
+}
+
+func TestNewAdapterInvalid(t *testing.T) {
+	parsedABI, _ := abi.JSON(strings.NewReader(testABI))
+	invalid := []func(cfg *config.WrapperConfig){
+		func(cfg *config.WrapperConfig) { cfg.Name = "" },
+		func(cfg *config.WrapperConfig) { cfg.Tick = "" },
+		func(cfg *config.WrapperConfig) { cfg.Address = "0x01" },
+		func(cfg *config.WrapperConfig) { cfg.Burn = nil },
+		func(cfg *config.WrapperConfig) { cfg.Burn.Event = "Wrapped" },
+		func(cfg *config.WrapperConfig) { cfg.Mint.Amount = "qty" },
+	}
+	for i, modify := range invalid {
+		cfg := testConfig()
+		modify(cfg)
+		if _, err := newAdapter(cfg, parsedABI); err == nil {
+			t.Fatalf("wrapper case[%d] %+v must be rejected", i, cfg)
+		}
+	}
+}
@@ -58,6 +58,8 @@ const (
 	SortTpyeProgress   = 2
 	SortTypeHolders    = 3
 	SortTypeTxCnt      = 4
+	SortTypeTurnover   = 5 // 24h turnover in wei
+	SortTypeMarketCap  = 6
 )
 
 type DBClient struct {
@@ -243,6 +245,9 @@ func (conn *DBClient) BatchUpdateInscriptionStats(dbTx *gorm.DB, chain string, i
 		"minted":  "%s",
 		"holders": "%d",
 		"tx_cnt":  "%d",
+		"last_sn": "%d",
+		"burned":  "%s",
+		"bridged": "%s",
 	}
 
 	vals := make([]map[string]interface{}, 0, len(items))
@@ -252,6 +257,9 @@ func (conn *DBClient) BatchUpdateInscriptionStats(dbTx *gorm.DB, chain string, i
 			"minted":  item.Minted,
 			"holders": item.Holders,
 			"tx_cnt":  item.TxCnt,
+			"last_sn": item.LastSN,
+			"burned":  item.Burned,
+			"bridged": item.Bridged,
 		})
 	}
 	err, _ := conn.BatchUpdatesBySID(dbTx, chain, model.InscriptionsStats{}.TableName(), fields, vals)
@@ -328,6 +336,210 @@ func (conn *DBClient) BatchUpdateUTXOs(dbTx *gorm.DB, chain string, items []*mod
 	return nil
 }
 
+func (conn *DBClient) BatchAddEthscriptions(dbTx *gorm.DB, items []*model.Ethscriptions) error {
+	if len(items) < 1 {
+		return nil
+	}
+	return conn.CreateInBatches(dbTx, items, 1000)
+}
+
+func (conn *DBClient) BatchUpdateEthscriptions(dbTx *gorm.DB, chain string, items []*model.Ethscriptions) error {
+	if len(items) < 1 {
+		return nil
+	}
+
+	fields := map[string]string{
+		"owner":          "%s",
+		"previous_owner": "%s",
+	}
+
+	values := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		values = append(values, map[string]interface{}{
+			"ethscription_id": item.EthscriptionId,
+			"owner":           item.Owner,
+			"previous_owner":  item.PreviousOwner,
+		})
+	}
+	err, _ := conn.BatchUpdatesBySIDKey(dbTx, chain, "ethscription_id", model.Ethscriptions{}.TableName(), fields, values)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// BatchAddInscriptionContents content is keyed by sha256, existing contents are kept
+func (conn *DBClient) BatchAddInscriptionContents(dbTx *gorm.DB, items []*model.InscriptionContents) error {
+	if len(items) < 1 {
+		return nil
+	}
+	return dbTx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "sha256"}},
+		DoNothing: true,
+	}).CreateInBatches(items, 100).Error
+}
+
+func (conn *DBClient) BatchAddRunes(dbTx *gorm.DB, items []*model.Runes) error {
+	if len(items) < 1 {
+		return nil
+	}
+	return conn.CreateInBatches(dbTx, items, 1000)
+}
+
+func (conn *DBClient) BatchUpdateRunes(dbTx *gorm.DB, chain string, items []*model.Runes) error {
+	if len(items) < 1 {
+		return nil
+	}
+
+	fields := map[string]string{
+		"mints":  "%v",
+		"supply": "%s",
+		"burned": "%s",
+	}
+
+	values := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		values = append(values, map[string]interface{}{
+			"rune_id": item.RuneId,
+			"mints":   item.Mints,
+			"supply":  item.Supply.String(),
+			"burned":  item.Burned.String(),
+		})
+	}
+	err, _ := conn.BatchUpdatesBySIDKey(dbTx, chain, "rune_id", model.Runes{}.TableName(), fields, values)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (conn *DBClient) BatchAddRuneBalances(dbTx *gorm.DB, items []*model.RuneBalances) error {
+	if len(items) < 1 {
+		return nil
+	}
+	return conn.CreateInBatches(dbTx, items, 1000)
+}
+
+func (conn *DBClient) BatchUpdateRuneBalances(dbTx *gorm.DB, chain string, items []*model.RuneBalances) error {
+	if len(items) < 1 {
+		return nil
+	}
+
+	fields := map[string]string{
+		"status": "%v",
+	}
+
+	values := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		values = append(values, map[string]interface{}{
+			"outpoint": item.Outpoint,
+			"status":   item.Status,
+		})
+	}
+	err, _ := conn.BatchUpdatesBySIDKey(dbTx, chain, "outpoint", model.RuneBalances{}.TableName(), fields, values)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (conn *DBClient) BatchAddRuneEvents(dbTx *gorm.DB, items []*model.RuneEvents) error {
+	if len(items) < 1 {
+		return nil
+	}
+	return conn.CreateInBatches(dbTx, items, 1000)
+}
+
+func (conn *DBClient) BatchAddListings(dbTx *gorm.DB, items []*model.Listings) error {
+	if len(items) < 1 {
+		return nil
+	}
+	return conn.CreateInBatches(dbTx, items, 1000)
+}
+
+// UpsertClosedListings close the listings, a listing whose list inscription was never indexed
+// (e.g. listed before the sync start height) is inserted closed
+func (conn *DBClient) UpsertClosedListings(dbTx *gorm.DB, items []*model.Listings) error {
+	if len(items) < 1 {
+		return nil
+	}
+	return dbTx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "chain"}, {Name: "list_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"status", "buyer", "price", "unit_price", "close_tx_hash", "close_block", "close_timestamp",
+		}),
+	}).CreateInBatches(items, 1000).Error
+}
+
+// UpsertTickCandles insert candles or merge them into the existing ones of the same period
+func (conn *DBClient) UpsertTickCandles(dbTx *gorm.DB, items []*model.TickCandles) error {
+	if len(items) < 1 {
+		return nil
+	}
+	return dbTx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "chain"}, {Name: "protocol"}, {Name: "tick"}, {Name: "period"}, {Name: "open_time"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"high":     gorm.Expr("GREATEST(high, VALUES(high))"),
+			"low":      gorm.Expr("LEAST(low, VALUES(low))"),
+			"close":    gorm.Expr("VALUES(close)"),
+			"volume":   gorm.Expr("volume + VALUES(volume)"),
+			"turnover": gorm.Expr("turnover + VALUES(turnover)"),
+			"trades":   gorm.Expr("trades + VALUES(trades)"),
+		}),
+	}).CreateInBatches(items, 1000).Error
+}
+
+// UpsertBridgeTransfers each side only updates its own columns & fills the empty ones of the other side,
+// the status is evaluated last so it sees the merged row
+func (conn *DBClient) UpsertBridgeTransfers(dbTx *gorm.DB, items []*model.BridgeTransfers) error {
+	fill := func(column string) clause.Assignment {
+		return clause.Assignment{
+			Column: clause.Column{Name: column},
+			Value:  gorm.Expr(fmt.Sprintf("IF(`%s` = '', VALUES(`%s`), `%s`)", column, column, column)),
+		}
+	}
+	status := clause.Assignment{
+		Column: clause.Column{Name: "status"},
+		Value: gorm.Expr("IF(source_tx_hash <> '' AND dest_tx_hash <> '', ?, ?)",
+			model.BridgeTransferStatusSettled, model.BridgeTransferStatusPending),
+	}
+
+	for _, v := range items {
+		var updates clause.Set
+		if v.SourceTxHash != "" {
+			updates = clause.AssignmentColumns([]string{"protocol", "tick", "amount", "sender", "source_chain", "source_contract",
+				"source_tx_hash", "source_block", "source_timestamp"})
+			updates = append(updates, fill("recipient"), fill("dest_chain"))
+		} else {
+			updates = clause.AssignmentColumns([]string{"recipient", "dest_chain", "dest_contract", "dest_tx_hash", "dest_block",
+				"dest_timestamp", "settle_type"})
+			updates = append(updates, fill("sender"), fill("source_chain"), fill("protocol"), fill("tick"))
+		}
+		updates = append(updates, status)
+
+		err := dbTx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "bridge"}, {Name: "bridge_id"}},
+			DoUpdates: updates,
+		}).Create(v).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpsertWrappers save the latest supply & locked balance of the wrappers
+func (conn *DBClient) UpsertWrappers(dbTx *gorm.DB, items []*model.Wrappers) error {
+	if len(items) < 1 {
+		return nil
+	}
+	return dbTx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "chain"}, {Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"protocol", "tick", "address", "contract", "supply", "locked",
+			"block_height"}),
+	}).CreateInBatches(items, 1000).Error
+}
+
 func (conn *DBClient) InsertOrUpdateBalances(dbTx *gorm.DB, items []*model.Balances) error {
 	if len(items) < 1 {
 		return nil
@@ -420,6 +632,47 @@ func (conn *DBClient) FindUserBalanceByTick(chain, protocol, tick, addr string)
 	return balance, nil
 }
 
+// FindLastInscriptionNumber the last assigned chain-wide inscription number, false without numbered txs
+func (conn *DBClient) FindLastInscriptionNumber(chain string) (int64, bool, error) {
+	txn := &model.Transaction{}
+	err := conn.SqlDB.Model(&model.Transaction{}).Select("inscription_number").
+		Where("chain = ? AND inscription_number >= 0", chain).Order("inscription_number desc").First(txn).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+	return txn.InscriptionNumber, true, nil
+}
+
+// FindTransactionByNumber find tx by chain-wide inscription number,
+// a number identifies one tx hash (& smart account sub index), the results of a multi event tx are stored as a single txs row
+func (conn *DBClient) FindTransactionByNumber(chain string, number int64) (*model.Transaction, error) {
+	txn := &model.Transaction{}
+	err := conn.SqlDB.First(txn, "chain = ? AND inscription_number = ?", chain, number).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return txn, nil
+}
+
+// FindMintBySN find mint tx by per-tick mint sequence number
+func (conn *DBClient) FindMintBySN(chain, protocol, tick string, sn uint64) (*model.Transaction, error) {
+	txn := &model.Transaction{}
+	err := conn.SqlDB.First(txn, "chain = ? AND protocol = ? AND tick = ? AND mint_sn = ?", chain, protocol, tick, sn).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return txn, nil
+}
+
 func (conn *DBClient) FindTransaction(chain string, hash common.Hash) (*model.Transaction, error) {
 	txn := &model.Transaction{}
 	query := conn.SqlDB.Model(&model.Transaction{}).Where("tx_hash = " + hash.Hex())
@@ -463,7 +716,7 @@ func (conn *DBClient) GetInscriptions(limit, offset int, chain, protocol, tick,
 		mode = "asc"
 	}
 
-	// sort by  0.id  1.deploy_time  2.progress  3.holders  4.tx_cnt
+	// sort by  0.id  1.deploy_time  2.progress  3.holders  4.tx_cnt  5.24h volume  6.market cap
 	switch sort {
 	case SortTypeId:
 		query = query.Order("`a`.id " + mode)
@@ -475,6 +728,15 @@ func (conn *DBClient) GetInscriptions(limit, offset int, chain, protocol, tick,
 		query = query.Order("holders " + mode)
 	case SortTypeTxCnt:
 		query = query.Order("tx_cnt " + mode)
+	case SortTypeTurnover:
+		// 24h window before the latest indexed block of the chain
+		since := fmt.Sprintf("(SELECT UNIX_TIMESTAMP(b.block_time) FROM `block` AS b WHERE b.chain = `a`.chain LIMIT 1) - %d",
+			model.CandlePeriods[model.CandlePeriod1d])
+		query = query.Order(fmt.Sprintf("(SELECT COALESCE(SUM(c.turnover), 0) FROM `tick_candles` AS c WHERE c.chain = `a`.chain "+
+			"AND c.protocol = `a`.protocol AND c.tick = `a`.tick AND c.period = '%s' AND c.open_time >= %s) %s", model.CandlePeriod1m, since, mode))
+	case SortTypeMarketCap:
+		query = query.Order(fmt.Sprintf("COALESCE((SELECT c.close FROM `tick_candles` AS c WHERE c.chain = `a`.chain "+
+			"AND c.protocol = `a`.protocol AND c.tick = `a`.tick AND c.period = '%s' ORDER BY c.open_time DESC LIMIT 1), 0) * d.minted %s", model.CandlePeriod1m, mode))
 	}
 
 	query = query.Count(&total)
@@ -525,6 +787,37 @@ func (conn *DBClient) GetInscriptionsByIdLimit(chain string, start uint64, limit
 	return inscriptions, nil
 }
 
+// GetMintCappedInscriptions inscriptions deployed with mint caps
+func (conn *DBClient) GetMintCappedInscriptions(chain string) ([]model.Inscriptions, error) {
+	inscriptions := make([]model.Inscriptions, 0)
+	err := conn.SqlDB.Where("chain = ? AND (max_mints_per_address > 0 OR max_mints_per_block > 0)", chain).Find(&inscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+	return inscriptions, nil
+}
+
+// GetMintCountsByAddress mint counts of the tick grouped by minter
+func (conn *DBClient) GetMintCountsByAddress(chain, protocol, tick string) (map[string]uint64, error) {
+	type mintCount struct {
+		Address string
+		Count   uint64
+	}
+	items := make([]mintCount, 0)
+	err := conn.SqlDB.Model(&model.Transaction{}).Select("`to` AS address, COUNT(*) AS count").
+		Where("chain = ? AND protocol = ? AND tick = ? AND op = ?", chain, protocol, tick, "mint").
+		Group("`to`").Scan(&items).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]uint64, len(items))
+	for _, item := range items {
+		counts[item.Address] = item.Count
+	}
+	return counts, nil
+}
+
 func (conn *DBClient) GetInscriptionStatsByIdLimit(chain string, start uint64, limit int) ([]model.InscriptionsStats, error) {
 	stats := make([]model.InscriptionsStats, 0)
 	err := conn.SqlDB.Where("chain = ?", chain).Where("id > ?", start).Order("id asc").Limit(limit).Find(&stats).Error
@@ -796,6 +1089,363 @@ func (conn *DBClient) GetBalancesByIdLimit(chain string, start uint64, limit int
 	return balances, nil
 }
 
+// GetEthscriptionsByIdLimit ethscriptions only, the content objects of token inscriptions are skipped
+func (conn *DBClient) GetEthscriptionsByIdLimit(chain string, start uint64, limit int) ([]model.Ethscriptions, error) {
+	items := make([]model.Ethscriptions, 0, limit)
+	err := conn.SqlDB.Where("chain = ? AND protocol = ''", chain).Where("id > ?", start).Order("id asc").Limit(limit).Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// FindEthscription find inscription content object by id (creation tx hash)
+func (conn *DBClient) FindEthscription(chain, id string) (*model.Ethscriptions, error) {
+	item := &model.Ethscriptions{}
+	err := conn.SqlDB.First(item, "chain = ? AND ethscription_id = ?", chain, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return item, nil
+}
+
+// GetEthscriptionsByOwner list the inscriptions owned by the address, latest first
+func (conn *DBClient) GetEthscriptionsByOwner(limit, offset int, chain, owner string) ([]*model.Ethscriptions, int64, error) {
+	var items []*model.Ethscriptions
+	var total int64
+
+	query := conn.SqlDB.Model(&model.Ethscriptions{}).Where("chain = ? AND owner = ?", chain, owner)
+	query = query.Count(&total)
+	result := query.Order("number desc").Limit(limit).Offset(offset).Find(&items)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return items, total, nil
+}
+
+// FindInscriptionContent find content by sha256
+func (conn *DBClient) FindInscriptionContent(sha256 string) (*model.InscriptionContents, error) {
+	item := &model.InscriptionContents{}
+	err := conn.SqlDB.First(item, "sha256 = ?", sha256).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return item, nil
+}
+
+func (conn *DBClient) GetRunesByIdLimit(chain string, start uint64, limit int) ([]model.Runes, error) {
+	items := make([]model.Runes, 0, limit)
+	err := conn.SqlDB.Where("chain = ?", chain).Where("id > ?", start).Order("id asc").Limit(limit).Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (conn *DBClient) GetUnspentRuneBalancesByIdLimit(chain string, start uint64, limit int) ([]model.RuneBalances, error) {
+	items := make([]model.RuneBalances, 0, limit)
+	err := conn.SqlDB.Where("chain = ? AND status = ?", chain, model.UTXOStatusUnspent).Where("id > ?", start).Order("id asc").Limit(limit).Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetRunes list runes, keyword matches the rune name or spaced rune name
+func (conn *DBClient) GetRunes(limit, offset int, chain, keyword string, sortMode int) ([]*model.Runes, int64, error) {
+	var data []*model.Runes
+	var total int64
+
+	query := conn.SqlDB.Model(&model.Runes{}).Where("chain = ?", chain)
+	if keyword != "" {
+		name := strings.ToUpper(strings.NewReplacer("•", "", ".", "").Replace(keyword))
+		query = query.Where("rune LIKE ? OR spaced_rune LIKE ?", "%"+name+"%", "%"+strings.ToUpper(strings.ReplaceAll(keyword, ".", "•"))+"%")
+	}
+	query = query.Count(&total)
+
+	mode := "desc"
+	if sortMode == OrderByModeAsc {
+		mode = "asc"
+	}
+	result := query.Order("number " + mode).Limit(limit).Offset(offset).Find(&data)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return data, total, nil
+}
+
+// FindRune find rune by rune id, rune name or spaced rune name
+func (conn *DBClient) FindRune(chain, key string) (*model.Runes, error) {
+	item := &model.Runes{}
+	name := strings.ToUpper(strings.NewReplacer("•", "", ".", "").Replace(key))
+	err := conn.SqlDB.Where("chain = ?", chain).
+		Where("rune_id = ? OR rune = ?", key, name).Take(item).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return item, nil
+}
+
+// FindRunesByIds find runes by rune ids
+func (conn *DBClient) FindRunesByIds(chain string, runeIds []string) ([]*model.Runes, error) {
+	items := make([]*model.Runes, 0, len(runeIds))
+	if len(runeIds) == 0 {
+		return items, nil
+	}
+	err := conn.SqlDB.Where("chain = ? AND rune_id IN ?", chain, runeIds).Find(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetRuneHolders aggregate unspent rune balances of a rune by address
+func (conn *DBClient) GetRuneHolders(limit, offset int, chain, runeId string, sortMode int) ([]*model.RuneHolding, int64, error) {
+	var holders []*model.RuneHolding
+	var total int64
+
+	query := conn.SqlDB.Model(&model.RuneBalances{}).
+		Where("chain = ? AND rune_id = ? AND status = ?", chain, runeId, model.UTXOStatusUnspent)
+	if err := query.Distinct("address").Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	orderBy := "amount desc"
+	if sortMode == OrderByModeAsc {
+		orderBy = "amount asc"
+	}
+	result := conn.SqlDB.Model(&model.RuneBalances{}).
+		Select("rune_id, address, SUM(amount) AS amount, COUNT(*) AS utxos").
+		Where("chain = ? AND rune_id = ? AND status = ?", chain, runeId, model.UTXOStatusUnspent).
+		Group("rune_id, address").Order(orderBy + ", address asc").Limit(limit).Offset(offset).Scan(&holders)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return holders, total, nil
+}
+
+// GetRuneBalancesByAddress aggregate unspent rune balances of an address by rune
+func (conn *DBClient) GetRuneBalancesByAddress(chain, address string) ([]*model.RuneHolding, error) {
+	var balances []*model.RuneHolding
+	result := conn.SqlDB.Model(&model.RuneBalances{}).
+		Select("rune_id, address, SUM(amount) AS amount, COUNT(*) AS utxos").
+		Where("chain = ? AND address = ? AND status = ?", chain, address, model.UTXOStatusUnspent).
+		Group("rune_id, address").Order("rune_id asc").Scan(&balances)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return balances, nil
+}
+
+// GetRuneUtxosByAddress list unspent outputs of an address holding runes, optionally filtered by rune id
+func (conn *DBClient) GetRuneUtxosByAddress(limit, offset int, chain, address, runeId string) ([]*model.RuneBalances, int64, error) {
+	var utxos []*model.RuneBalances
+	var total int64
+
+	query := conn.SqlDB.Model(&model.RuneBalances{}).
+		Where("chain = ? AND address = ? AND status = ?", chain, address, model.UTXOStatusUnspent)
+	if runeId != "" {
+		query = query.Where("rune_id = ?", runeId)
+	}
+	query = query.Count(&total)
+
+	result := query.Order("block_height desc, id desc").Limit(limit).Offset(offset).Find(&utxos)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return utxos, total, nil
+}
+
+// GetBridgeTransfers list bridge transfers of a chain, address matches either the sender or the recipient, newest first
+func (conn *DBClient) GetBridgeTransfers(limit, offset int, chain, protocol, tick, address string, status int8) ([]*model.BridgeTransfers, int64, error) {
+	var transfers []*model.BridgeTransfers
+	var total int64
+
+	query := conn.SqlDB.Model(&model.BridgeTransfers{}).Where("(source_chain = ? OR dest_chain = ?)", chain, chain)
+	if protocol != "" {
+		query = query.Where("protocol = ?", protocol)
+	}
+	if tick != "" {
+		query = query.Where("tick = ?", tick)
+	}
+	if address != "" {
+		query = query.Where("(sender = ? OR recipient = ?)", address, address)
+	}
+	if status > 0 {
+		query = query.Where("status = ?", status)
+	}
+
+	query = query.Count(&total)
+	result := query.Order("id desc").Limit(limit).Offset(offset).Find(&transfers)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return transfers, total, nil
+}
+
+// FindBridgeTransfer find a bridge transfer by the bridge & transfer id
+func (conn *DBClient) FindBridgeTransfer(bridge, bridgeId string) (*model.BridgeTransfers, error) {
+	item := &model.BridgeTransfers{}
+	err := conn.SqlDB.First(item, "bridge = ? AND bridge_id = ?", bridge, bridgeId).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return item, nil
+}
+
+// GetBridgeLockedBalances balances of the bridge contracts holding locked tokens of the tick
+func (conn *DBClient) GetBridgeLockedBalances(chain, protocol, tick string) ([]*model.Balances, error) {
+	contracts := conn.SqlDB.Model(&model.BridgeTransfers{}).Distinct("source_contract").
+		Where("source_chain = ? AND protocol = ? AND tick = ?", chain, protocol, tick)
+
+	balances := make([]*model.Balances, 0)
+	err := conn.SqlDB.Where("chain = ? AND protocol = ? AND tick = ? AND address IN (?)", chain, protocol, tick, contracts).
+		Find(&balances).Error
+	if err != nil {
+		return nil, err
+	}
+	return balances, nil
+}
+
+// GetWrappers wrappers of a chain, empty protocol & tick match all
+func (conn *DBClient) GetWrappers(chain, protocol, tick string) ([]*model.Wrappers, error) {
+	query := conn.SqlDB.Where("chain = ?", chain)
+	if protocol != "" {
+		query = query.Where("protocol = ?", protocol)
+	}
+	if tick != "" {
+		query = query.Where("tick = ?", tick)
+	}
+
+	items := make([]*model.Wrappers, 0)
+	if err := query.Order("name").Find(&items).Error; err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetListings list marketplace listings of a tick or a seller by status, newest first
+func (conn *DBClient) GetListings(limit, offset int, chain, protocol, tick, seller string, status int8) ([]*model.Listings, int64, error) {
+	var listings []*model.Listings
+	var total int64
+
+	query := conn.SqlDB.Model(&model.Listings{}).Where("chain = ? AND status = ?", chain, status)
+	if protocol != "" {
+		query = query.Where("protocol = ?", protocol)
+	}
+	if tick != "" {
+		query = query.Where("tick = ?", tick)
+	}
+	if seller != "" {
+		query = query.Where("seller = ?", seller)
+	}
+	query = query.Count(&total)
+
+	order := "close_block desc, id desc"
+	if status == model.ListingStatusActive {
+		order = "list_block desc, id desc"
+	}
+	result := query.Order(order).Limit(limit).Offset(offset).Find(&listings)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return listings, total, nil
+}
+
+// GetListingFills list filled listings of a tick, address matches either the seller or the buyer
+func (conn *DBClient) GetListingFills(limit, offset int, chain, protocol, tick, address string) ([]*model.Listings, int64, error) {
+	var fills []*model.Listings
+	var total int64
+
+	query := conn.SqlDB.Model(&model.Listings{}).Where("chain = ? AND status = ?", chain, model.ListingStatusFilled)
+	if protocol != "" {
+		query = query.Where("protocol = ?", protocol)
+	}
+	if tick != "" {
+		query = query.Where("tick = ?", tick)
+	}
+	if address != "" {
+		query = query.Where("(seller = ? OR buyer = ?)", address, address)
+	}
+	query = query.Count(&total)
+
+	result := query.Order("close_block desc, id desc").Limit(limit).Offset(offset).Find(&fills)
+	if result.Error != nil {
+		return nil, 0, result.Error
+	}
+	return fills, total, nil
+}
+
+// GetTickCandles list candles of a tick between [start, end), in time order
+func (conn *DBClient) GetTickCandles(chain, protocol, tick, period string, start, end int64, limit int) ([]*model.TickCandles, error) {
+	var candles []*model.TickCandles
+	query := conn.SqlDB.Model(&model.TickCandles{}).
+		Where("chain = ? AND protocol = ? AND tick = ? AND period = ?", chain, protocol, tick, period)
+	if start > 0 {
+		query = query.Where("open_time >= ?", start)
+	}
+	if end > 0 {
+		query = query.Where("open_time < ?", end)
+	}
+
+	// the latest candles are returned when the range exceeds the limit
+	result := query.Order("open_time desc").Limit(limit).Find(&candles)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	for i, j := 0, len(candles)-1; i < j; i, j = i+1, j-1 {
+		candles[i], candles[j] = candles[j], candles[i]
+	}
+	return candles, nil
+}
+
+// FindTickCandle find the first or the last candle of a tick since the given time, nil if no trades
+func (conn *DBClient) FindTickCandle(chain, protocol, tick, period string, since int64, last bool) (*model.TickCandles, error) {
+	order := "open_time asc"
+	if last {
+		order = "open_time desc"
+	}
+
+	candle := &model.TickCandles{}
+	err := conn.SqlDB.Model(&model.TickCandles{}).
+		Where("chain = ? AND protocol = ? AND tick = ? AND period = ? AND open_time >= ?", chain, protocol, tick, period, since).
+		Order(order).Take(candle).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return candle, nil
+}
+
+// GetTickMarketSummary aggregate the candles of a tick since the given time
+func (conn *DBClient) GetTickMarketSummary(chain, protocol, tick, period string, since int64) (*model.TickMarketSummary, error) {
+	summary := &model.TickMarketSummary{}
+	result := conn.SqlDB.Model(&model.TickCandles{}).
+		Select("COALESCE(MIN(low), 0) AS low, COALESCE(MAX(high), 0) AS high, COALESCE(SUM(volume), 0) AS volume, "+
+			"COALESCE(SUM(turnover), 0) AS turnover, COALESCE(SUM(trades), 0) AS trades").
+		Where("chain = ? AND protocol = ? AND tick = ? AND period = ? AND open_time >= ?", chain, protocol, tick, period, since).
+		Scan(summary)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return summary, nil
+}
+
 func (conn *DBClient) GetUTXOsByIdLimit(start uint64, limit int) ([]model.UTXO, error) {
 	utxos := make([]model.UTXO, 0, limit)
 	err := conn.SqlDB.Where("id > ? ", start).Where("status = ? ", model.UTXOStatusUnspent).Order("id asc").Limit(limit).Find(&utxos).Error
@@ -1019,28 +1669,3 @@ func (conn *DBClient) FindFirstTxsByBlockTime(chain string, blockTime string) (*
 func (conn *DBClient) DeleteChainStatByChainAndDateHour(chain string, dateHour uint64) error {
 	return conn.SqlDB.Where("chain = ? and date_hour = ?", chain, dateHour).Delete(&model.ChainStatHour{}).Error
 }
-
-func (conn *DBClient) SaveRunes(runes []model.Runes) error {
-	if len(runes) < 1 {
-		return nil
-	}
-	for _, v := range runes {
-		var runes model.Runes
-		err := conn.SqlDB.Where("rune = ?", v.Rune).Find(&runes).Error
-		if err != nil {
-			xylog.Logger.Errorf("find runnes error =%v", err)
-		}
-		if runes.Rune != "" {
-			err := conn.SqlDB.Model(&model.Runes{}).Where("rune = ?", v.Rune).Updates(&v).Error
-			if err != nil {
-				xylog.Logger.Errorf("update runes error =%v", err)
-			}
-		} else {
-			err := conn.SqlDB.Create(&v).Error
-			if err != nil {
-				xylog.Logger.Errorf("insert_option err = %v runes = %v", err, v.Rune)
-			}
-		}
-	}
-	return nil
-}
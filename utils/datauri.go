@@ -0,0 +1,130 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package utils
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+const dataURIScheme = "data:"
+
+// DataURI rfc 2397 data uri, data:[<mediatype>][;<param>=<value>]*[;base64],<data>
+type DataURI struct {
+	MediaType string            // lower case type/subtype, empty when omitted
+	Params    map[string]string // lower case param names, e.g. charset, rule
+	Base64    bool
+	Data      []byte // decoded payload
+}
+
+// Rule the ethscriptions rule param, e.g. esip6
+func (d *DataURI) Rule() string {
+	return strings.ToLower(d.Params["rule"])
+}
+
+// ParseDataURI parses the data uri & decodes its base64 / percent encoded payload
+func ParseDataURI(uri string) (*DataURI, error) {
+	if len(uri) < len(dataURIScheme) || !strings.EqualFold(uri[:len(dataURIScheme)], dataURIScheme) {
+		return nil, fmt.Errorf("data uri scheme not found")
+	}
+
+	idx := strings.Index(uri, ",")
+	if idx == -1 {
+		return nil, fmt.Errorf("data uri separator not found")
+	}
+
+	d := &DataURI{
+		Params: make(map[string]string),
+	}
+	parts := strings.Split(uri[len(dataURIScheme):idx], ";")
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		switch {
+		case i == 0 && !strings.Contains(part, "="):
+			if part != "" && !strings.Contains(part, "/") {
+				return nil, fmt.Errorf("data uri media type[%s] invalid", part)
+			}
+			d.MediaType = strings.ToLower(part)
+		case i == len(parts)-1 && strings.EqualFold(part, "base64"):
+			d.Base64 = true
+		default:
+			kv := strings.SplitN(part, "=", 2)
+			if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+				return nil, fmt.Errorf("data uri param[%s] invalid", part)
+			}
+			d.Params[strings.ToLower(strings.TrimSpace(kv[0]))] = strings.Trim(strings.TrimSpace(kv[1]), `"`)
+		}
+	}
+
+	payload := uri[idx+1:]
+	if d.Base64 {
+		data, err := base64.StdEncoding.DecodeString(payload)
+		if err != nil {
+			// tolerate the unpadded form
+			if data, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(payload, "=")); err != nil {
+				return nil, fmt.Errorf("data uri base64 decode err:%v", err)
+			}
+		}
+		d.Data = data
+		return d, nil
+	}
+
+	data, err := url.PathUnescape(payload)
+	if err != nil {
+		return nil, fmt.Errorf("data uri percent decode err:%v", err)
+	}
+	d.Data = []byte(data)
+	return d, nil
+}
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// IsGzip checks the gzip magic header, ESIP-7 compressed calldata
+func IsGzip(data []byte) bool {
+	return bytes.HasPrefix(data, gzipMagic)
+}
+
+// Gunzip decompresses the data, fails when the output exceeds maxSize bytes
+func Gunzip(data []byte, maxSize int) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("gzip reader err:%v", err)
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	out, err := io.ReadAll(io.LimitReader(r, int64(maxSize)+1))
+	if err != nil {
+		return nil, fmt.Errorf("gzip decompress err:%v", err)
+	}
+	if len(out) > maxSize {
+		return nil, fmt.Errorf("gzip decompressed size > %d", maxSize)
+	}
+	return out, nil
+}
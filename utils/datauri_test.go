@@ -0,0 +1,82 @@
+// Copyright (c) 2023-2024 The UXUY Developer Team
+// License:
+// MIT License
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+//SOFTWARE
+
+package utils
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+)
+
+func TestParseDataURI(t *testing.T) {
+	tests := []struct {
+		uri       string
+		mediaType string
+		base64    bool
+		rule      string
+		data      string
+		wantErr   bool
+	}{
+		{uri: `data:,{"p":"asc-20"}`, data: `{"p":"asc-20"}`},
+		{uri: `data:Application/JSON;charset=utf-8,%7B%22p%22%3A1%7D`, mediaType: "application/json", data: `{"p":1}`},
+		{uri: `data:application/json;rule=esip6;base64,eyJwIjoxfQ==`, mediaType: "application/json", base64: true, rule: "esip6", data: `{"p":1}`},
+		{uri: `data:;base64,eyJwIjoxfQ`, base64: true, data: `{"p":1}`},
+		{uri: `data:text/plain;base64,!!`, wantErr: true},
+		{uri: `data:text/plain;charset,abc`, wantErr: true},
+		{uri: `text/plain,abc`, wantErr: true},
+		{uri: `data:text/plain`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		d, err := ParseDataURI(tt.uri)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("uri[%s] err[%v], wantErr[%v]", tt.uri, err, tt.wantErr)
+		}
+		if err != nil {
+			continue
+		}
+		if d.MediaType != tt.mediaType || d.Base64 != tt.base64 || d.Rule() != tt.rule || string(d.Data) != tt.data {
+			t.Fatalf("uri[%s] parsed %+v, data[%s]", tt.uri, d, d.Data)
+		}
+	}
+}
+
+func TestGunzip(t *testing.T) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, _ = w.Write([]byte(`data:,{"p":"asc-20"}`))
+	_ = w.Close()
+
+	if !IsGzip(buf.Bytes()) || IsGzip([]byte("data:,")) {
+		t.Fatalf("gzip magic checking failed")
+	}
+
+	out, err := Gunzip(buf.Bytes(), 64)
+	if err != nil || string(out) != `data:,{"p":"asc-20"}` {
+		t.Fatalf("gunzip out[%s] err[%v]", out, err)
+	}
+
+	if _, err = Gunzip(buf.Bytes(), 8); err == nil {
+		t.Fatalf("oversize output must be rejected")
+	}
+}
@@ -86,7 +86,18 @@ func (err *InsError) Cause(cause error) error {
 	return err.cause
 }
 
+// WrapCause returns a copy of err carrying cause, the package level errors are shared
+// by concurrently parsing shards and must never be mutated.
 func (err *InsError) WrapCause(cause error) *InsError {
-	err.cause = cause
-	return err
+	return &InsError{
+		code:  err.code,
+		msg:   err.msg,
+		cause: cause,
+	}
+}
+
+// Is matches wrapped copies against the error they were derived from.
+func (err *InsError) Is(target error) bool {
+	t, ok := target.(*InsError)
+	return ok && t.code == err.code && t.msg == err.msg
 }
@@ -36,4 +36,9 @@ func TestWrapErrors(t *testing.T) {
 
 	internalErr := ErrInternal.WrapCause(NewInsError(-19, "test errors 2"))
 	assert.Equal(t, errors.Is(internalErr, ErrInternal), true)
+	assert.Equal(t, errors.Is(internalErr, ErrDataVerifiedFailed), false)
+
+	// the shared sentinels stay untouched
+	assert.Equal(t, ErrDataVerifiedFailed.Error(), "data verified failed:-102:")
+	assert.Equal(t, verifiedErr.Error(), "data verified failed:-102:test errors")
 }